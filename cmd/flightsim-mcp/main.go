@@ -30,13 +30,47 @@ func run() error {
 	defer cancel()
 
 	mgr := state.NewManager(cfg.Polling.StaleThreshold)
-	mcpServer := internalmcp.NewServer(mgr)
+	for group, interval := range groupIntervals(&cfg.Polling) {
+		mgr.SetStaleThreshold(group, cfg.Polling.StaleThresholdFor(interval))
+	}
+	client := simconnect.NewClient(simconnect.Config{
+		Host:    cfg.SimConnect.Host,
+		Port:    cfg.SimConnect.Port,
+		Timeout: cfg.SimConnect.Timeout,
+		AppName: cfg.SimConnect.AppName,
+	})
+	opts := []internalmcp.Option{
+		internalmcp.WithEventTransmitter(client),
+		internalmcp.WithCommandTimeout(cfg.MCP.CommandTimeout),
+		internalmcp.WithDiagnostics(client),
+		internalmcp.WithSimulatorStatus(client),
+		internalmcp.WithSimVarReader(client),
+		internalmcp.WithTrafficReader(client),
+		internalmcp.WithFacilityReader(client),
+		internalmcp.WithWeatherReader(client),
+		internalmcp.WithFlightPlanReader(client),
+	}
+	if cfg.FlightPlan.Dir != "" {
+		opts = append(opts, internalmcp.WithFlightPlanLoader(client, cfg.FlightPlan.Dir, cfg.FlightPlan.SimDir))
+	}
+	if len(cfg.Flights.Dirs) > 0 {
+		opts = append(opts, internalmcp.WithFlightStore(client, cfg.Flights.Dirs))
+	}
+	if cfg.MCP.AllowReposition {
+		opts = append(opts, internalmcp.WithAircraftPositioner(client))
+	}
+	mcpServer := internalmcp.NewServer(mgr, opts...)
+
+	client.OnStateChange(mcpServer.OnConnectionStateChange)
+	readiness := internalmcp.NewReadinessTracker(mgr)
+	client.OnStateChange(readiness.OnConnectionStateChange)
 
-	go runPollerLoop(ctx, &cfg, mgr)
+	connMgr := simconnect.NewConnectionManager(client, pollSession(&cfg, mgr), managerConfig(&cfg.SimConnect))
+	go connMgr.Run(ctx) //nolint:errcheck // returns ctx.Err() on shutdown
 
 	switch cfg.MCP.Transport {
 	case "http":
-		return runHTTP(ctx, &cfg, mcpServer, mgr)
+		return runHTTP(ctx, &cfg, mcpServer, readiness)
 	default:
 		if err := mcpServer.Run(ctx); !errors.Is(err, context.Canceled) {
 			return err
@@ -45,11 +79,11 @@ func run() error {
 	}
 }
 
-func runHTTP(ctx context.Context, cfg *config.Config, srv *internalmcp.Server, mgr *state.Manager) error {
+func runHTTP(ctx context.Context, cfg *config.Config, srv *internalmcp.Server, rc internalmcp.ReadinessChecker) error {
 	mux := http.NewServeMux()
 	mux.Handle("/mcp", srv.Handler())
 	mux.Handle("/health", internalmcp.HealthHandler())
-	mux.Handle("/ready", internalmcp.ReadyHandler(mgr, cfg.Polling.StaleThreshold))
+	mux.Handle("/ready", internalmcp.ReadyHandler(rc, cfg.Polling.StaleThreshold))
 
 	httpServer := &http.Server{
 		Addr:              cfg.MCP.HTTPAddr,
@@ -71,58 +105,81 @@ func runHTTP(ctx context.Context, cfg *config.Config, srv *internalmcp.Server, m
 	return nil
 }
 
-// runPollerLoop connects to SimConnect and polls for data, retrying with
-// exponential backoff (1s → 30s cap) on failure.
-func runPollerLoop(ctx context.Context, cfg *config.Config, mgr *state.Manager) {
-	backoff := time.Second
-	const maxBackoff = 30 * time.Second
-
-	for {
-		if err := ctx.Err(); err != nil {
-			return
-		}
-
-		if err := runPoller(ctx, cfg, mgr); err != nil {
-			if errors.Is(err, context.Canceled) {
-				return
-			}
-			log.Printf("simconnect: disconnected: %v (retrying in %s)", err, backoff)
-		}
-
-		select {
-		case <-ctx.Done():
-			return
-		case <-time.After(backoff):
+// pollSession registers SimVars, subscribes to system events and runs the
+// poller on each connection the connection manager opens. The client is shared
+// across reconnects so MCP tools can transmit events through it.
+func pollSession(cfg *config.Config, mgr *state.Manager) simconnect.Session {
+	return func(ctx context.Context, client *simconnect.Client) error {
+		poller := simconnect.NewPoller(client, mgr, pollerConfig(&cfg.Polling))
+		if err := poller.RegisterSimVars(); err != nil {
+			return err
 		}
-
-		backoff *= 2
-		if backoff > maxBackoff {
-			backoff = maxBackoff
+		if err := poller.SubscribeSystemEvents(); err != nil {
+			return err
 		}
+		return poller.Start(ctx)
 	}
 }
 
-// runPoller creates a client, registers SimVars, and runs the polling loop.
-// Returns when the connection is lost or ctx is done.
-func runPoller(ctx context.Context, cfg *config.Config, mgr *state.Manager) error {
-	client := simconnect.NewClient(simconnect.Config{
-		Host:    cfg.SimConnect.Host,
-		Port:    cfg.SimConnect.Port,
-		Timeout: cfg.SimConnect.Timeout,
-		AppName: cfg.SimConnect.AppName,
-	})
-
-	if err := client.Connect(ctx); err != nil {
-		return err
+// managerConfig maps the SimConnect reconnect and heartbeat settings onto a
+// simconnect.ManagerConfig.
+func managerConfig(cfg *config.SimConnectConfig) simconnect.ManagerConfig {
+	return simconnect.ManagerConfig{
+		Backoff: simconnect.BackoffConfig{
+			InitialInterval: cfg.ReconnectInitial,
+			MaxInterval:     cfg.ReconnectMax,
+			Multiplier:      cfg.ReconnectMultiplier,
+			MaxJitter:       cfg.ReconnectJitter,
+		},
+		Heartbeat: simconnect.HeartbeatConfig{
+			Interval: cfg.HeartbeatInterval,
+			Timeout:  cfg.HeartbeatTimeout,
+		},
 	}
-	defer client.Close() //nolint:errcheck // best-effort cleanup on disconnect
-
-	pollerCfg := simconnect.PollerConfig{PollInterval: cfg.Polling.Interval}
-	poller := simconnect.NewPoller(client, mgr, pollerCfg)
+}
 
-	if err := poller.RegisterSimVars(); err != nil {
-		return err
+// groupIntervals returns the poll interval for each state group.
+func groupIntervals(cfg *config.PollingConfig) map[string]time.Duration {
+	return map[string]time.Duration{
+		state.GroupPosition:       cfg.PositionInterval,
+		state.GroupInstruments:    cfg.InstrumentsInterval,
+		state.GroupEngine:         cfg.EngineInterval,
+		state.GroupEnvironment:    cfg.EnvironmentInterval,
+		state.GroupAutopilot:      cfg.AutopilotInterval,
+		state.GroupAircraft:       cfg.AircraftInterval,
+		state.GroupFlightControls: cfg.ControlsInterval,
+		state.GroupSystems:        cfg.SystemsInterval,
+		state.GroupNavigation:     cfg.NavigationInterval,
 	}
+}
 
-	return poller.Start(ctx)
+// pollerConfig maps the polling settings onto a simconnect.PollerConfig.
+// Unknown modes fall back to once mode and unknown periods to PERIOD_SIM_FRAME.
+func pollerConfig(cfg *config.PollingConfig) simconnect.PollerConfig {
+	pc := simconnect.PollerConfig{
+		PollInterval: cfg.Interval,
+		GroupIntervals: map[uint32]time.Duration{
+			simconnect.DefIDPosition:       cfg.PositionInterval,
+			simconnect.DefIDInstruments:    cfg.InstrumentsInterval,
+			simconnect.DefIDEngine:         cfg.EngineInterval,
+			simconnect.DefIDEnvironment:    cfg.EnvironmentInterval,
+			simconnect.DefIDAutopilot:      cfg.AutopilotInterval,
+			simconnect.DefIDAircraft:       cfg.AircraftInterval,
+			simconnect.DefIDFlightControls: cfg.ControlsInterval,
+			simconnect.DefIDSystems:        cfg.SystemsInterval,
+			simconnect.DefIDNavigation:     cfg.NavigationInterval,
+		},
+		Mode:         simconnect.PollModeOnce,
+		Subscription: simconnect.RequestOptions{Period: simconnect.PeriodSimFrame},
+	}
+	if cfg.Mode == "subscribe" {
+		pc.Mode = simconnect.PollModeSubscribe
+	}
+	switch cfg.Period {
+	case "second":
+		pc.Subscription.Period = simconnect.PeriodSecond
+	case "visual_frame":
+		pc.Subscription.Period = simconnect.PeriodVisualFrame
+	}
+	return pc
 }
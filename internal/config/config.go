@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,12 +12,33 @@ type Config struct {
 	SimConnect SimConnectConfig
 	Polling    PollingConfig
 	MCP        MCPConfig
+	FlightPlan FlightPlanConfig
+	Flights    FlightsConfig
 }
 
 // MCPConfig holds MCP server transport settings.
 type MCPConfig struct {
-	Transport string
-	HTTPAddr  string
+	Transport      string
+	HTTPAddr       string
+	CommandTimeout time.Duration
+
+	// AllowReposition enables set_aircraft_position, which moves the
+	// aircraft instantly; off by default because it is destructive.
+	AllowReposition bool
+}
+
+// FlightPlanConfig holds where load_flight_plan writes .PLN files.
+type FlightPlanConfig struct {
+	Dir    string // local directory; empty disables load_flight_plan
+	SimDir string // Dir as the simulator's machine sees it, e.g. a network share
+}
+
+// FlightsConfig holds where save_flight_situation and load_flight_situation
+// may keep .FLT files.
+type FlightsConfig struct {
+	// Dirs are directories on the simulator's machine; empty disables the
+	// tools. The first is the default.
+	Dirs []string
 }
 
 // SimConnectConfig holds SimConnect TCP connection settings.
@@ -25,30 +47,106 @@ type SimConnectConfig struct {
 	Port    int
 	Timeout time.Duration
 	AppName string
+
+	// Reconnect backoff.
+	ReconnectInitial    time.Duration
+	ReconnectMax        time.Duration
+	ReconnectMultiplier float64
+	ReconnectJitter     time.Duration
+
+	// Heartbeat for detecting silent connections; a zero interval disables it.
+	HeartbeatInterval time.Duration
+	HeartbeatTimeout  time.Duration
 }
 
 // PollingConfig holds data polling settings.
 type PollingConfig struct {
-	Interval       time.Duration
-	StaleThreshold time.Duration
+	Interval       time.Duration // fallback for groups without their own interval
+	StaleThreshold time.Duration // threshold for data without a poll cadence, and for /ready
+	Mode           string        // "once" (default) or "subscribe"
+	Period         string        // "sim_frame", "visual_frame" or "second" (subscribe mode)
+
+	// Per-group poll intervals (fast/medium/slow tiers).
+	PositionInterval    time.Duration
+	InstrumentsInterval time.Duration
+	EngineInterval      time.Duration
+	EnvironmentInterval time.Duration
+	AutopilotInterval   time.Duration
+	AircraftInterval    time.Duration
+	ControlsInterval    time.Duration
+	SystemsInterval     time.Duration
+	NavigationInterval  time.Duration
+}
+
+// StaleMissedPolls is how many consecutive polls a group may miss before its
+// data is considered stale.
+const StaleMissedPolls = 10
+
+// StaleThresholdFor returns the staleness threshold for a group polled every
+// interval: StaleMissedPolls of its own updates, so fast groups go stale
+// quickly. Groups without an interval are polled every Interval. Subscriptions
+// with the "second" period update at most once a second, whatever the
+// interval.
+func (p *PollingConfig) StaleThresholdFor(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		interval = p.Interval
+	}
+	if interval <= 0 {
+		return p.StaleThreshold
+	}
+	if p.Mode == "subscribe" && p.Period == "second" {
+		interval = max(interval.Round(time.Second), time.Second)
+	}
+	return StaleMissedPolls * interval
 }
 
 // Load reads configuration from environment variables, falling back to defaults.
 func Load() Config {
+	planDir := getEnvString("FLIGHT_PLAN_DIR", "")
 	return Config{
 		SimConnect: SimConnectConfig{
 			Host:    getEnvString("SIMCONNECT_HOST", "192.168.10.100"),
 			Port:    getEnvInt("SIMCONNECT_PORT", 4500),
 			Timeout: getEnvDuration("SIMCONNECT_TIMEOUT", 10*time.Second),
 			AppName: getEnvString("SIMCONNECT_APP_NAME", "flightsim-mcp"),
+
+			ReconnectInitial:    getEnvDuration("SIMCONNECT_RECONNECT_INITIAL", time.Second),
+			ReconnectMax:        getEnvDuration("SIMCONNECT_RECONNECT_MAX", 60*time.Second),
+			ReconnectMultiplier: getEnvFloat("SIMCONNECT_RECONNECT_MULTIPLIER", 2.0),
+			ReconnectJitter:     getEnvDuration("SIMCONNECT_RECONNECT_JITTER", time.Second),
+
+			HeartbeatInterval: getEnvDuration("SIMCONNECT_HEARTBEAT_INTERVAL", 5*time.Second),
+			HeartbeatTimeout:  getEnvDuration("SIMCONNECT_HEARTBEAT_TIMEOUT", 15*time.Second),
 		},
 		Polling: PollingConfig{
 			Interval:       getEnvDuration("POLL_INTERVAL", 500*time.Millisecond),
 			StaleThreshold: getEnvDuration("STALE_THRESHOLD", 5*time.Second),
+			Mode:           getEnvString("POLL_MODE", "once"),
+			Period:         getEnvString("POLL_PERIOD", "sim_frame"),
+
+			PositionInterval:    getEnvDuration("POLL_INTERVAL_POSITION", 50*time.Millisecond),
+			InstrumentsInterval: getEnvDuration("POLL_INTERVAL_INSTRUMENTS", 200*time.Millisecond),
+			EngineInterval:      getEnvDuration("POLL_INTERVAL_ENGINE", 200*time.Millisecond),
+			EnvironmentInterval: getEnvDuration("POLL_INTERVAL_ENVIRONMENT", 1000*time.Millisecond),
+			AutopilotInterval:   getEnvDuration("POLL_INTERVAL_AUTOPILOT", 200*time.Millisecond),
+			AircraftInterval:    getEnvDuration("POLL_INTERVAL_AIRCRAFT", 5*time.Second),
+			ControlsInterval:    getEnvDuration("POLL_INTERVAL_CONTROLS", 200*time.Millisecond),
+			SystemsInterval:     getEnvDuration("POLL_INTERVAL_SYSTEMS", 1000*time.Millisecond),
+			NavigationInterval:  getEnvDuration("POLL_INTERVAL_NAVIGATION", 500*time.Millisecond),
 		},
 		MCP: MCPConfig{
-			Transport: getEnvString("MCP_TRANSPORT", "stdio"),
-			HTTPAddr:  getEnvString("MCP_HTTP_ADDR", ":8080"),
+			Transport:      getEnvString("MCP_TRANSPORT", "stdio"),
+			HTTPAddr:       getEnvString("MCP_HTTP_ADDR", ":8080"),
+			CommandTimeout: getEnvDuration("MCP_COMMAND_TIMEOUT", 3*time.Second),
+
+			AllowReposition: getEnvBool("ALLOW_AIRCRAFT_REPOSITION", false),
+		},
+		FlightPlan: FlightPlanConfig{
+			Dir:    planDir,
+			SimDir: getEnvString("FLIGHT_PLAN_SIM_DIR", planDir),
+		},
+		Flights: FlightsConfig{
+			Dirs: getEnvList("FLIGHT_SITUATION_DIRS"),
 		},
 	}
 }
@@ -60,6 +158,17 @@ func getEnvString(key, defaultVal string) string {
 	return defaultVal
 }
 
+// getEnvList splits a comma-separated variable, dropping empty entries.
+func getEnvList(key string) []string {
+	var list []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
 func getEnvInt(key string, defaultVal int) int {
 	v := os.Getenv(key)
 	if v == "" {
@@ -72,6 +181,30 @@ func getEnvInt(key string, defaultVal int) int {
 	return n
 }
 
+func getEnvFloat(key string, defaultVal float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultVal
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return defaultVal
+	}
+	return f
+}
+
+func getEnvBool(key string, defaultVal bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultVal
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return defaultVal
+	}
+	return b
+}
+
 func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
 	v := os.Getenv(key)
 	if v == "" {
@@ -13,10 +13,32 @@ func TestLoadDefaults(t *testing.T) {
 	assert.Equal(t, 4500, cfg.SimConnect.Port)
 	assert.Equal(t, 10*time.Second, cfg.SimConnect.Timeout)
 	assert.Equal(t, "flightsim-mcp", cfg.SimConnect.AppName)
+	assert.Equal(t, time.Second, cfg.SimConnect.ReconnectInitial)
+	assert.Equal(t, 60*time.Second, cfg.SimConnect.ReconnectMax)
+	assert.Equal(t, 2.0, cfg.SimConnect.ReconnectMultiplier)
+	assert.Equal(t, time.Second, cfg.SimConnect.ReconnectJitter)
+	assert.Equal(t, 5*time.Second, cfg.SimConnect.HeartbeatInterval)
+	assert.Equal(t, 15*time.Second, cfg.SimConnect.HeartbeatTimeout)
 	assert.Equal(t, 500*time.Millisecond, cfg.Polling.Interval)
 	assert.Equal(t, 5*time.Second, cfg.Polling.StaleThreshold)
+	assert.Equal(t, "once", cfg.Polling.Mode)
+	assert.Equal(t, "sim_frame", cfg.Polling.Period)
+	assert.Equal(t, 50*time.Millisecond, cfg.Polling.PositionInterval)
+	assert.Equal(t, 200*time.Millisecond, cfg.Polling.InstrumentsInterval)
+	assert.Equal(t, 200*time.Millisecond, cfg.Polling.EngineInterval)
+	assert.Equal(t, 1000*time.Millisecond, cfg.Polling.EnvironmentInterval)
+	assert.Equal(t, 200*time.Millisecond, cfg.Polling.AutopilotInterval)
+	assert.Equal(t, 5*time.Second, cfg.Polling.AircraftInterval)
+	assert.Equal(t, 200*time.Millisecond, cfg.Polling.ControlsInterval)
+	assert.Equal(t, 1000*time.Millisecond, cfg.Polling.SystemsInterval)
+	assert.Equal(t, 500*time.Millisecond, cfg.Polling.NavigationInterval)
 	assert.Equal(t, "stdio", cfg.MCP.Transport)
 	assert.Equal(t, ":8080", cfg.MCP.HTTPAddr)
+	assert.Equal(t, 3*time.Second, cfg.MCP.CommandTimeout)
+	assert.False(t, cfg.MCP.AllowReposition)
+	assert.Empty(t, cfg.FlightPlan.Dir)
+	assert.Empty(t, cfg.FlightPlan.SimDir)
+	assert.Empty(t, cfg.Flights.Dirs)
 }
 
 func TestLoadFromEnv(t *testing.T) {
@@ -42,6 +64,30 @@ func TestLoadFromEnv(t *testing.T) {
 				assert.Equal(t, 9999, cfg.SimConnect.Port)
 			},
 		},
+		{
+			name:   "SIMCONNECT_RECONNECT_MULTIPLIER valid",
+			envKey: "SIMCONNECT_RECONNECT_MULTIPLIER",
+			envVal: "1.5",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 1.5, cfg.SimConnect.ReconnectMultiplier)
+			},
+		},
+		{
+			name:   "SIMCONNECT_RECONNECT_MULTIPLIER invalid falls back to default",
+			envKey: "SIMCONNECT_RECONNECT_MULTIPLIER",
+			envVal: "fast",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 2.0, cfg.SimConnect.ReconnectMultiplier)
+			},
+		},
+		{
+			name:   "SIMCONNECT_HEARTBEAT_INTERVAL",
+			envKey: "SIMCONNECT_HEARTBEAT_INTERVAL",
+			envVal: "0s",
+			check: func(t *testing.T, cfg Config) {
+				assert.Zero(t, cfg.SimConnect.HeartbeatInterval)
+			},
+		},
 		{
 			name:   "SIMCONNECT_PORT invalid falls back to default",
 			envKey: "SIMCONNECT_PORT",
@@ -98,6 +144,78 @@ func TestLoadFromEnv(t *testing.T) {
 				assert.Equal(t, 10*time.Second, cfg.Polling.StaleThreshold)
 			},
 		},
+		{
+			name:   "POLL_MODE subscribe",
+			envKey: "POLL_MODE",
+			envVal: "subscribe",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, "subscribe", cfg.Polling.Mode)
+			},
+		},
+		{
+			name:   "POLL_PERIOD second",
+			envKey: "POLL_PERIOD",
+			envVal: "second",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, "second", cfg.Polling.Period)
+			},
+		},
+		{
+			name:   "POLL_INTERVAL_POSITION valid",
+			envKey: "POLL_INTERVAL_POSITION",
+			envVal: "100ms",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 100*time.Millisecond, cfg.Polling.PositionInterval)
+			},
+		},
+		{
+			name:   "POLL_INTERVAL_ENVIRONMENT valid",
+			envKey: "POLL_INTERVAL_ENVIRONMENT",
+			envVal: "10s",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 10*time.Second, cfg.Polling.EnvironmentInterval)
+			},
+		},
+		{
+			name:   "POLL_INTERVAL_AUTOPILOT invalid falls back to default",
+			envKey: "POLL_INTERVAL_AUTOPILOT",
+			envVal: "fast",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 200*time.Millisecond, cfg.Polling.AutopilotInterval)
+			},
+		},
+		{
+			name:   "POLL_INTERVAL_AIRCRAFT valid",
+			envKey: "POLL_INTERVAL_AIRCRAFT",
+			envVal: "30s",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 30*time.Second, cfg.Polling.AircraftInterval)
+			},
+		},
+		{
+			name:   "POLL_INTERVAL_CONTROLS valid",
+			envKey: "POLL_INTERVAL_CONTROLS",
+			envVal: "100ms",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 100*time.Millisecond, cfg.Polling.ControlsInterval)
+			},
+		},
+		{
+			name:   "POLL_INTERVAL_SYSTEMS valid",
+			envKey: "POLL_INTERVAL_SYSTEMS",
+			envVal: "2s",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 2*time.Second, cfg.Polling.SystemsInterval)
+			},
+		},
+		{
+			name:   "POLL_INTERVAL_NAVIGATION valid",
+			envKey: "POLL_INTERVAL_NAVIGATION",
+			envVal: "250ms",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 250*time.Millisecond, cfg.Polling.NavigationInterval)
+			},
+		},
 		{
 			name:   "MCP_TRANSPORT set to http",
 			envKey: "MCP_TRANSPORT",
@@ -114,6 +232,55 @@ func TestLoadFromEnv(t *testing.T) {
 				assert.Equal(t, ":9090", cfg.MCP.HTTPAddr)
 			},
 		},
+		{
+			name:   "MCP_COMMAND_TIMEOUT valid",
+			envKey: "MCP_COMMAND_TIMEOUT",
+			envVal: "750ms",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, 750*time.Millisecond, cfg.MCP.CommandTimeout)
+			},
+		},
+		{
+			name:   "FLIGHT_PLAN_DIR also sets the simulator's directory",
+			envKey: "FLIGHT_PLAN_DIR",
+			envVal: "/srv/plans",
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, "/srv/plans", cfg.FlightPlan.Dir)
+				assert.Equal(t, "/srv/plans", cfg.FlightPlan.SimDir)
+			},
+		},
+		{
+			name:   "FLIGHT_PLAN_SIM_DIR",
+			envKey: "FLIGHT_PLAN_SIM_DIR",
+			envVal: `\\nas\plans`,
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, `\\nas\plans`, cfg.FlightPlan.SimDir)
+			},
+		},
+		{
+			name:   "FLIGHT_SITUATION_DIRS",
+			envKey: "FLIGHT_SITUATION_DIRS",
+			envVal: ` C:\Flights\Training , ,D:\Checkrides`,
+			check: func(t *testing.T, cfg Config) {
+				assert.Equal(t, []string{`C:\Flights\Training`, `D:\Checkrides`}, cfg.Flights.Dirs)
+			},
+		},
+		{
+			name:   "ALLOW_AIRCRAFT_REPOSITION",
+			envKey: "ALLOW_AIRCRAFT_REPOSITION",
+			envVal: "true",
+			check: func(t *testing.T, cfg Config) {
+				assert.True(t, cfg.MCP.AllowReposition)
+			},
+		},
+		{
+			name:   "ALLOW_AIRCRAFT_REPOSITION invalid falls back to default",
+			envKey: "ALLOW_AIRCRAFT_REPOSITION",
+			envVal: "sure",
+			check: func(t *testing.T, cfg Config) {
+				assert.False(t, cfg.MCP.AllowReposition)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -124,3 +291,26 @@ func TestLoadFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestStaleThresholdFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      PollingConfig
+		interval time.Duration
+		want     time.Duration
+	}{
+		{"fast group goes stale before STALE_THRESHOLD", PollingConfig{StaleThreshold: 5 * time.Second}, 50 * time.Millisecond, 500 * time.Millisecond},
+		{"medium group", PollingConfig{StaleThreshold: 5 * time.Second}, 200 * time.Millisecond, 2 * time.Second},
+		{"slow group", PollingConfig{StaleThreshold: 5 * time.Second}, 10 * time.Second, 100 * time.Second},
+		{"no interval uses POLL_INTERVAL", PollingConfig{StaleThreshold: 5 * time.Second, Interval: 300 * time.Millisecond}, 0, 3 * time.Second},
+		{"no cadence at all", PollingConfig{StaleThreshold: 5 * time.Second}, 0, 5 * time.Second},
+		{"second period", PollingConfig{Mode: "subscribe", Period: "second"}, 50 * time.Millisecond, 10 * time.Second},
+		{"second period rounds", PollingConfig{Mode: "subscribe", Period: "second"}, 2400 * time.Millisecond, 20 * time.Second},
+		{"second period only in subscribe mode", PollingConfig{Mode: "once", Period: "second"}, 50 * time.Millisecond, 500 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cfg.StaleThresholdFor(tt.interval))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package geo provides great-circle navigation math on a spherical Earth,
+// accurate enough for traffic, airport and positioning tools.
+package geo
+
+import "math"
+
+// EarthRadiusNM is the mean Earth radius in nautical miles.
+const EarthRadiusNM = 3440.065
+
+// MetersPerNM is the number of meters in a nautical mile.
+const MetersPerNM = 1852.0
+
+// MetersPerFoot is the number of meters in an international foot.
+const MetersPerFoot = 0.3048
+
+func rad(deg float64) float64 { return deg * math.Pi / 180 }
+func deg(rad float64) float64 { return rad * 180 / math.Pi }
+
+// DistanceNM returns the great-circle distance between two points in
+// nautical miles, using the haversine formula.
+func DistanceNM(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := rad(lat2 - lat1)
+	dLon := rad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadiusNM * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BearingDeg returns the initial true bearing from the first point to the
+// second in degrees, 0 to 360.
+func BearingDeg(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := rad(lat1), rad(lat2)
+	dLon := rad(lon2 - lon1)
+	y := math.Sin(dLon) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLon)
+	return NormalizeDeg(deg(math.Atan2(y, x)))
+}
+
+// Destination returns the point reached by travelling distanceNM from the
+// given point on the given true bearing.
+func Destination(lat, lon, bearingDeg, distanceNM float64) (float64, float64) {
+	phi1, lambda1 := rad(lat), rad(lon)
+	theta := rad(bearingDeg)
+	delta := distanceNM / EarthRadiusNM
+
+	phi2 := math.Asin(math.Sin(phi1)*math.Cos(delta) + math.Cos(phi1)*math.Sin(delta)*math.Cos(theta))
+	lambda2 := lambda1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(phi1),
+		math.Cos(delta)-math.Sin(phi1)*math.Sin(phi2))
+	return deg(phi2), NormalizeLon(deg(lambda2))
+}
+
+// ExtendedCenterline returns the point distanceNM before a runway threshold
+// on the runway's extended centerline, for a runway landed on the true
+// heading runwayHeadingDeg.
+func ExtendedCenterline(thresholdLat, thresholdLon, runwayHeadingDeg, distanceNM float64) (float64, float64) {
+	return Destination(thresholdLat, thresholdLon, runwayHeadingDeg+180, distanceNM)
+}
+
+// GlidePathHeightFt returns the height in feet above a runway threshold of a
+// glide path of glidePathDeg at distanceNM from the threshold.
+func GlidePathHeightFt(distanceNM, glidePathDeg float64) float64 {
+	return distanceNM * MetersPerNM / MetersPerFoot * math.Tan(rad(glidePathDeg))
+}
+
+// NormalizeDeg wraps an angle into [0, 360).
+func NormalizeDeg(d float64) float64 {
+	d = math.Mod(d, 360)
+	if d < 0 {
+		d += 360
+	}
+	return d
+}
+
+// RelativeDeg returns the signed angle from reference to d in (-180, 180],
+// positive clockwise.
+func RelativeDeg(d, reference float64) float64 {
+	r := NormalizeDeg(d - reference)
+	if r > 180 {
+		r -= 360
+	}
+	return r
+}
+
+// NormalizeLon wraps a longitude into [-180, 180).
+func NormalizeLon(lon float64) float64 {
+	return NormalizeDeg(lon+180) - 180
+}
@@ -0,0 +1,89 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistanceNM(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want, delta            float64
+	}{
+		{"same point", 47.4502, -122.3088, 47.4502, -122.3088, 0, 1e-9},
+		{"one degree of latitude", 0, 0, 1, 0, 60.04, 0.01},
+		{"KSEA to KPDX", 47.4502, -122.3088, 45.5887, -122.5975, 112, 1},
+		{"across the antimeridian", 0, 179.5, 0, -179.5, 60.04, 0.01},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, DistanceNM(tt.lat1, tt.lon1, tt.lat2, tt.lon2), tt.delta)
+		})
+	}
+}
+
+func TestBearingDeg(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want                   float64
+	}{
+		{"north", 0, 0, 1, 0, 0},
+		{"east", 0, 0, 0, 1, 90},
+		{"south", 1, 0, 0, 0, 180},
+		{"west", 0, 1, 0, 0, 270},
+		{"east across the antimeridian", 0, 179.5, 0, -179.5, 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, BearingDeg(tt.lat1, tt.lon1, tt.lat2, tt.lon2), 1e-6)
+		})
+	}
+}
+
+func TestDestinationRoundTrip(t *testing.T) {
+	lat, lon := Destination(47.4502, -122.3088, 163, 0.5)
+	assert.InDelta(t, 0.5, DistanceNM(47.4502, -122.3088, lat, lon), 1e-6)
+	assert.InDelta(t, 163, BearingDeg(47.4502, -122.3088, lat, lon), 1e-3)
+
+	lat, lon = Destination(0, 179.9, 90, 60)
+	assert.InDelta(t, 0, lat, 1e-9)
+	assert.InDelta(t, -179.1, lon, 0.01)
+}
+
+func TestRelativeDeg(t *testing.T) {
+	assert.InDelta(t, 0, RelativeDeg(90, 90), 1e-9)
+	assert.InDelta(t, 20, RelativeDeg(10, 350), 1e-9)
+	assert.InDelta(t, -20, RelativeDeg(350, 10), 1e-9)
+	assert.InDelta(t, 180, RelativeDeg(270, 90), 1e-9)
+	assert.InDelta(t, 0, NormalizeDeg(360), 1e-9)
+	assert.InDelta(t, -179, NormalizeLon(181), 1e-9)
+}
+
+func TestExtendedCenterline(t *testing.T) {
+	// 10 NM final for a runway landed on 163° true.
+	lat, lon := ExtendedCenterline(47.4638, -122.3081, 163, 10)
+	assert.InDelta(t, 10, DistanceNM(lat, lon, 47.4638, -122.3081), 1e-6)
+	assert.InDelta(t, 163, BearingDeg(lat, lon, 47.4638, -122.3081), 0.1)
+	assert.Greater(t, lat, 47.4638, "a final for a southbound runway lies to the north")
+}
+
+func TestGlidePathHeightFt(t *testing.T) {
+	tests := []struct {
+		name                     string
+		distanceNM, glidePathDeg float64
+		want                     float64
+	}{
+		{"threshold", 0, 3, 0},
+		{"3 degrees at 10 NM", 10, 3, 3184},
+		{"3 degrees at 1 NM", 1, 3, 318},
+		{"steep approach", 5, 5.5, 2925},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, GlidePathHeightFt(tt.distanceNM, tt.glidePathDeg), 1)
+		})
+	}
+}
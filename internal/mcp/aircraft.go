@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AircraftInfoResponse is the JSON payload returned by get_aircraft_info.
+type AircraftInfoResponse struct {
+	Title           string  `json:"title"`
+	ATCID           string  `json:"atc_id"`
+	ATCAirline      string  `json:"atc_airline,omitempty"`
+	ATCFlightNumber string  `json:"atc_flight_number,omitempty"`
+	ATCModel        string  `json:"atc_model"`
+	Category        string  `json:"category"`
+	EngineType      string  `json:"engine_type"`
+	NumberOfEngines int     `json:"number_of_engines"`
+	MaxGrossWeight  float64 `json:"max_gross_weight_lbs"`
+	DesignSpeedVS0  float64 `json:"design_speed_vs0_kts"`
+	DesignSpeedVS1  float64 `json:"design_speed_vs1_kts"`
+	DesignSpeedVC   float64 `json:"design_speed_vc_kts"`
+	DesignSpeedVMO  float64 `json:"design_speed_vmo_kts"`
+	Timestamp       string  `json:"timestamp"`
+}
+
+// engineTypeNames maps the ENGINE TYPE enum to names.
+var engineTypeNames = []string{"piston", "jet", "none", "helo_turbine", "unsupported", "turboprop"}
+
+// engineTypeName returns the name of an ENGINE TYPE enum value.
+func engineTypeName(v float64) string {
+	if i := int(v); i >= 0 && i < len(engineTypeNames) && float64(i) == v {
+		return engineTypeNames[i]
+	}
+	return "unsupported"
+}
+
+func (s *Server) handleGetAircraftInfo(
+	_ context.Context,
+	_ *mcpsdk.CallToolRequest,
+	_ emptyInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	info, err := s.state.GetAircraftInfo()
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	return s.jsonResult(AircraftInfoResponse{
+		Title:           info.Title,
+		ATCID:           info.ATCID,
+		ATCAirline:      info.ATCAirline,
+		ATCFlightNumber: info.ATCFlightNumber,
+		ATCModel:        info.ATCModel,
+		Category:        info.Category,
+		EngineType:      engineTypeName(info.EngineType),
+		NumberOfEngines: int(info.NumberOfEngines),
+		MaxGrossWeight:  info.MaxGrossWeight,
+		DesignSpeedVS0:  info.DesignSpeedVS0,
+		DesignSpeedVS1:  info.DesignSpeedVS1,
+		DesignSpeedVC:   info.DesignSpeedVC,
+		DesignSpeedVMO:  info.DesignSpeedVMO,
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,68 @@
+package mcp_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func TestGetAircraftInfo(t *testing.T) {
+	sg := &mockStateGetter{acft: types.AircraftInfo{
+		Title:           "Airbus A320neo Asobo",
+		ATCID:           "D-AINA",
+		ATCAirline:      "Lufthansa",
+		ATCFlightNumber: "400",
+		ATCModel:        "A20N",
+		Category:        "Airplane",
+		EngineType:      1,
+		NumberOfEngines: 2,
+		MaxGrossWeight:  174165,
+		DesignSpeedVS0:  105,
+		DesignSpeedVS1:  120,
+		DesignSpeedVC:   300,
+		DesignSpeedVMO:  350,
+	}}
+	res := callTool(t, sg, "get_aircraft_info", nil)
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, "Airbus A320neo Asobo", m["title"])
+	assert.Equal(t, "D-AINA", m["atc_id"])
+	assert.Equal(t, "Lufthansa", m["atc_airline"])
+	assert.Equal(t, "400", m["atc_flight_number"])
+	assert.Equal(t, "A20N", m["atc_model"])
+	assert.Equal(t, "jet", m["engine_type"])
+	assert.Equal(t, 2.0, m["number_of_engines"])
+	assert.Equal(t, 174165.0, m["max_gross_weight_lbs"])
+	assert.Equal(t, 350.0, m["design_speed_vmo_kts"])
+	assert.Contains(t, m, "timestamp")
+}
+
+func TestGetAircraftInfoEngineTypes(t *testing.T) {
+	tests := []struct {
+		engineType float64
+		want       string
+	}{
+		{0, "piston"},
+		{3, "helo_turbine"},
+		{5, "turboprop"},
+		{9, "unsupported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			res := callTool(t, &mockStateGetter{acft: types.AircraftInfo{EngineType: tt.engineType}}, "get_aircraft_info", nil)
+			require.False(t, res.IsError)
+			assert.Equal(t, tt.want, parseJSON(t, res)["engine_type"])
+		})
+	}
+}
+
+func TestGetAircraftInfoStale(t *testing.T) {
+	res := callTool(t, &mockStateGetter{err: state.ErrStale}, "get_aircraft_info", nil)
+	require.True(t, res.IsError)
+	assert.Equal(t, "DATA_STALE", parseJSON(t, res)["code"])
+}
@@ -0,0 +1,315 @@
+package mcp
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// FacilityReader reads airports and navaids from the simulator's facility
+// database. Implemented by simconnect.Client.
+type FacilityReader interface {
+	AirportInfo(ctx context.Context, icao string) (types.Airport, error)
+	ListFacilities(ctx context.Context, listType simconnect.FacilityListType) ([]types.Facility, error)
+}
+
+const (
+	defaultNearestAirports = 5
+	maxNearestAirports     = 20
+)
+
+// icaoPattern matches airport idents: ICAO codes and the shorter or numbered
+// idents of small fields, e.g. KSEA, 0S9 or W39.
+var icaoPattern = regexp.MustCompile(`^[A-Z0-9]{2,6}$`)
+
+// runwaySurfaces names the facility SURFACE enum values.
+var runwaySurfaces = map[int]string{
+	0: "concrete", 1: "grass", 2: "water", 3: "grass_bumpy", 4: "asphalt",
+	5: "short_grass", 6: "long_grass", 7: "hard_turf", 8: "snow", 9: "ice",
+	10: "urban", 11: "forest", 12: "dirt", 13: "coral", 14: "gravel",
+	15: "oil_treated", 16: "steel_mats", 17: "bituminous", 18: "brick",
+	19: "macadam", 20: "planks", 21: "sand", 22: "shale", 23: "tarmac",
+}
+
+// frequencyTypes names the facility frequency TYPE enum values.
+var frequencyTypes = map[int]string{
+	1: "atis", 2: "multicom", 3: "unicom", 4: "ctaf", 5: "ground", 6: "tower",
+	7: "clearance", 8: "approach", 9: "departure", 10: "center", 11: "fss",
+	12: "awos", 13: "asos", 14: "clearance_pre_taxi", 15: "remote_clearance_delivery",
+}
+
+// runwayDesignators are the suffixes of the facility designator enum values.
+var runwayDesignators = []string{"", "L", "R", "C", "W", "A", "B"}
+
+// runwayDirections name runway numbers 37-44, used for fields with compass-point runways.
+var runwayDirections = []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// --- Input structs ---
+
+type getAirportInfoInput struct {
+	ICAO string `json:"icao" jsonschema:"airport ident, e.g. KSEA or EGLL"`
+}
+
+type findNearestAirportsInput struct {
+	Count         int     `json:"count,omitempty" jsonschema:"how many airports to return, 1-20 (default 5)"`
+	MaxDistanceNM float64 `json:"max_distance_nm,omitempty" jsonschema:"only return airports within this distance in nautical miles"`
+}
+
+// --- Response structs ---
+
+// ILSJSON is the localizer serving a runway end.
+type ILSJSON struct {
+	Ident         string  `json:"ident"`
+	Name          string  `json:"name,omitempty"`
+	FrequencyMHz  float64 `json:"frequency_mhz,omitempty"`
+	CourseDeg     float64 `json:"course_deg,omitempty"`
+	HasGlideslope bool    `json:"has_glideslope"`
+	GlideslopeDeg float64 `json:"glideslope_deg,omitempty"`
+}
+
+// RunwayEndJSON is one landing direction of a runway.
+type RunwayEndJSON struct {
+	Runway         string   `json:"runway"`
+	HeadingTrueDeg float64  `json:"heading_true_deg"`
+	ILS            *ILSJSON `json:"ils,omitempty"`
+}
+
+// RunwayJSON is a runway with both of its ends.
+type RunwayJSON struct {
+	Designation string          `json:"designation"`
+	LengthFt    float64         `json:"length_ft"`
+	WidthFt     float64         `json:"width_ft"`
+	Surface     string          `json:"surface"`
+	Ends        []RunwayEndJSON `json:"ends"`
+}
+
+// AirportFrequencyJSON is a published airport frequency.
+type AirportFrequencyJSON struct {
+	Type         string  `json:"type"`
+	Name         string  `json:"name"`
+	FrequencyMHz float64 `json:"frequency_mhz"`
+}
+
+// AirportInfoResponse is the JSON payload returned by get_airport_info.
+// Distance and bearing are from the user aircraft and omitted when its
+// position is unavailable.
+type AirportInfoResponse struct {
+	ICAO        string                 `json:"icao"`
+	Region      string                 `json:"region"`
+	Name        string                 `json:"name"`
+	Latitude    float64                `json:"latitude_deg"`
+	Longitude   float64                `json:"longitude_deg"`
+	ElevationFt float64                `json:"elevation_ft"`
+	MagVarDeg   float64                `json:"magvar_deg"`
+	DistanceNM  *float64               `json:"distance_nm,omitempty"`
+	BearingDeg  *float64               `json:"bearing_deg,omitempty"`
+	Runways     []RunwayJSON           `json:"runways"`
+	Frequencies []AirportFrequencyJSON `json:"frequencies"`
+	Timestamp   string                 `json:"timestamp"`
+}
+
+// NearbyAirportJSON is one airport in find_nearest_airports.
+type NearbyAirportJSON struct {
+	ICAO               string  `json:"icao"`
+	Region             string  `json:"region"`
+	Latitude           float64 `json:"latitude_deg"`
+	Longitude          float64 `json:"longitude_deg"`
+	ElevationFt        float64 `json:"elevation_ft"`
+	DistanceNM         float64 `json:"distance_nm"`
+	BearingDeg         float64 `json:"bearing_deg"`
+	RelativeBearingDeg float64 `json:"relative_bearing_deg"`
+}
+
+// NearestAirportsResponse is the JSON payload returned by
+// find_nearest_airports, nearest first.
+type NearestAirportsResponse struct {
+	Count     int                 `json:"count"`
+	Airports  []NearbyAirportJSON `json:"airports"`
+	Timestamp string              `json:"timestamp"`
+}
+
+func (s *Server) registerAirportTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_airport_info",
+		Description: "Returns an airport from the simulator's facility database by ident, e.g. KSEA: name, position, " +
+			"elevation, runways with true heading, length, width, surface and ILS frequency and course per runway end, " +
+			"and published frequencies (ATIS, ground, tower, ...). Includes distance and bearing from the aircraft.",
+	}, s.handleGetAirportInfo)
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "find_nearest_airports",
+		Description: "Lists the airports nearest the aircraft, nearest first, with distance, true and relative bearing and " +
+			"elevation. Only airports the simulator has loaded around the aircraft (roughly 100 NM) are considered.",
+	}, s.handleFindNearestAirports)
+}
+
+func (s *Server) handleGetAirportInfo(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input getAirportInfoInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	icao := strings.ToUpper(strings.TrimSpace(input.ICAO))
+	if !icaoPattern.MatchString(icao) {
+		return s.errorResult(fmt.Errorf("%w: icao must be 2-6 letters or digits, e.g. KSEA", ErrInvalidInput)), nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	airport, err := s.facilities.AirportInfo(ctx, icao)
+	if errors.Is(err, simconnect.ErrFacilityNotFound) {
+		err = fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	resp := AirportInfoResponse{
+		ICAO:        airport.ICAO,
+		Region:      airport.Region,
+		Name:        airport.Name,
+		Latitude:    airport.Latitude,
+		Longitude:   airport.Longitude,
+		ElevationFt: math.Round(airport.Altitude / geo.MetersPerFoot),
+		MagVarDeg:   round1(airport.MagVar),
+		Runways:     make([]RunwayJSON, 0, len(airport.Runways)),
+		Frequencies: make([]AirportFrequencyJSON, 0, len(airport.Frequencies)),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+	}
+	if pos, err := s.state.GetPosition(); err == nil {
+		dist := round1(geo.DistanceNM(pos.Latitude, pos.Longitude, airport.Latitude, airport.Longitude))
+		bearing := math.Round(geo.BearingDeg(pos.Latitude, pos.Longitude, airport.Latitude, airport.Longitude))
+		resp.DistanceNM, resp.BearingDeg = &dist, &bearing
+	}
+	for i := range airport.Runways {
+		resp.Runways = append(resp.Runways, newRunwayJSON(&airport.Runways[i]))
+	}
+	for _, f := range airport.Frequencies {
+		resp.Frequencies = append(resp.Frequencies, AirportFrequencyJSON{
+			Type:         enumName(frequencyTypes, f.Type),
+			Name:         f.Name,
+			FrequencyMHz: roundFrequency(f.Frequency / 1e6),
+		})
+	}
+	return s.jsonResult(resp)
+}
+
+func (s *Server) handleFindNearestAirports(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input findNearestAirportsInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	count := input.Count
+	if count == 0 {
+		count = defaultNearestAirports
+	}
+	if count < 1 || count > maxNearestAirports {
+		return s.errorResult(fmt.Errorf("%w: count must be between 1 and %d", ErrInvalidInput, maxNearestAirports)), nil, nil
+	}
+	if input.MaxDistanceNM < 0 || math.IsNaN(input.MaxDistanceNM) {
+		return s.errorResult(fmt.Errorf("%w: max_distance_nm must be positive", ErrInvalidInput)), nil, nil
+	}
+
+	pos, err := s.state.GetPosition()
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	facilities, err := s.facilities.ListFacilities(ctx, simconnect.FacilityListAirport)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	airports := make([]NearbyAirportJSON, 0, len(facilities))
+	for _, f := range facilities {
+		dist := geo.DistanceNM(pos.Latitude, pos.Longitude, f.Latitude, f.Longitude)
+		if input.MaxDistanceNM > 0 && dist > input.MaxDistanceNM {
+			continue
+		}
+		bearing := geo.BearingDeg(pos.Latitude, pos.Longitude, f.Latitude, f.Longitude)
+		airports = append(airports, NearbyAirportJSON{
+			ICAO:               f.Ident,
+			Region:             f.Region,
+			Latitude:           f.Latitude,
+			Longitude:          f.Longitude,
+			ElevationFt:        math.Round(f.Altitude / geo.MetersPerFoot),
+			DistanceNM:         round1(dist),
+			BearingDeg:         math.Round(bearing),
+			RelativeBearingDeg: math.Round(geo.RelativeDeg(bearing, pos.HeadingTrue)),
+		})
+	}
+	slices.SortStableFunc(airports, func(a, b NearbyAirportJSON) int {
+		return cmp.Compare(a.DistanceNM, b.DistanceNM)
+	})
+	if len(airports) > count {
+		airports = airports[:count]
+	}
+
+	return s.jsonResult(NearestAirportsResponse{
+		Count:     len(airports),
+		Airports:  airports,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func newRunwayJSON(rw *types.Runway) RunwayJSON {
+	primary := runwayName(rw.PrimaryNumber, rw.PrimaryDesignator)
+	secondary := runwayName(rw.SecondaryNumber, rw.SecondaryDesignator)
+	return RunwayJSON{
+		Designation: primary + "/" + secondary,
+		LengthFt:    math.Round(rw.Length / geo.MetersPerFoot),
+		WidthFt:     math.Round(rw.Width / geo.MetersPerFoot),
+		Surface:     enumName(runwaySurfaces, rw.Surface),
+		Ends: []RunwayEndJSON{
+			{Runway: primary, HeadingTrueDeg: round1(geo.NormalizeDeg(rw.Heading)), ILS: newILSJSON(&rw.PrimaryILS)},
+			{Runway: secondary, HeadingTrueDeg: round1(geo.NormalizeDeg(rw.Heading + 180)), ILS: newILSJSON(&rw.SecondaryILS)},
+		},
+	}
+}
+
+func newILSJSON(ils *types.ILS) *ILSJSON {
+	if ils.Ident == "" {
+		return nil
+	}
+	return &ILSJSON{
+		Ident:         ils.Ident,
+		Name:          ils.Name,
+		FrequencyMHz:  roundFrequency(ils.Frequency / 1e6),
+		CourseDeg:     round1(ils.Course),
+		HasGlideslope: ils.HasGlideSlope,
+		GlideslopeDeg: round1(ils.GlideSlope),
+	}
+}
+
+// runwayName formats a runway end such as "16L", or "NE" for compass-point runways.
+func runwayName(number, designator int) string {
+	name := fmt.Sprintf("%02d", number)
+	if number > 36 && number-37 < len(runwayDirections) {
+		name = runwayDirections[number-37]
+	}
+	if designator > 0 && designator < len(runwayDesignators) {
+		name += runwayDesignators[designator]
+	}
+	return name
+}
+
+// enumName returns the name of an enum value, or "unknown".
+func enumName(names map[int]string, v int) string {
+	if name, ok := names[v]; ok {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,197 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+type fakeFacilities struct {
+	airports   map[string]types.Airport
+	facilities []types.Facility
+	listed     []simconnect.FacilityListType
+	err        error
+}
+
+func (f *fakeFacilities) AirportInfo(_ context.Context, icao string) (types.Airport, error) {
+	if f.err != nil {
+		return types.Airport{}, f.err
+	}
+	a, ok := f.airports[icao]
+	if !ok {
+		return types.Airport{}, fmt.Errorf("airport %s: %w", icao, simconnect.ErrFacilityNotFound)
+	}
+	return a, nil
+}
+
+func (f *fakeFacilities) ListFacilities(_ context.Context, listType simconnect.FacilityListType) ([]types.Facility, error) {
+	f.listed = append(f.listed, listType)
+	return f.facilities, f.err
+}
+
+var sampleAirport = types.Airport{
+	ICAO: "KSEA", Region: "K1", Name: "Seattle-Tacoma Intl",
+	Latitude: 47.449, Longitude: -122.309, Altitude: 131.7, MagVar: 15.1,
+	Runways: []types.Runway{{
+		Heading: 179.96, Length: 3627, Width: 46, Surface: 4,
+		PrimaryNumber: 16, PrimaryDesignator: 1, SecondaryNumber: 34, SecondaryDesignator: 2,
+		PrimaryILS: types.ILS{
+			Ident: "ISNQ", Name: "ILS RWY 16L", Frequency: 110300000, Course: 179.5, GlideSlope: 3, HasGlideSlope: true,
+		},
+	}},
+	Frequencies: []types.AirportFrequency{
+		{Type: 1, Frequency: 118000000, Name: "SEATTLE ATIS"},
+		{Type: 6, Frequency: 119900000, Name: "SEATTLE TOWER"},
+	},
+}
+
+func TestGetAirportInfo(t *testing.T) {
+	f := &fakeFacilities{airports: map[string]types.Airport{"KSEA": sampleAirport}}
+	// 10 NM due north of the airport.
+	lat, lon := geo.Destination(sampleAirport.Latitude, sampleAirport.Longitude, 0, 10)
+	sg := &mockStateGetter{pos: types.AircraftPosition{Latitude: lat, Longitude: lon}}
+
+	res := callTool(t, sg, "get_airport_info", map[string]any{"icao": " ksea "}, internalmcp.WithFacilityReader(f))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, "KSEA", m["icao"])
+	assert.Equal(t, "Seattle-Tacoma Intl", m["name"])
+	assert.Equal(t, 432.0, m["elevation_ft"])
+	assert.Equal(t, 10.0, m["distance_nm"])
+	assert.Equal(t, 180.0, m["bearing_deg"])
+	assert.Equal(t, []any{map[string]any{
+		"designation": "16L/34R",
+		"length_ft":   11900.0,
+		"width_ft":    151.0,
+		"surface":     "asphalt",
+		"ends": []any{
+			map[string]any{
+				"runway":           "16L",
+				"heading_true_deg": 180.0,
+				"ils": map[string]any{
+					"ident": "ISNQ", "name": "ILS RWY 16L", "frequency_mhz": 110.3,
+					"course_deg": 179.5, "has_glideslope": true, "glideslope_deg": 3.0,
+				},
+			},
+			map[string]any{"runway": "34R", "heading_true_deg": 360.0},
+		},
+	}}, m["runways"])
+	assert.Equal(t, []any{
+		map[string]any{"type": "atis", "name": "SEATTLE ATIS", "frequency_mhz": 118.0},
+		map[string]any{"type": "tower", "name": "SEATTLE TOWER", "frequency_mhz": 119.9},
+	}, m["frequencies"])
+}
+
+func TestGetAirportInfoWithoutPosition(t *testing.T) {
+	f := &fakeFacilities{airports: map[string]types.Airport{"KSEA": sampleAirport}}
+	res := callTool(t, &mockStateGetter{err: state.ErrStale}, "get_airport_info", map[string]any{"icao": "KSEA"},
+		internalmcp.WithFacilityReader(f))
+	require.False(t, res.IsError, "airport data does not depend on live aircraft data")
+	m := parseJSON(t, res)
+	assert.NotContains(t, m, "distance_nm")
+	assert.NotContains(t, m, "bearing_deg")
+}
+
+func TestGetAirportInfoErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		icao     string
+		err      error
+		wantCode string
+	}{
+		{name: "empty", icao: "", wantCode: "INVALID_INPUT"},
+		{name: "too long", icao: "KSEATTLE", wantCode: "INVALID_INPUT"},
+		{name: "punctuation", icao: "K-SEA", wantCode: "INVALID_INPUT"},
+		{name: "unknown airport", icao: "ZZZZ", wantCode: "INVALID_INPUT"},
+		{name: "timeout", icao: "KSEA", err: context.DeadlineExceeded, wantCode: "TIMEOUT"},
+		{name: "not connected", icao: "KSEA", err: simconnect.ErrNotConnected, wantCode: "SIMULATOR_NOT_CONNECTED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeFacilities{err: tt.err}
+			res := callTool(t, &mockStateGetter{}, "get_airport_info", map[string]any{"icao": tt.icao},
+				internalmcp.WithFacilityReader(f))
+			require.True(t, res.IsError)
+			assert.Equal(t, tt.wantCode, parseJSON(t, res)["code"])
+		})
+	}
+}
+
+func TestFindNearestAirports(t *testing.T) {
+	own := types.AircraftPosition{Latitude: 47.5, Longitude: -122.3, HeadingTrue: 90}
+	at := func(ident string, bearing, dist float64) types.Facility {
+		lat, lon := geo.Destination(own.Latitude, own.Longitude, bearing, dist)
+		return types.Facility{Ident: ident, Region: "K1", Latitude: lat, Longitude: lon, Altitude: 100}
+	}
+	f := &fakeFacilities{facilities: []types.Facility{
+		at("KPAE", 0, 18), at("KSEA", 180, 4), at("KBFI", 270, 2), at("KOLM", 200, 40),
+	}}
+
+	res := callTool(t, &mockStateGetter{pos: own}, "find_nearest_airports", map[string]any{"count": 3},
+		internalmcp.WithFacilityReader(f))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, []simconnect.FacilityListType{simconnect.FacilityListAirport}, f.listed)
+	assert.Equal(t, 3.0, m["count"])
+	airports := m["airports"].([]any)
+	var idents []any
+	for _, a := range airports {
+		idents = append(idents, a.(map[string]any)["icao"])
+	}
+	assert.Equal(t, []any{"KBFI", "KSEA", "KPAE"}, idents)
+
+	nearest := airports[0].(map[string]any)
+	assert.Equal(t, 2.0, nearest["distance_nm"])
+	assert.Equal(t, 270.0, nearest["bearing_deg"])
+	assert.Equal(t, 180.0, nearest["relative_bearing_deg"])
+	assert.Equal(t, 328.0, nearest["elevation_ft"])
+}
+
+func TestFindNearestAirportsMaxDistance(t *testing.T) {
+	own := types.AircraftPosition{Latitude: 47.5, Longitude: -122.3}
+	lat, lon := geo.Destination(own.Latitude, own.Longitude, 0, 30)
+	f := &fakeFacilities{facilities: []types.Facility{{Ident: "KBVS", Latitude: lat, Longitude: lon}}}
+
+	res := callTool(t, &mockStateGetter{pos: own}, "find_nearest_airports", map[string]any{"max_distance_nm": 25},
+		internalmcp.WithFacilityReader(f))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+	assert.Equal(t, 0.0, m["count"])
+	assert.Equal(t, []any{}, m["airports"])
+}
+
+func TestFindNearestAirportsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]any
+		stateErr error
+		err      error
+		wantCode string
+	}{
+		{name: "count too large", args: map[string]any{"count": 50}, wantCode: "INVALID_INPUT"},
+		{name: "negative count", args: map[string]any{"count": -1}, wantCode: "INVALID_INPUT"},
+		{name: "negative distance", args: map[string]any{"max_distance_nm": -5}, wantCode: "INVALID_INPUT"},
+		{name: "stale position", stateErr: state.ErrStale, wantCode: "DATA_STALE"},
+		{name: "timeout", err: context.DeadlineExceeded, wantCode: "TIMEOUT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeFacilities{err: tt.err}
+			res := callTool(t, &mockStateGetter{err: tt.stateErr}, "find_nearest_airports", tt.args,
+				internalmcp.WithFacilityReader(f))
+			require.True(t, res.IsError)
+			assert.Equal(t, tt.wantCode, parseJSON(t, res)["code"])
+		})
+	}
+}
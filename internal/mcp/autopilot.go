@@ -0,0 +1,367 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// EventTransmitter fires allowlisted sim events at the user aircraft.
+// Implemented by simconnect.Client.
+type EventTransmitter interface {
+	TransmitEvent(eventName string, data uint32) error
+}
+
+// Verification tolerances for autopilot target values.
+const (
+	headingToleranceDeg      = 1.0
+	altitudeToleranceFt      = 10.0
+	verticalSpeedToleranceFt = 10.0
+
+	// commandPollInterval is how often the cached state is re-read while
+	// waiting for the simulator to reflect a command.
+	commandPollInterval = 50 * time.Millisecond
+)
+
+// autopilotMode maps a mode name to its ON/OFF events and state flag.
+type autopilotMode struct {
+	on, off string
+	flag    func(ap *types.AutopilotState) float64
+}
+
+var autopilotModes = map[string]autopilotMode{
+	"master": {simconnect.EventAutopilotOn, simconnect.EventAutopilotOff,
+		func(ap *types.AutopilotState) float64 { return ap.Master }},
+	"heading": {simconnect.EventAPHeadingHoldOn, simconnect.EventAPHeadingHoldOff,
+		func(ap *types.AutopilotState) float64 { return ap.HeadingLock }},
+	"nav": {simconnect.EventAPNav1HoldOn, simconnect.EventAPNav1HoldOff,
+		func(ap *types.AutopilotState) float64 { return ap.Nav1Lock }},
+	"approach": {simconnect.EventAPApproachHoldOn, simconnect.EventAPApproachHoldOff,
+		func(ap *types.AutopilotState) float64 { return ap.ApproachHold }},
+	"altitude": {simconnect.EventAPAltitudeHoldOn, simconnect.EventAPAltitudeHoldOff,
+		func(ap *types.AutopilotState) float64 { return ap.AltitudeLock }},
+	"vertical_speed": {simconnect.EventAPVerticalSpeedOn, simconnect.EventAPVerticalSpeedOff,
+		func(ap *types.AutopilotState) float64 { return ap.VerticalHold }},
+	"airspeed": {simconnect.EventAPAirspeedOn, simconnect.EventAPAirspeedOff,
+		func(ap *types.AutopilotState) float64 { return ap.AirspeedHold }},
+}
+
+// --- Input structs ---
+
+type setHeadingInput struct {
+	Heading float64 `json:"heading_deg" jsonschema:"target heading bug in degrees, 0-360"`
+}
+
+type setAltitudeInput struct {
+	Altitude float64 `json:"altitude_ft" jsonschema:"target altitude in feet MSL, 0-60000"`
+}
+
+type setVerticalSpeedInput struct {
+	VerticalSpeed float64 `json:"vertical_speed_fpm" jsonschema:"target vertical speed in feet per minute, -10000 to 10000"`
+}
+
+type setAutopilotModeInput struct {
+	Mode    string `json:"mode" jsonschema:"one of: master, heading, nav, approach, altitude, vertical_speed, airspeed"`
+	Engaged bool   `json:"engaged" jsonschema:"true to engage the mode, false to disengage it"`
+}
+
+type setFlightDirectorInput struct {
+	Active bool `json:"active" jsonschema:"true to turn the flight director on, false to turn it off"`
+}
+
+// --- Response structs ---
+
+// AutopilotCommandResponse is the JSON payload returned by autopilot control tools
+// once the simulator reflects the requested change.
+type AutopilotCommandResponse struct {
+	Command   string                 `json:"command"`
+	Event     string                 `json:"event,omitempty"`
+	Requested any                    `json:"requested"`
+	Actual    any                    `json:"actual"`
+	Verified  bool                   `json:"verified"`
+	Autopilot AutopilotStateResponse `json:"autopilot"`
+	Timestamp string                 `json:"timestamp"`
+}
+
+// CommandErrorResponse is returned when a command was sent but the simulator
+// did not reflect it within the command timeout.
+type CommandErrorResponse struct {
+	Error       string   `json:"error"`
+	Code        string   `json:"code"`
+	Recoverable bool     `json:"recoverable"`
+	Suggestion  string   `json:"suggestion"`
+	Command     string   `json:"command"`
+	Event       string   `json:"event,omitempty"`
+	Events      []string `json:"events,omitempty"`
+	Requested   any      `json:"requested"`
+	Actual      any      `json:"actual"`
+	Verified    bool     `json:"verified"`
+	Timestamp   string   `json:"timestamp"`
+}
+
+// autopilotCommand describes a sim event to fire and how to confirm it took effect.
+type autopilotCommand struct {
+	name      string
+	event     string // empty when the sim already reflects the request
+	data      uint32
+	requested any
+	actual    func(ap *types.AutopilotState) any
+	confirmed func(ap *types.AutopilotState) bool
+}
+
+func (s *Server) registerAutopilotControlTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name:        "set_autopilot_heading",
+		Description: "Sets the autopilot heading bug and confirms the simulator reflects the new heading.",
+	}, s.handleSetAutopilotHeading)
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name:        "set_autopilot_altitude",
+		Description: "Sets the autopilot target altitude and confirms the simulator reflects the new value.",
+	}, s.handleSetAutopilotAltitude)
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name:        "set_autopilot_vertical_speed",
+		Description: "Sets the autopilot target vertical speed and confirms the simulator reflects the new value.",
+	}, s.handleSetAutopilotVerticalSpeed)
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name:        "set_autopilot_mode",
+		Description: "Engages or disengages an autopilot mode (master, heading, nav, approach, altitude, vertical_speed, airspeed) and confirms the result.",
+	}, s.handleSetAutopilotMode)
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name:        "set_flight_director",
+		Description: "Turns the flight director on or off and confirms the result.",
+	}, s.handleSetFlightDirector)
+}
+
+func (s *Server) handleSetAutopilotHeading(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input setHeadingInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	if input.Heading < 0 || input.Heading > 360 || math.IsNaN(input.Heading) {
+		return s.errorResult(fmt.Errorf("%w: heading_deg must be between 0 and 360", ErrInvalidInput)), nil, nil
+	}
+	heading := math.Mod(math.Round(input.Heading), 360)
+
+	return s.runAutopilotCommand(ctx, &autopilotCommand{
+		name:      "set_autopilot_heading",
+		event:     simconnect.EventHeadingBugSet,
+		data:      uint32(heading),
+		requested: heading,
+		actual:    func(ap *types.AutopilotState) any { return ap.HeadingLockDir },
+		confirmed: func(ap *types.AutopilotState) bool {
+			return headingDiff(ap.HeadingLockDir, heading) <= headingToleranceDeg
+		},
+	})
+}
+
+func (s *Server) handleSetAutopilotAltitude(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input setAltitudeInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	if input.Altitude < 0 || input.Altitude > 60000 || math.IsNaN(input.Altitude) {
+		return s.errorResult(fmt.Errorf("%w: altitude_ft must be between 0 and 60000", ErrInvalidInput)), nil, nil
+	}
+	altitude := math.Round(input.Altitude)
+
+	return s.runAutopilotCommand(ctx, &autopilotCommand{
+		name:      "set_autopilot_altitude",
+		event:     simconnect.EventAPAltitudeVarSet,
+		data:      uint32(altitude),
+		requested: altitude,
+		actual:    func(ap *types.AutopilotState) any { return ap.AltitudeLockVar },
+		confirmed: func(ap *types.AutopilotState) bool {
+			return math.Abs(ap.AltitudeLockVar-altitude) <= altitudeToleranceFt
+		},
+	})
+}
+
+func (s *Server) handleSetAutopilotVerticalSpeed(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input setVerticalSpeedInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	if input.VerticalSpeed < -10000 || input.VerticalSpeed > 10000 || math.IsNaN(input.VerticalSpeed) {
+		return s.errorResult(fmt.Errorf("%w: vertical_speed_fpm must be between -10000 and 10000", ErrInvalidInput)), nil, nil
+	}
+	vs := math.Round(input.VerticalSpeed)
+
+	return s.runAutopilotCommand(ctx, &autopilotCommand{
+		name:      "set_autopilot_vertical_speed",
+		event:     simconnect.EventAPVSVarSet,
+		data:      uint32(int32(vs)), // #nosec G115 -- sim events carry signed values as two's complement DWORDs
+		requested: vs,
+		actual:    func(ap *types.AutopilotState) any { return ap.VerticalHoldVar },
+		confirmed: func(ap *types.AutopilotState) bool {
+			return math.Abs(ap.VerticalHoldVar-vs) <= verticalSpeedToleranceFt
+		},
+	})
+}
+
+func (s *Server) handleSetAutopilotMode(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input setAutopilotModeInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	mode, ok := autopilotModes[strings.ToLower(input.Mode)]
+	if !ok {
+		return s.errorResult(fmt.Errorf("%w: mode must be one of: %s",
+			ErrInvalidInput, strings.Join(autopilotModeNames(), ", "))), nil, nil
+	}
+	event := mode.off
+	if input.Engaged {
+		event = mode.on
+	}
+
+	return s.runAutopilotCommand(ctx, &autopilotCommand{
+		name:      "set_autopilot_mode",
+		event:     event,
+		requested: input.Engaged,
+		actual:    func(ap *types.AutopilotState) any { return mode.flag(ap) != 0 },
+		confirmed: func(ap *types.AutopilotState) bool { return (mode.flag(ap) != 0) == input.Engaged },
+	})
+}
+
+func (s *Server) handleSetFlightDirector(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input setFlightDirectorInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	ap, err := s.state.GetAutopilot()
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	// The sim only offers a toggle, so fire it only when the state differs.
+	event := ""
+	if (ap.FlightDirector != 0) != input.Active {
+		event = simconnect.EventToggleFD
+	}
+
+	return s.runAutopilotCommand(ctx, &autopilotCommand{
+		name:      "set_flight_director",
+		event:     event,
+		requested: input.Active,
+		actual:    func(ap *types.AutopilotState) any { return ap.FlightDirector != 0 },
+		confirmed: func(ap *types.AutopilotState) bool { return (ap.FlightDirector != 0) == input.Active },
+	})
+}
+
+// runAutopilotCommand fires cmd.event and waits for the cached autopilot state
+// to reflect it. Commands are refused while autopilot data is unavailable,
+// since the result could not be verified.
+func (s *Server) runAutopilotCommand(ctx context.Context, cmd *autopilotCommand) (*mcpsdk.CallToolResult, any, error) {
+	if _, err := s.state.GetAutopilot(); err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	if cmd.event != "" {
+		if err := s.events.TransmitEvent(cmd.event, cmd.data); err != nil {
+			return s.errorResult(err), nil, nil
+		}
+	}
+
+	ap, ok := s.awaitAutopilot(ctx, cmd.confirmed)
+	if !ok {
+		return s.commandErrorResult(cmd, ap), nil, nil
+	}
+
+	return s.jsonResult(AutopilotCommandResponse{
+		Command:   cmd.name,
+		Event:     cmd.event,
+		Requested: cmd.requested,
+		Actual:    cmd.actual(&ap),
+		Verified:  true,
+		Autopilot: newAutopilotStateResponse(&ap),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// awaitAutopilot polls the cached autopilot state until confirmed reports true,
+// the command timeout elapses, or ctx is done. It returns the last state seen.
+func (s *Server) awaitAutopilot(ctx context.Context, confirmed func(ap *types.AutopilotState) bool) (types.AutopilotState, bool) {
+	return awaitState(ctx, s.commandTimeout, s.state.GetAutopilot, confirmed)
+}
+
+// awaitState polls get until confirmed reports true, timeout elapses, or ctx
+// is done. It returns the last value get returned without error.
+func awaitState[T any](ctx context.Context, timeout time.Duration, get func() (T, error), confirmed func(*T) bool) (T, bool) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(commandPollInterval)
+	defer ticker.Stop()
+
+	var last T
+	for {
+		if v, err := get(); err == nil {
+			last = v
+			if confirmed(&v) {
+				return v, true
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return last, false
+		case <-deadline.C:
+			return last, false
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) commandErrorResult(cmd *autopilotCommand, ap types.AutopilotState) *mcpsdk.CallToolResult { //nolint:gocritic
+	return notConfirmedResult(&CommandErrorResponse{
+		Suggestion: fmt.Sprintf("The simulator did not reflect the change within %s. Check that the aircraft's autopilot supports this command, then retry.", s.commandTimeout),
+		Command:    cmd.name,
+		Event:      cmd.event,
+		Requested:  cmd.requested,
+		Actual:     cmd.actual(&ap),
+	})
+}
+
+// notConfirmedResult fills in the COMMAND_NOT_CONFIRMED error fields of resp
+// and returns it as an error result.
+func notConfirmedResult(resp *CommandErrorResponse) *mcpsdk.CallToolResult {
+	resp.Error = ErrCommandNotConfirmed.Error()
+	resp.Code = "COMMAND_NOT_CONFIRMED"
+	resp.Recoverable = true
+	resp.Verified = false
+	resp.Timestamp = time.Now().UTC().Format(time.RFC3339)
+
+	data, _ := json.Marshal(resp)
+	return &mcpsdk.CallToolResult{
+		Content: []mcpsdk.Content{&mcpsdk.TextContent{Text: string(data)}},
+		IsError: true,
+	}
+}
+
+// headingDiff returns the smallest angular difference between two headings in degrees.
+func headingDiff(a, b float64) float64 {
+	d := math.Mod(math.Abs(a-b), 360)
+	if d > 180 {
+		d = 360 - d
+	}
+	return d
+}
+
+func autopilotModeNames() []string {
+	names := make([]string, 0, len(autopilotModes))
+	for name := range autopilotModes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
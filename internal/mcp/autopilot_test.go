@@ -0,0 +1,232 @@
+package mcp_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// fakeAutopilot is a StateGetter and EventTransmitter whose autopilot state
+// reacts to transmitted events the way the simulator would.
+type fakeAutopilot struct {
+	mockStateGetter
+
+	mu       sync.Mutex
+	ignore   bool // when true, events are recorded but have no effect
+	sendErr  error
+	events   []string
+	lastData uint32
+}
+
+func (f *fakeAutopilot) GetAutopilot() (types.AutopilotState, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ap, f.err
+}
+
+func (f *fakeAutopilot) TransmitEvent(name string, data uint32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.events = append(f.events, name)
+	f.lastData = data
+	if f.ignore {
+		return nil
+	}
+
+	switch name {
+	case simconnect.EventHeadingBugSet:
+		f.ap.HeadingLockDir = float64(data)
+	case simconnect.EventAPAltitudeVarSet:
+		f.ap.AltitudeLockVar = float64(data)
+	case simconnect.EventAPVSVarSet:
+		f.ap.VerticalHoldVar = float64(int32(data)) // #nosec G115 -- test decodes two's complement
+	case simconnect.EventAutopilotOn:
+		f.ap.Master = 1
+	case simconnect.EventAutopilotOff:
+		f.ap.Master = 0
+	case simconnect.EventAPHeadingHoldOn:
+		f.ap.HeadingLock = 1
+	case simconnect.EventAPAltitudeHoldOff:
+		f.ap.AltitudeLock = 0
+	case simconnect.EventToggleFD:
+		f.ap.FlightDirector = 1 - f.ap.FlightDirector
+	}
+	return nil
+}
+
+func (f *fakeAutopilot) sentEvents() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.events...)
+}
+
+func callControlTool(t *testing.T, f *fakeAutopilot, toolName string, args map[string]any) *mcpsdk.CallToolResult {
+	t.Helper()
+	return callTool(t, f, toolName, args,
+		internalmcp.WithEventTransmitter(f),
+		internalmcp.WithCommandTimeout(200*time.Millisecond))
+}
+
+func TestSetAutopilotTargets(t *testing.T) {
+	tests := []struct {
+		name      string
+		tool      string
+		args      map[string]any
+		wantEvent string
+		wantData  uint32
+		requested float64
+	}{
+		{"heading", "set_autopilot_heading", map[string]any{"heading_deg": 135.4}, simconnect.EventHeadingBugSet, 135, 135},
+		{"heading 360 wraps to 0", "set_autopilot_heading", map[string]any{"heading_deg": 360}, simconnect.EventHeadingBugSet, 0, 0},
+		{"altitude", "set_autopilot_altitude", map[string]any{"altitude_ft": 12000}, simconnect.EventAPAltitudeVarSet, 12000, 12000},
+		{"vertical speed descent", "set_autopilot_vertical_speed", map[string]any{"vertical_speed_fpm": -1500}, simconnect.EventAPVSVarSet, 0xFFFFFA24, -1500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeAutopilot{mockStateGetter: mockStateGetter{ap: sampleAP}}
+			res := callControlTool(t, f, tt.tool, tt.args)
+
+			require.False(t, res.IsError)
+			m := parseJSON(t, res)
+			assert.Equal(t, tt.tool, m["command"])
+			assert.Equal(t, tt.wantEvent, m["event"])
+			assert.Equal(t, true, m["verified"])
+			assert.InDelta(t, tt.requested, m["requested"].(float64), 1e-9)
+			assert.InDelta(t, tt.requested, m["actual"].(float64), 1e-9)
+			assert.Contains(t, m, "autopilot")
+			assert.Contains(t, m, "timestamp")
+
+			assert.Equal(t, []string{tt.wantEvent}, f.sentEvents())
+			assert.Equal(t, tt.wantData, f.lastData)
+		})
+	}
+}
+
+func TestSetAutopilotMode(t *testing.T) {
+	f := &fakeAutopilot{mockStateGetter: mockStateGetter{ap: types.AutopilotState{AltitudeLock: 1}}}
+
+	res := callControlTool(t, f, "set_autopilot_mode", map[string]any{"mode": "master", "engaged": true})
+	require.False(t, res.IsError)
+	assert.Equal(t, true, parseJSON(t, res)["actual"])
+
+	res = callControlTool(t, f, "set_autopilot_mode", map[string]any{"mode": "altitude", "engaged": false})
+	require.False(t, res.IsError)
+	assert.Equal(t, false, parseJSON(t, res)["actual"])
+
+	assert.Equal(t, []string{simconnect.EventAutopilotOn, simconnect.EventAPAltitudeHoldOff}, f.sentEvents())
+}
+
+func TestSetFlightDirector(t *testing.T) {
+	t.Run("toggles when state differs", func(t *testing.T) {
+		f := &fakeAutopilot{}
+		res := callControlTool(t, f, "set_flight_director", map[string]any{"active": true})
+
+		require.False(t, res.IsError)
+		assert.Equal(t, true, parseJSON(t, res)["actual"])
+		assert.Equal(t, []string{simconnect.EventToggleFD}, f.sentEvents())
+	})
+
+	t.Run("skips toggle when already in state", func(t *testing.T) {
+		f := &fakeAutopilot{mockStateGetter: mockStateGetter{ap: types.AutopilotState{FlightDirector: 1}}}
+		res := callControlTool(t, f, "set_flight_director", map[string]any{"active": true})
+
+		require.False(t, res.IsError)
+		m := parseJSON(t, res)
+		assert.Equal(t, true, m["verified"])
+		assert.NotContains(t, m, "event")
+		assert.Empty(t, f.sentEvents())
+	})
+}
+
+func TestAutopilotCommandNotConfirmed(t *testing.T) {
+	f := &fakeAutopilot{mockStateGetter: mockStateGetter{ap: sampleAP}, ignore: true}
+
+	start := time.Now()
+	res := callControlTool(t, f, "set_autopilot_altitude", map[string]any{"altitude_ft": 5000})
+
+	require.True(t, res.IsError)
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+	m := parseJSON(t, res)
+	assert.Equal(t, "COMMAND_NOT_CONFIRMED", m["code"])
+	assert.Equal(t, true, m["recoverable"])
+	assert.Equal(t, "set_autopilot_altitude", m["command"])
+	assert.Equal(t, simconnect.EventAPAltitudeVarSet, m["event"])
+	assert.InDelta(t, 5000.0, m["requested"].(float64), 1e-9)
+	assert.InDelta(t, sampleAP.AltitudeLockVar, m["actual"].(float64), 1e-9)
+	assert.Equal(t, false, m["verified"])
+}
+
+func TestAutopilotCommandInvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+	}{
+		{"heading above range", "set_autopilot_heading", map[string]any{"heading_deg": 361}},
+		{"negative heading", "set_autopilot_heading", map[string]any{"heading_deg": -5}},
+		{"altitude above range", "set_autopilot_altitude", map[string]any{"altitude_ft": 70000}},
+		{"vertical speed below range", "set_autopilot_vertical_speed", map[string]any{"vertical_speed_fpm": -12000}},
+		{"unknown mode", "set_autopilot_mode", map[string]any{"mode": "autoland", "engaged": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeAutopilot{mockStateGetter: mockStateGetter{ap: sampleAP}}
+			res := callControlTool(t, f, tt.tool, tt.args)
+
+			require.True(t, res.IsError)
+			assert.Equal(t, "INVALID_INPUT", parseJSON(t, res)["code"])
+			assert.Empty(t, f.sentEvents())
+		})
+	}
+}
+
+func TestAutopilotCommandErrors(t *testing.T) {
+	t.Run("not connected", func(t *testing.T) {
+		f := &fakeAutopilot{mockStateGetter: mockStateGetter{ap: sampleAP}, sendErr: simconnect.ErrNotConnected}
+		res := callControlTool(t, f, "set_autopilot_heading", map[string]any{"heading_deg": 90})
+
+		require.True(t, res.IsError)
+		assert.Equal(t, "SIMULATOR_NOT_CONNECTED", parseJSON(t, res)["code"])
+	})
+
+	t.Run("no autopilot data", func(t *testing.T) {
+		f := &fakeAutopilot{mockStateGetter: mockStateGetter{err: simconnect.ErrNotConnected}}
+		res := callControlTool(t, f, "set_autopilot_heading", map[string]any{"heading_deg": 90})
+
+		require.True(t, res.IsError)
+		assert.Equal(t, "SIMULATOR_NOT_CONNECTED", parseJSON(t, res)["code"])
+		assert.Empty(t, f.sentEvents(), "commands must not be sent when they cannot be verified")
+	})
+}
+
+func TestAutopilotControlToolsRequireTransmitter(t *testing.T) {
+	ctx := context.Background()
+	srv := internalmcp.NewServer(&mockStateGetter{})
+	st, ct := mcpsdk.NewInMemoryTransports()
+	_, err := srv.Connect(ctx, st)
+	require.NoError(t, err)
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "1.0"}, nil)
+	cs, err := client.Connect(ctx, ct, nil)
+	require.NoError(t, err)
+	defer cs.Close()
+
+	tools, err := cs.ListTools(ctx, nil)
+	require.NoError(t, err)
+	for _, tool := range tools.Tools {
+		assert.NotContains(t, tool.Name, "set_", "control tool %s registered without a transmitter", tool.Name)
+		assert.NotEqual(t, "tune_radio", tool.Name, "control tool registered without a transmitter")
+	}
+}
@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+// connectionState tracks the SimConnect state reported through
+// simconnect.Client.OnStateChange. Until the first change it is unknown and
+// treated as connected, so the cached data alone decides.
+type connectionState struct {
+	known atomic.Bool
+	state atomic.Int32
+}
+
+func (c *connectionState) set(to simconnect.ConnectionState) {
+	c.state.Store(int32(to))
+	c.known.Store(true)
+}
+
+// disconnected reports whether SimConnect is known to be down.
+func (c *connectionState) disconnected() bool {
+	return c.known.Load() && simconnect.ConnectionState(c.state.Load()) != simconnect.StateConnected
+}
+
+// OnConnectionStateChange records the SimConnect connection state so tools
+// report SIMULATOR_NOT_CONNECTED, rather than DATA_STALE, while the simulator
+// is down. It matches simconnect.StateChangeFunc.
+func (s *Server) OnConnectionStateChange(_, to simconnect.ConnectionState) {
+	s.conn.set(to)
+}
+
+// ReadinessTracker is a ReadinessChecker that reports no data while
+// SimConnect is disconnected, so /ready fails as soon as the connection drops
+// instead of after the staleness threshold.
+type ReadinessTracker struct {
+	rc   ReadinessChecker
+	conn connectionState
+}
+
+// NewReadinessTracker wraps rc. Register OnConnectionStateChange with
+// simconnect.Client.OnStateChange.
+func NewReadinessTracker(rc ReadinessChecker) *ReadinessTracker {
+	return &ReadinessTracker{rc: rc}
+}
+
+// OnConnectionStateChange records the SimConnect connection state.
+// It matches simconnect.StateChangeFunc.
+func (r *ReadinessTracker) OnConnectionStateChange(_, to simconnect.ConnectionState) {
+	r.conn.set(to)
+}
+
+// LastUpdated returns the wrapped checker's last update time, or zero while
+// SimConnect is disconnected.
+func (r *ReadinessTracker) LastUpdated() time.Time {
+	if r.conn.disconnected() {
+		return time.Time{}
+	}
+	return r.rc.LastUpdated()
+}
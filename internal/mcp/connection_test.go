@@ -0,0 +1,50 @@
+package mcp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+)
+
+func TestStaleDataReportedAsNotConnectedWhileDisconnected(t *testing.T) {
+	srv := internalmcp.NewServer(&mockStateGetter{err: state.ErrStale})
+
+	res := callServerTool(t, srv, "get_aircraft_position", nil)
+	assert.Equal(t, "DATA_STALE", parseJSON(t, res)["code"], "unknown connection state")
+
+	srv.OnConnectionStateChange(simconnect.StateConnected, simconnect.StateReconnecting)
+	res = callServerTool(t, srv, "get_aircraft_position", nil)
+	assert.True(t, res.IsError)
+	assert.Equal(t, "SIMULATOR_NOT_CONNECTED", parseJSON(t, res)["code"])
+
+	srv.OnConnectionStateChange(simconnect.StateReconnecting, simconnect.StateConnected)
+	res = callServerTool(t, srv, "get_aircraft_position", nil)
+	assert.Equal(t, "DATA_STALE", parseJSON(t, res)["code"])
+}
+
+func TestReadinessTracker(t *testing.T) {
+	rc := &mockReadinessChecker{lastUpdated: time.Now()}
+	tracker := internalmcp.NewReadinessTracker(rc)
+	handler := internalmcp.ReadyHandler(tracker, 5*time.Second)
+	ready := func() int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", http.NoBody))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, ready(), "unknown connection state defers to the data")
+
+	tracker.OnConnectionStateChange(simconnect.StateConnected, simconnect.StateDisconnected)
+	assert.Equal(t, http.StatusServiceUnavailable, ready(), "fresh data but disconnected")
+	assert.True(t, tracker.LastUpdated().IsZero())
+
+	tracker.OnConnectionStateChange(simconnect.StateConnecting, simconnect.StateConnected)
+	assert.Equal(t, http.StatusOK, ready())
+}
@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// FlightControlsStatus holds the flight controls fields shared by
+// get_flight_controls and the controls section of get_systems_status.
+type FlightControlsStatus struct {
+	ElevatorPosition   float64 `json:"elevator_position"`
+	AileronPosition    float64 `json:"aileron_position"`
+	RudderPosition     float64 `json:"rudder_position"`
+	ElevatorTrim       float64 `json:"elevator_trim_deg"`
+	AileronTrim        float64 `json:"aileron_trim_pct"`
+	RudderTrim         float64 `json:"rudder_trim_pct"`
+	FlapsHandleIndex   int     `json:"flaps_handle_index"`
+	FlapsHandlePercent float64 `json:"flaps_handle_pct"`
+	SpoilersHandle     float64 `json:"spoilers_handle_pct"`
+	SpoilersArmed      bool    `json:"spoilers_armed"`
+	GearHandleDown     bool    `json:"gear_handle_down"`
+	GearCenter         float64 `json:"gear_center_pct"`
+	GearLeft           float64 `json:"gear_left_pct"`
+	GearRight          float64 `json:"gear_right_pct"`
+	ParkingBrakeSet    bool    `json:"parking_brake_set"`
+}
+
+// FlightControlsResponse is the JSON payload returned by get_flight_controls.
+type FlightControlsResponse struct {
+	FlightControlsStatus
+	Timestamp string `json:"timestamp"`
+}
+
+func newFlightControlsStatus(fc *types.FlightControls) FlightControlsStatus {
+	return FlightControlsStatus{
+		ElevatorPosition:   fc.Elevator,
+		AileronPosition:    fc.Aileron,
+		RudderPosition:     fc.Rudder,
+		ElevatorTrim:       fc.ElevatorTrim,
+		AileronTrim:        fc.AileronTrim,
+		RudderTrim:         fc.RudderTrim,
+		FlapsHandleIndex:   int(fc.FlapsHandleIndex),
+		FlapsHandlePercent: fc.FlapsHandlePercent,
+		SpoilersHandle:     fc.SpoilersHandle,
+		SpoilersArmed:      fc.SpoilersArmed != 0,
+		GearHandleDown:     fc.GearHandle != 0,
+		GearCenter:         fc.GearCenter,
+		GearLeft:           fc.GearLeft,
+		GearRight:          fc.GearRight,
+		ParkingBrakeSet:    fc.ParkingBrake != 0,
+	}
+}
+
+func (s *Server) handleGetFlightControls(
+	_ context.Context,
+	_ *mcpsdk.CallToolRequest,
+	_ emptyInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	fc, err := s.state.GetFlightControls()
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	return s.jsonResult(FlightControlsResponse{
+		FlightControlsStatus: newFlightControlsStatus(&fc),
+		Timestamp:            time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,47 @@
+package mcp_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func TestGetFlightControls(t *testing.T) {
+	sg := &mockStateGetter{fc: types.FlightControls{
+		Elevator:           -0.25,
+		ElevatorTrim:       2.5,
+		AileronTrim:        -10,
+		FlapsHandleIndex:   2,
+		FlapsHandlePercent: 50,
+		SpoilersArmed:      1,
+		GearHandle:         1,
+		GearCenter:         100,
+		GearLeft:           100,
+		GearRight:          99.5,
+		ParkingBrake:       0,
+	}}
+	res := callTool(t, sg, "get_flight_controls", nil)
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, -0.25, m["elevator_position"])
+	assert.Equal(t, 2.5, m["elevator_trim_deg"])
+	assert.Equal(t, -10.0, m["aileron_trim_pct"])
+	assert.Equal(t, 2.0, m["flaps_handle_index"])
+	assert.Equal(t, 50.0, m["flaps_handle_pct"])
+	assert.Equal(t, true, m["spoilers_armed"])
+	assert.Equal(t, true, m["gear_handle_down"])
+	assert.Equal(t, 99.5, m["gear_right_pct"])
+	assert.Equal(t, false, m["parking_brake_set"])
+	assert.Contains(t, m, "timestamp")
+}
+
+func TestGetFlightControlsStale(t *testing.T) {
+	res := callTool(t, &mockStateGetter{err: state.ErrStale}, "get_flight_controls", nil)
+	require.True(t, res.IsError)
+	assert.Equal(t, "DATA_STALE", parseJSON(t, res)["code"])
+}
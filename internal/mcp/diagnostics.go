@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+// DiagnosticsProvider reports SimConnect exceptions and rejected SimVars.
+// Implemented by simconnect.Client.
+type DiagnosticsProvider interface {
+	Diagnostics() simconnect.Diagnostics
+}
+
+// --- Response structs ---
+
+// RejectedSimVarResponse describes a SimVar the simulator refused to register.
+type RejectedSimVarResponse struct {
+	SimVar       string `json:"simvar"`
+	Group        string `json:"group"`
+	DefinitionID uint32 `json:"definition_id"`
+	Exception    string `json:"exception"`
+	Code         uint32 `json:"code"`
+}
+
+// ExceptionResponse describes a SimConnect exception and the message that caused it.
+type ExceptionResponse struct {
+	Exception  string `json:"exception"`
+	Code       uint32 `json:"code"`
+	SendID     uint32 `json:"send_id"`
+	Index      uint32 `json:"index"`
+	Request    string `json:"request,omitempty"`
+	SimVar     string `json:"simvar,omitempty"`
+	Event      string `json:"event,omitempty"`
+	ReceivedAt string `json:"received_at"`
+}
+
+// DiagnosticsResponse is the JSON payload returned by get_simconnect_diagnostics.
+type DiagnosticsResponse struct {
+	Healthy          bool                     `json:"healthy"`
+	RejectedSimVars  []RejectedSimVarResponse `json:"rejected_simvars"`
+	RecentExceptions []ExceptionResponse      `json:"recent_exceptions"`
+	Timestamp        string                   `json:"timestamp"`
+}
+
+func (s *Server) registerDiagnosticsTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_simconnect_diagnostics",
+		Description: "Returns SimVars the simulator rejected on the current connection (their values read as zero) " +
+			"and recent SimConnect exceptions with the request that caused each one.",
+	}, s.handleGetSimConnectDiagnostics)
+}
+
+func (s *Server) handleGetSimConnectDiagnostics(
+	_ context.Context,
+	_ *mcpsdk.CallToolRequest,
+	_ emptyInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	diag := s.diagnostics.Diagnostics()
+
+	resp := DiagnosticsResponse{
+		Healthy:          len(diag.RejectedSimVars) == 0,
+		RejectedSimVars:  make([]RejectedSimVarResponse, 0, len(diag.RejectedSimVars)),
+		RecentExceptions: make([]ExceptionResponse, 0, len(diag.RecentExceptions)),
+		Timestamp:        time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, rv := range diag.RejectedSimVars {
+		r := RejectedSimVarResponse{
+			SimVar:       rv.Name,
+			Group:        simconnect.DefinitionName(rv.DefID),
+			DefinitionID: rv.DefID,
+		}
+		if rv.Exception != nil {
+			r.Exception = rv.Exception.Name()
+			r.Code = rv.Exception.Code
+		}
+		resp.RejectedSimVars = append(resp.RejectedSimVars, r)
+	}
+	for _, exc := range diag.RecentExceptions {
+		r := ExceptionResponse{
+			Exception:  exc.Name(),
+			Code:       exc.Code,
+			SendID:     exc.SendID,
+			Index:      exc.Index,
+			SimVar:     exc.Message.SimVar,
+			Event:      exc.Message.Event,
+			ReceivedAt: exc.Received.UTC().Format(time.RFC3339),
+		}
+		if exc.Message.Type != 0 {
+			r.Request = exc.Message.TypeName()
+		}
+		resp.RecentExceptions = append(resp.RecentExceptions, r)
+	}
+
+	return s.jsonResult(resp)
+}
@@ -0,0 +1,72 @@
+package mcp_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+type fakeDiagnostics struct {
+	diag simconnect.Diagnostics
+}
+
+func (f *fakeDiagnostics) Diagnostics() simconnect.Diagnostics { return f.diag }
+
+func TestGetSimConnectDiagnostics(t *testing.T) {
+	exc := &simconnect.Exception{
+		Code:   simconnect.ExceptionNameUnrecognized,
+		SendID: 12,
+		Message: simconnect.SentMessage{
+			SendID: 12,
+			Type:   simconnect.SendAddToDataDef,
+			DefID:  simconnect.DefIDEngine,
+			SimVar: "GENERAL ENG RPM:2",
+		},
+		Received: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+	}
+	dp := &fakeDiagnostics{diag: simconnect.Diagnostics{
+		RejectedSimVars: []simconnect.RejectedSimVar{
+			{Name: "GENERAL ENG RPM:2", DefID: simconnect.DefIDEngine, Exception: exc},
+		},
+		RecentExceptions: []*simconnect.Exception{exc},
+	}}
+
+	res := callTool(t, &mockStateGetter{}, "get_simconnect_diagnostics", nil, internalmcp.WithDiagnostics(dp))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, false, m["healthy"])
+	rejected := m["rejected_simvars"].([]any)
+	require.Len(t, rejected, 1)
+	assert.Equal(t, map[string]any{
+		"simvar":        "GENERAL ENG RPM:2",
+		"group":         "engine",
+		"definition_id": float64(simconnect.DefIDEngine),
+		"exception":     "NAME_UNRECOGNIZED",
+		"code":          float64(simconnect.ExceptionNameUnrecognized),
+	}, rejected[0])
+
+	recent := m["recent_exceptions"].([]any)
+	require.Len(t, recent, 1)
+	first := recent[0].(map[string]any)
+	assert.Equal(t, "ADD_TO_DATA_DEFINITION", first["request"])
+	assert.Equal(t, "GENERAL ENG RPM:2", first["simvar"])
+	assert.Equal(t, float64(12), first["send_id"])
+	assert.Equal(t, "2026-10-16T12:00:00Z", first["received_at"])
+}
+
+func TestGetSimConnectDiagnosticsHealthy(t *testing.T) {
+	res := callTool(t, &mockStateGetter{}, "get_simconnect_diagnostics", nil,
+		internalmcp.WithDiagnostics(&fakeDiagnostics{}))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, true, m["healthy"])
+	assert.Empty(t, m["rejected_simvars"])
+	assert.Empty(t, m["recent_exceptions"])
+}
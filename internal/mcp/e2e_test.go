@@ -0,0 +1,221 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+)
+
+// startFakeSim runs the real Client, Poller and state.Manager against an
+// in-process fake SimConnect server. The returned manager is fed by the poller
+// until the test ends.
+func startFakeSim(t *testing.T, sim *simconnecttest.Server) *state.Manager {
+	t.Helper()
+	_, mgr := startFakeSimClient(t, sim)
+	return mgr
+}
+
+// startFakeSimClient is startFakeSim, also returning the connected Client.
+func startFakeSimClient(t *testing.T, sim *simconnecttest.Server) (*simconnect.Client, *state.Manager) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client := simconnect.NewClient(sim.Config())
+	require.NoError(t, client.Connect(ctx))
+
+	mgr := state.NewManager(5 * time.Second)
+	poller := simconnect.NewPoller(client, mgr, simconnect.PollerConfig{PollInterval: 20 * time.Millisecond})
+	require.NoError(t, poller.RegisterSimVars())
+
+	done := make(chan error, 1)
+	go func() { done <- poller.Start(ctx) }()
+
+	t.Cleanup(func() {
+		// Dropping the server side unblocks the poller's read loop.
+		sim.Close()
+		cancel()
+		<-done
+	})
+	return client, mgr
+}
+
+func TestEndToEndAircraftPosition(t *testing.T) {
+	sim := simconnecttest.NewServer()
+	sim.SetFloat64s(simconnect.ReqIDPosition,
+		47.6062, -122.3321, 35000.0, 34950.0,
+		270.0, 268.5, 450.0, 455.0, 448.0, 500.0, 2.5, -1.0)
+
+	mgr := startFakeSim(t, sim)
+
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetPosition()
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	res := callTool(t, mgr, "get_aircraft_position", map[string]any{"include_attitude": true})
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.InDelta(t, 47.6062, m["latitude"].(float64), 1e-9)
+	assert.InDelta(t, -122.3321, m["longitude"].(float64), 1e-9)
+	assert.InDelta(t, 35000.0, m["altitude_msl_ft"].(float64), 1e-9)
+	assert.InDelta(t, -1.0, m["bank_deg"].(float64), 1e-9)
+
+	total := len(simconnect.PositionSimVars) + len(simconnect.InstrumentsSimVars) +
+		len(simconnect.EngineSimVars) + len(simconnect.EnvironmentSimVars) + len(simconnect.AutopilotSimVars) +
+		len(simconnect.AircraftSimVars) + len(simconnect.FlightControlsSimVars) +
+		len(simconnect.SystemsSimVars) + len(simconnect.NavigationSimVars)
+	assert.Len(t, sim.Messages(simconnect.SendAddToDataDef), total)
+}
+
+func TestEndToEndUnscriptedGroupIsStale(t *testing.T) {
+	sim := simconnecttest.NewServer()
+	sim.SetFloat64s(simconnect.ReqIDPosition, make([]float64, len(simconnect.PositionSimVars))...)
+
+	mgr := startFakeSim(t, sim)
+
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetPosition()
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	res := callTool(t, mgr, "get_autopilot_state", nil)
+	require.True(t, res.IsError)
+	assert.Equal(t, "DATA_STALE", parseJSON(t, res)["code"])
+}
+
+func TestEndToEndRejectedReadSimVarsStaysHealthy(t *testing.T) {
+	sim := simconnecttest.NewServer()
+	sim.RejectSimVar("LIGHT STROBE", simconnect.ExceptionNameUnrecognized)
+	client, mgr := startFakeSimClient(t, sim)
+	opts := []internalmcp.Option{internalmcp.WithSimVarReader(client), internalmcp.WithDiagnostics(client)}
+
+	res := callTool(t, mgr, "read_simvars", map[string]any{
+		"simvars": []map[string]any{{"name": "LIGHT STROBE"}},
+	}, opts...)
+	require.True(t, res.IsError)
+
+	res = callTool(t, mgr, "get_simconnect_diagnostics", nil, opts...)
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+	assert.Equal(t, true, m["healthy"])
+	assert.Empty(t, m["rejected_simvars"])
+}
+
+func TestEndToEndAircraftInfo(t *testing.T) {
+	sim := simconnecttest.NewServer()
+	sim.SetSimVar("TITLE", "Cessna Skyhawk G1000 Asobo")
+	sim.SetSimVar("ATC ID", "N172SP")
+	sim.SetSimVar("CATEGORY", "Airplane")
+	sim.SetSimVar("NUMBER OF ENGINES", 1)
+	sim.SetSimVar("DESIGN SPEED VS0", 40)
+
+	mgr := startFakeSim(t, sim)
+
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetAircraftInfo()
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	res := callTool(t, mgr, "get_aircraft_info", nil)
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, "Cessna Skyhawk G1000 Asobo", m["title"])
+	assert.Equal(t, "N172SP", m["atc_id"])
+	assert.Equal(t, "Airplane", m["category"])
+	assert.Equal(t, "piston", m["engine_type"])
+	assert.Equal(t, 1.0, m["number_of_engines"])
+	assert.Equal(t, 40.0, m["design_speed_vs0_kts"])
+}
+
+func TestEndToEndFourEngines(t *testing.T) {
+	sim := simconnecttest.NewServer()
+	sim.SetSimVar("NUMBER OF ENGINES", 4)
+	for n := 1; n <= 4; n++ {
+		sim.SetSimVar(fmt.Sprintf("TURB ENG N1:%d", n), 80+n)
+		sim.SetSimVar(fmt.Sprintf("TURB ENG ITT:%d", n), 700+n)
+	}
+
+	mgr := startFakeSim(t, sim)
+
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetEngine()
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	res := callTool(t, mgr, "get_engine_data", nil)
+	require.False(t, res.IsError)
+	engines := parseJSON(t, res)["engines"].([]any)
+	require.Len(t, engines, 4)
+	e4 := engines[3].(map[string]any)
+	assert.Equal(t, 4.0, e4["engine_number"])
+	assert.Equal(t, 84.0, e4["n1_pct"])
+	assert.Equal(t, 704.0, e4["itt_celsius"])
+
+	res = callTool(t, mgr, "get_engine_data", map[string]any{"engine": 3})
+	require.False(t, res.IsError)
+	engines = parseJSON(t, res)["engines"].([]any)
+	require.Len(t, engines, 1)
+	assert.Equal(t, 83.0, engines[0].(map[string]any)["n1_pct"])
+}
+
+func TestEndToEndFlightControls(t *testing.T) {
+	sim := simconnecttest.NewServer()
+	sim.SetSimVar("FLAPS HANDLE INDEX", 3)
+	sim.SetSimVar("GEAR HANDLE POSITION", 1)
+	sim.SetSimVar("GEAR POSITION:1", 100)
+	sim.SetSimVar("BRAKE PARKING POSITION", 1)
+
+	mgr := startFakeSim(t, sim)
+
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetFlightControls()
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	res := callTool(t, mgr, "get_flight_controls", nil)
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+	assert.Equal(t, 3.0, m["flaps_handle_index"])
+	assert.Equal(t, true, m["gear_handle_down"])
+	assert.Equal(t, 100.0, m["gear_left_pct"])
+	assert.Equal(t, true, m["parking_brake_set"])
+}
+
+func TestEndToEndNavigation(t *testing.T) {
+	sim := simconnecttest.NewServer()
+	sim.SetSimVar("NAV ACTIVE FREQUENCY:1", 110.5)
+	sim.SetSimVar("NAV TOFROM:1", 1)
+	sim.SetSimVar("NAV HAS LOCALIZER:1", 1)
+	sim.SetSimVar("COM ACTIVE FREQUENCY:1", 121.5)
+	sim.SetSimVar("TRANSPONDER CODE:1", 0x1200)
+	sim.SetSimVar("GPS WP NEXT ID", "KSEA")
+
+	mgr := startFakeSim(t, sim)
+
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetNavigation()
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	res := callTool(t, mgr, "get_navigation", nil)
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+	nav1 := m["nav1"].(map[string]any)
+	assert.Equal(t, 110.5, nav1["active_frequency_mhz"])
+	assert.Equal(t, "to", nav1["to_from"])
+	assert.Equal(t, true, nav1["has_localizer"])
+	assert.Equal(t, 121.5, m["com1"].(map[string]any)["active_frequency_mhz"])
+	assert.Equal(t, "1200", m["transponder_code"])
+	assert.Equal(t, "KSEA", m["gps"].(map[string]any)["next_waypoint_id"])
+}
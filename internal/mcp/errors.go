@@ -0,0 +1,11 @@
+package mcp
+
+import "errors"
+
+var (
+	// ErrInvalidInput is returned when tool arguments fail validation.
+	ErrInvalidInput = errors.New("mcp: invalid input")
+	// ErrCommandNotConfirmed is returned when the simulator does not reflect a
+	// command within the command timeout.
+	ErrCommandNotConfirmed = errors.New("mcp: command not confirmed by simulator")
+)
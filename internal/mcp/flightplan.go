@@ -0,0 +1,191 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// FlightPlanReader reads the progress of the GPS along its flight plan.
+// Implemented by simconnect.Client.
+type FlightPlanReader interface {
+	ReadFlightPlan(ctx context.Context) (types.FlightPlan, error)
+}
+
+// minETEGroundSpeedKts is the ground speed below which times en route are
+// not estimated, e.g. while taxiing or parked.
+const minETEGroundSpeedKts = 30
+
+// routePoint is a waypoint of the route get_flight_plan reports legs for.
+type routePoint struct {
+	id          string
+	lat, lon    float64
+	altitudeFt  float64
+	hasAltitude bool
+}
+
+// --- Response structs ---
+
+// FlightPlanLegJSON is one leg of the route ahead, ending at To. Distances
+// and times remaining are measured from the aircraft, along the route, to
+// the end of the leg; times are at the current ground speed.
+type FlightPlanLegJSON struct {
+	From                 string   `json:"from"`
+	To                   string   `json:"to"`
+	Active               bool     `json:"active"`
+	ToLatitude           float64  `json:"to_latitude_deg"`
+	ToLongitude          float64  `json:"to_longitude_deg"`
+	ToAltitudeFt         *float64 `json:"to_altitude_ft,omitempty"`
+	CourseDeg            *float64 `json:"course_true_deg,omitempty"`
+	LegDistanceNM        *float64 `json:"leg_distance_nm,omitempty"`
+	BearingDeg           *float64 `json:"bearing_true_deg,omitempty"`
+	DistanceToGoNM       float64  `json:"distance_to_go_nm"`
+	TotalDistanceNM      float64  `json:"total_distance_nm"`
+	ETESeconds           *float64 `json:"ete_seconds,omitempty"`
+	CumulativeETESeconds *float64 `json:"cumulative_ete_seconds,omitempty"`
+}
+
+// FlightPlanResponse is the JSON payload returned by get_flight_plan.
+type FlightPlanResponse struct {
+	Active              bool                `json:"active"`
+	PlanTitle           string              `json:"plan_title,omitempty"`
+	Note                string              `json:"note,omitempty"`
+	WaypointCount       int                 `json:"waypoint_count"`
+	ActiveWaypointIndex int                 `json:"active_waypoint_index"`
+	NextWaypointID      string              `json:"next_waypoint_id"`
+	CrossTrackErrorNM   float64             `json:"cross_track_error_nm"`
+	GroundSpeedKts      float64             `json:"ground_speed_kts"`
+	Legs                []FlightPlanLegJSON `json:"legs"`
+	GPSNextWaypointETE  float64             `json:"gps_next_waypoint_ete_seconds"`
+	GPSNextWaypointETA  string              `json:"gps_next_waypoint_eta_local,omitempty"`
+	GPSDestinationETE   float64             `json:"gps_destination_ete_seconds"`
+	GPSDestinationETA   string              `json:"gps_destination_eta_local,omitempty"`
+	Timestamp           string              `json:"timestamp"`
+}
+
+func (s *Server) registerFlightPlanTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_flight_plan",
+		Description: "Returns the GPS flight plan: whether one is active, waypoint count and active waypoint, cross-track " +
+			"error, and the legs ahead with course, leg length, and distance and time to go from the aircraft at its " +
+			"current ground speed, plus the GPS's own ETE and ETA to the next waypoint and the destination. Only the active " +
+			"leg is known unless the plan was loaded with load_flight_plan.",
+	}, s.handleGetFlightPlan)
+}
+
+func (s *Server) handleGetFlightPlan(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	_ emptyInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	pos, err := s.state.GetPosition()
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	fp, err := s.flightPlans.ReadFlightPlan(ctx)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	resp := FlightPlanResponse{
+		Active:              fp.IsActive != 0,
+		WaypointCount:       int(fp.WaypointCount),
+		ActiveWaypointIndex: int(fp.ActiveWaypointIndex),
+		NextWaypointID:      fp.NextWaypointID,
+		CrossTrackErrorNM:   round2(fp.CrossTrackError),
+		GroundSpeedKts:      round1(pos.GroundSpeed),
+		Legs:                []FlightPlanLegJSON{},
+		GPSNextWaypointETE:  math.Round(fp.NextWaypointETE),
+		GPSNextWaypointETA:  timeOfDay(fp.NextWaypointETA),
+		GPSDestinationETE:   math.Round(fp.DestinationETE),
+		GPSDestinationETA:   timeOfDay(fp.DestinationETA),
+		Timestamp:           time.Now().UTC().Format(time.RFC3339),
+	}
+	if !resp.Active {
+		resp.Note = "No flight plan is active in the GPS."
+		return s.jsonResult(resp)
+	}
+
+	if plan := s.loadedPlanFlown(&fp); plan != nil {
+		resp.PlanTitle = plan.Title
+		resp.Legs = routeLegs(&pos, planRoute(plan), resp.ActiveWaypointIndex)
+		return s.jsonResult(resp)
+	}
+
+	// Otherwise the GPS exposes only the active leg: the previous waypoint, which is
+	// empty before the first leg, and the next one.
+	route := []routePoint{
+		{id: fp.PrevWaypointID, lat: fp.PrevWaypointLat, lon: fp.PrevWaypointLon},
+		{id: fp.NextWaypointID, lat: fp.NextWaypointLat, lon: fp.NextWaypointLon,
+			altitudeFt: fp.NextWaypointAltitude, hasAltitude: fp.NextWaypointAltitude != 0},
+	}
+	resp.Legs = routeLegs(&pos, route, 1)
+	return s.jsonResult(resp)
+}
+
+// routeLegs returns the legs of route from the active leg, which ends at
+// route[active], to the end of the route. A leg starting at a waypoint with
+// no ident has no course or length.
+func routeLegs(pos *types.AircraftPosition, route []routePoint, active int) []FlightPlanLegJSON {
+	legs := make([]FlightPlanLegJSON, 0, len(route))
+	var total float64
+	for i := max(active, 1); i < len(route); i++ {
+		from, to := route[i-1], route[i]
+		leg := FlightPlanLegJSON{
+			From:        from.id,
+			To:          to.id,
+			Active:      i == active,
+			ToLatitude:  to.lat,
+			ToLongitude: to.lon,
+		}
+		if to.hasAltitude {
+			alt := math.Round(to.altitudeFt)
+			leg.ToAltitudeFt = &alt
+		}
+		if from.id != "" {
+			course := math.Round(geo.BearingDeg(from.lat, from.lon, to.lat, to.lon))
+			length := round1(geo.DistanceNM(from.lat, from.lon, to.lat, to.lon))
+			leg.CourseDeg, leg.LegDistanceNM = &course, &length
+		}
+
+		var toGo float64
+		if leg.Active {
+			toGo = geo.DistanceNM(pos.Latitude, pos.Longitude, to.lat, to.lon)
+			bearing := math.Round(geo.BearingDeg(pos.Latitude, pos.Longitude, to.lat, to.lon))
+			leg.BearingDeg = &bearing
+		} else {
+			toGo = geo.DistanceNM(from.lat, from.lon, to.lat, to.lon)
+		}
+		total += toGo
+		leg.DistanceToGoNM = round1(toGo)
+		leg.TotalDistanceNM = round1(total)
+
+		if pos.GroundSpeed >= minETEGroundSpeedKts {
+			ete := math.Round(toGo / pos.GroundSpeed * 3600)
+			cumulative := math.Round(total / pos.GroundSpeed * 3600)
+			leg.ETESeconds, leg.CumulativeETESeconds = &ete, &cumulative
+		}
+		legs = append(legs, leg)
+	}
+	return legs
+}
+
+// timeOfDay formats seconds since midnight as HH:MM, or "" when zero.
+func timeOfDay(secs float64) string {
+	if secs <= 0 {
+		return ""
+	}
+	mins := int(secs/60) % (24 * 60)
+	return fmt.Sprintf("%02d:%02d", mins/60, mins%60)
+}
+
+func round2(v float64) float64 { return math.Round(v*100) / 100 }
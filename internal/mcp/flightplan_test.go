@@ -0,0 +1,121 @@
+package mcp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+type fakeFlightPlans struct {
+	fp  types.FlightPlan
+	err error
+}
+
+func (f *fakeFlightPlans) ReadFlightPlan(context.Context) (types.FlightPlan, error) {
+	return f.fp, f.err
+}
+
+// activeLeg is a GPS flight plan flying from SEA to a waypoint 60 NM due south.
+func activeLeg() types.FlightPlan {
+	lat, lon := geo.Destination(47.435, -122.31, 180, 60)
+	return types.FlightPlan{
+		IsActive: 1, WaypointCount: 4, ActiveWaypointIndex: 2,
+		PrevWaypointID: "SEA", PrevWaypointLat: 47.435, PrevWaypointLon: -122.31,
+		NextWaypointID: "SOUTH", NextWaypointLat: lat, NextWaypointLon: lon, NextWaypointAltitude: 8000,
+		NextWaypointDistance: 40, NextWaypointETE: 1200, NextWaypointETA: 45000,
+		DestinationETE: 3600, DestinationETA: 48630, CrossTrackError: -0.234,
+	}
+}
+
+func TestGetFlightPlan(t *testing.T) {
+	// 20 NM along the leg, at 120 kts.
+	lat, lon := geo.Destination(47.435, -122.31, 180, 20)
+	sg := &mockStateGetter{pos: types.AircraftPosition{Latitude: lat, Longitude: lon, GroundSpeed: 120}}
+
+	res := callTool(t, sg, "get_flight_plan", nil, internalmcp.WithFlightPlanReader(&fakeFlightPlans{fp: activeLeg()}))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, true, m["active"])
+	assert.NotContains(t, m, "note")
+	assert.Equal(t, 4.0, m["waypoint_count"])
+	assert.Equal(t, 2.0, m["active_waypoint_index"])
+	assert.Equal(t, "SOUTH", m["next_waypoint_id"])
+	assert.Equal(t, -0.23, m["cross_track_error_nm"])
+	assert.Equal(t, 120.0, m["ground_speed_kts"])
+	assert.Equal(t, 1200.0, m["gps_next_waypoint_ete_seconds"])
+	assert.Equal(t, "12:30", m["gps_next_waypoint_eta_local"])
+	assert.Equal(t, 3600.0, m["gps_destination_ete_seconds"])
+	assert.Equal(t, "13:30", m["gps_destination_eta_local"])
+
+	legs := m["legs"].([]any)
+	require.Len(t, legs, 1)
+	leg := legs[0].(map[string]any)
+	assert.Equal(t, "SEA", leg["from"])
+	assert.Equal(t, "SOUTH", leg["to"])
+	assert.Equal(t, true, leg["active"])
+	assert.Equal(t, 8000.0, leg["to_altitude_ft"])
+	assert.Equal(t, 180.0, leg["course_true_deg"])
+	assert.Equal(t, 60.0, leg["leg_distance_nm"])
+	assert.Equal(t, 180.0, leg["bearing_true_deg"])
+	assert.Equal(t, 40.0, leg["distance_to_go_nm"])
+	assert.Equal(t, 40.0, leg["total_distance_nm"])
+	assert.Equal(t, 1200.0, leg["ete_seconds"])
+	assert.Equal(t, 1200.0, leg["cumulative_ete_seconds"])
+}
+
+func TestGetFlightPlanFirstLegOnGround(t *testing.T) {
+	fp := activeLeg()
+	fp.PrevWaypointID, fp.PrevWaypointLat, fp.PrevWaypointLon = "", 0, 0
+	fp.NextWaypointAltitude = 0
+	sg := &mockStateGetter{pos: types.AircraftPosition{Latitude: 47.435, Longitude: -122.31, GroundSpeed: 12}}
+
+	res := callTool(t, sg, "get_flight_plan", nil, internalmcp.WithFlightPlanReader(&fakeFlightPlans{fp: fp}))
+	require.False(t, res.IsError)
+	leg := parseJSON(t, res)["legs"].([]any)[0].(map[string]any)
+
+	assert.Equal(t, "", leg["from"])
+	assert.Equal(t, 60.0, leg["distance_to_go_nm"])
+	for _, key := range []string{"course_true_deg", "leg_distance_nm", "to_altitude_ft", "ete_seconds", "cumulative_ete_seconds"} {
+		assert.NotContains(t, leg, key)
+	}
+}
+
+func TestGetFlightPlanInactive(t *testing.T) {
+	res := callTool(t, &mockStateGetter{}, "get_flight_plan", nil,
+		internalmcp.WithFlightPlanReader(&fakeFlightPlans{fp: types.FlightPlan{}}))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, false, m["active"])
+	assert.Equal(t, "No flight plan is active in the GPS.", m["note"])
+	assert.Equal(t, []any{}, m["legs"])
+	assert.NotContains(t, m, "gps_next_waypoint_eta_local")
+}
+
+func TestGetFlightPlanErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		stateErr error
+		readErr  error
+		wantCode string
+	}{
+		{name: "not connected", stateErr: simconnect.ErrNotConnected, wantCode: "SIMULATOR_NOT_CONNECTED"},
+		{name: "timeout", readErr: context.DeadlineExceeded, wantCode: "TIMEOUT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := callTool(t, &mockStateGetter{err: tt.stateErr}, "get_flight_plan", nil,
+				internalmcp.WithFlightPlanReader(&fakeFlightPlans{err: tt.readErr}))
+			require.True(t, res.IsError)
+			assert.Equal(t, tt.wantCode, parseJSON(t, res)["code"])
+		})
+	}
+}
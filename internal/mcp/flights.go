@@ -0,0 +1,215 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FlightStore saves and loads flights (.FLT files) on the simulator's
+// machine. Implemented by simconnect.Client.
+type FlightStore interface {
+	FlightSave(ctx context.Context, path, title, description string) error
+	FlightLoad(ctx context.Context, path string) error
+}
+
+// Limits on flight names. SimConnect file names are char[260] and the
+// simulator appends .FLT.
+const (
+	maxFlightPathLength = 255
+	maxFlightNameDepth  = 4
+)
+
+// flightComponentPattern matches one directory or file name of a flight
+// name. Colons, which would name a drive or an alternate data stream, and
+// separators are excluded.
+var flightComponentPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 _.,()+-]*$`)
+
+// windowsDeviceNames cannot be used as file names on Windows, with or
+// without an extension.
+var windowsDeviceNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true, "COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true, "LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// --- Input structs ---
+
+type saveFlightSituationInput struct {
+	Name        string `json:"name" jsonschema:"file name for the flight, e.g. short-final-16R or training/short-final; relative to the flight directory"`
+	Title       string `json:"title,omitempty" jsonschema:"title shown in the simulator's flight list; defaults to the name"`
+	Description string `json:"description,omitempty" jsonschema:"description of the situation, e.g. short final runway 16R, 1500 ft, gusty"`
+	Directory   string `json:"directory,omitempty" jsonschema:"one of the configured flight directories; defaults to the first"`
+}
+
+type loadFlightSituationInput struct {
+	Name      string `json:"name" jsonschema:"file name of a saved flight, relative to the flight directory"`
+	Directory string `json:"directory,omitempty" jsonschema:"one of the configured flight directories; defaults to the first"`
+}
+
+// --- Response structs ---
+
+// SaveFlightSituationResponse is the JSON payload returned by
+// save_flight_situation. File is the path on the simulator's machine.
+type SaveFlightSituationResponse struct {
+	Saved     bool   `json:"saved"`
+	File      string `json:"file"`
+	Title     string `json:"title"`
+	Timestamp string `json:"timestamp"`
+}
+
+// LoadFlightSituationResponse is the JSON payload returned by
+// load_flight_situation. SimConnect does not report when the flight has
+// finished loading, so Requested only means the simulator accepted the file.
+type LoadFlightSituationResponse struct {
+	Requested bool   `json:"requested"`
+	File      string `json:"file"`
+	Note      string `json:"note"`
+	Timestamp string `json:"timestamp"`
+}
+
+func (s *Server) registerFlightTools() {
+	dirs := strings.Join(s.flightDirs, ", ")
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "save_flight_situation",
+		Description: "Saves the current flight (aircraft position, state, weather and time) as a .FLT file on the " +
+			"simulator's machine, to restore later with load_flight_situation, e.g. to checkpoint a training scenario. " +
+			"Flight directories: " + dirs + ".",
+	}, s.handleSaveFlightSituation)
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "load_flight_situation",
+		Description: "Loads a .FLT flight saved with save_flight_situation, replacing the current flight. The simulator " +
+			"loads it in the background; get_simulator_status reports the flight file once it has loaded. " +
+			"Flight directories: " + dirs + ".",
+	}, s.handleLoadFlightSituation)
+}
+
+func (s *Server) handleSaveFlightSituation(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input saveFlightSituationInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	file, err := s.flightPath(input.Directory, input.Name)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		title = path.Base(strings.ReplaceAll(cleanFlightExt(input.Name), `\`, "/"))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	if err := s.flights.FlightSave(ctx, file, title, input.Description); err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	return s.jsonResult(SaveFlightSituationResponse{
+		Saved:     true,
+		File:      file,
+		Title:     title,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func (s *Server) handleLoadFlightSituation(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input loadFlightSituationInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	file, err := s.flightPath(input.Directory, input.Name)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	if err := s.flights.FlightLoad(ctx, file); err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	return s.jsonResult(LoadFlightSituationResponse{
+		Requested: true,
+		File:      file,
+		Note:      "The simulator loads the flight in the background; get_simulator_status reports it as the flight file once it has loaded.",
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// flightPath resolves a flight name within one of the configured flight
+// directories to a .FLT path on the simulator's machine. The name cannot
+// leave the directory: absolute paths, drive letters and . or ..
+// components are rejected rather than cleaned.
+func (s *Server) flightPath(directory, name string) (string, error) {
+	dir := s.flightDirs[0]
+	if directory != "" {
+		var ok bool
+		if dir, ok = s.flightDir(directory); !ok {
+			return "", fmt.Errorf("%w: directory must be one of %s", ErrInvalidInput, strings.Join(s.flightDirs, ", "))
+		}
+	}
+	rel, err := cleanFlightName(name)
+	if err != nil {
+		return "", fmt.Errorf("%w: name %w", ErrInvalidInput, err)
+	}
+	file := simPath(dir, rel) + ".flt"
+	if len(file) > maxFlightPathLength {
+		return "", fmt.Errorf("%w: path %s is longer than %d characters", ErrInvalidInput, file, maxFlightPathLength)
+	}
+	return file, nil
+}
+
+// flightDir returns the configured flight directory matching directory,
+// ignoring case and trailing separators as Windows does.
+func (s *Server) flightDir(directory string) (string, bool) {
+	want := strings.TrimRight(strings.TrimSpace(directory), `/\`)
+	for _, dir := range s.flightDirs {
+		if strings.EqualFold(strings.TrimRight(dir, `/\`), want) {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// cleanFlightName validates a flight name relative to a flight directory
+// and returns it with / separators and without a .FLT extension.
+func cleanFlightName(name string) (string, error) {
+	name = strings.ReplaceAll(cleanFlightExt(name), `\`, "/")
+	if name == "" {
+		return "", errors.New("is required")
+	}
+	if strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("%q must be relative to the flight directory", name)
+	}
+	parts := strings.Split(name, "/")
+	for _, part := range parts {
+		switch {
+		case part == "" || part == "." || part == "..":
+			return "", fmt.Errorf("%q must not contain empty, . or .. components", name)
+		case !flightComponentPattern.MatchString(part) || strings.HasSuffix(part, ".") || strings.HasSuffix(part, " "):
+			return "", fmt.Errorf("%q: %q must start with a letter or digit and use only letters, digits, spaces and _ . , ( ) + -", name, part)
+		}
+		base, _, _ := strings.Cut(part, ".")
+		if windowsDeviceNames[strings.ToUpper(strings.TrimSpace(base))] {
+			return "", fmt.Errorf("%q: %q is reserved on Windows", name, part)
+		}
+	}
+	if len(parts) > maxFlightNameDepth {
+		return "", fmt.Errorf("%q has more than %d levels", name, maxFlightNameDepth)
+	}
+	return strings.Join(parts, "/"), nil
+}
+
+// cleanFlightExt trims spaces and a .FLT extension from a flight name.
+func cleanFlightExt(name string) string {
+	name = strings.TrimSpace(name)
+	if ext := path.Ext(name); strings.EqualFold(ext, ".flt") {
+		name = name[:len(name)-len(ext)]
+	}
+	return name
+}
@@ -0,0 +1,182 @@
+package mcp_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+type savedFlight struct {
+	path, title, description string
+}
+
+type fakeFlightStore struct {
+	mu     sync.Mutex
+	saved  []savedFlight
+	loaded []string
+	err    error
+}
+
+func (f *fakeFlightStore) FlightSave(_ context.Context, path, title, description string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.saved = append(f.saved, savedFlight{path, title, description})
+	return f.err
+}
+
+func (f *fakeFlightStore) FlightLoad(_ context.Context, path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.loaded = append(f.loaded, path)
+	return f.err
+}
+
+func (f *fakeFlightStore) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.saved) + len(f.loaded)
+}
+
+var flightDirs = []string{`C:\Users\pilot\Flights\`, "/mnt/checkrides"}
+
+func TestSaveFlightSituation(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantPath  string
+		wantTitle string
+	}{
+		{
+			name:      "default directory",
+			args:      map[string]any{"name": "short-final-16R", "description": "1500 ft, gusty"},
+			wantPath:  `C:\Users\pilot\Flights\short-final-16R.flt`,
+			wantTitle: "short-final-16R",
+		},
+		{
+			name:      "subdirectory and title",
+			args:      map[string]any{"name": "training/Short Final.FLT", "title": "Short final", "description": "1500 ft, gusty"},
+			wantPath:  `C:\Users\pilot\Flights\training\Short Final.flt`,
+			wantTitle: "Short final",
+		},
+		{
+			name:      "other directory",
+			args:      map[string]any{"name": `ifr\ils-16R`, "directory": "/MNT/checkrides/", "description": "1500 ft, gusty"},
+			wantPath:  "/mnt/checkrides/ifr/ils-16R.flt",
+			wantTitle: "ils-16R",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeFlightStore{}
+			res := callTool(t, &mockStateGetter{}, "save_flight_situation", tt.args,
+				internalmcp.WithFlightStore(store, flightDirs))
+			require.False(t, res.IsError)
+			m := parseJSON(t, res)
+
+			assert.Equal(t, true, m["saved"])
+			assert.Equal(t, tt.wantPath, m["file"])
+			assert.Equal(t, tt.wantTitle, m["title"])
+			assert.Equal(t, []savedFlight{{tt.wantPath, tt.wantTitle, "1500 ft, gusty"}}, store.saved)
+		})
+	}
+}
+
+func TestLoadFlightSituation(t *testing.T) {
+	store := &fakeFlightStore{}
+	res := callTool(t, &mockStateGetter{}, "load_flight_situation", map[string]any{"name": "short-final-16R.flt"},
+		internalmcp.WithFlightStore(store, flightDirs))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, true, m["requested"])
+	assert.Equal(t, `C:\Users\pilot\Flights\short-final-16R.flt`, m["file"])
+	assert.Contains(t, m["note"], "get_simulator_status")
+	assert.Equal(t, []string{`C:\Users\pilot\Flights\short-final-16R.flt`}, store.loaded)
+}
+
+func TestFlightSituationRejectsPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr string
+	}{
+		{"missing name", map[string]any{"name": " "}, "name is required"},
+		{"parent directory", map[string]any{"name": "../secrets"}, "must not contain empty, . or .. components"},
+		{"nested parent directory", map[string]any{"name": `training\..\..\Windows\win`}, "must not contain empty, . or .. components"},
+		{"current directory", map[string]any{"name": "./x"}, "must not contain empty, . or .. components"},
+		{"empty component", map[string]any{"name": "a//b"}, "must not contain empty, . or .. components"},
+		{"absolute", map[string]any{"name": "/etc/passwd"}, "must be relative to the flight directory"},
+		{"absolute Windows", map[string]any{"name": `\Windows\win`}, "must be relative to the flight directory"},
+		{"UNC", map[string]any{"name": `\\server\share\x`}, "must be relative to the flight directory"},
+		{"drive letter", map[string]any{"name": `D:\x`}, "must start with a letter or digit"},
+		{"drive-relative", map[string]any{"name": `D:x`}, "must start with a letter or digit"},
+		{"alternate data stream", map[string]any{"name": "x:stream"}, "must start with a letter or digit"},
+		{"trailing dot", map[string]any{"name": "x."}, "must start with a letter or digit"},
+		{"hidden file", map[string]any{"name": ".x"}, "must start with a letter or digit"},
+		{"device name", map[string]any{"name": "training/NUL.txt"}, "reserved on Windows"},
+		{"too deep", map[string]any{"name": "a/b/c/d/e"}, "more than 4 levels"},
+		{"too long", map[string]any{"name": strings.Repeat("a", 250)}, "longer than 255 characters"},
+		{"unknown directory", map[string]any{"name": "x", "directory": `C:\Windows`}, `directory must be one of C:\Users\pilot\Flights\, /mnt/checkrides`},
+	}
+	for _, tool := range []string{"save_flight_situation", "load_flight_situation"} {
+		for _, tt := range tests {
+			t.Run(tool+"/"+tt.name, func(t *testing.T) {
+				store := &fakeFlightStore{}
+				res := callTool(t, &mockStateGetter{}, tool, tt.args, internalmcp.WithFlightStore(store, flightDirs))
+				require.True(t, res.IsError)
+				m := parseJSON(t, res)
+				assert.Equal(t, "INVALID_INPUT", m["code"])
+				assert.Contains(t, m["error"], tt.wantErr)
+				assert.Zero(t, store.calls(), "rejected paths must not reach the simulator")
+			})
+		}
+	}
+}
+
+func TestFlightSituationErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+	}{
+		{"not connected", simconnect.ErrNotConnected, "SIMULATOR_NOT_CONNECTED"},
+		{"rejected", &simconnect.Exception{Code: simconnect.ExceptionError}, "SIMCONNECT_EXCEPTION"},
+	}
+	for _, tool := range []string{"save_flight_situation", "load_flight_situation"} {
+		for _, tt := range tests {
+			t.Run(tool+"/"+tt.name, func(t *testing.T) {
+				res := callTool(t, &mockStateGetter{}, tool, map[string]any{"name": "x"},
+					internalmcp.WithFlightStore(&fakeFlightStore{err: tt.err}, flightDirs))
+				require.True(t, res.IsError)
+				assert.Equal(t, tt.wantCode, parseJSON(t, res)["code"])
+			})
+		}
+	}
+}
+
+func TestFlightSituationToolsRequireDirectories(t *testing.T) {
+	ctx := context.Background()
+	srv := internalmcp.NewServer(&mockStateGetter{}, internalmcp.WithFlightStore(&fakeFlightStore{}, nil))
+	st, ct := mcpsdk.NewInMemoryTransports()
+	_, err := srv.Connect(ctx, st)
+	require.NoError(t, err)
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "1.0"}, nil)
+	cs, err := client.Connect(ctx, ct, nil)
+	require.NoError(t, err)
+	defer cs.Close()
+
+	tools, err := cs.ListTools(ctx, nil)
+	require.NoError(t, err)
+	for _, tool := range tools.Tools {
+		assert.NotContains(t, tool.Name, "flight_situation", "%s registered without a flight directory", tool.Name)
+	}
+}
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// NavRadioResponse describes one NAV receiver in get_navigation.
+type NavRadioResponse struct {
+	ActiveFrequency  float64 `json:"active_frequency_mhz"`
+	StandbyFrequency float64 `json:"standby_frequency_mhz"`
+	OBS              float64 `json:"obs_deg"`
+	Radial           float64 `json:"radial_deg"`
+	CDI              int     `json:"cdi_deflection"`
+	GSI              int     `json:"gsi_deflection"`
+	ToFrom           string  `json:"to_from"`
+	DME              float64 `json:"dme_nm"`
+	HasSignal        bool    `json:"has_signal"`
+	HasLocalizer     bool    `json:"has_localizer"`
+	HasGlideSlope    bool    `json:"has_glideslope"`
+}
+
+// ADFResponse describes the ADF receiver in get_navigation.
+type ADFResponse struct {
+	Frequency       float64 `json:"frequency_khz"`
+	RelativeBearing float64 `json:"relative_bearing_deg"`
+}
+
+// ComRadioResponse describes one COM radio in get_navigation.
+type ComRadioResponse struct {
+	ActiveFrequency  float64 `json:"active_frequency_mhz"`
+	StandbyFrequency float64 `json:"standby_frequency_mhz"`
+}
+
+// GPSResponse describes GPS guidance to the next waypoint in get_navigation.
+type GPSResponse struct {
+	CourseToSteer        float64 `json:"course_to_steer_deg"`
+	DesiredTrack         float64 `json:"desired_track_deg"`
+	NextWaypointID       string  `json:"next_waypoint_id"`
+	NextWaypointDistance float64 `json:"next_waypoint_distance_nm"`
+	NextWaypointETE      float64 `json:"next_waypoint_ete_seconds"`
+}
+
+// NavigationResponse is the JSON payload returned by get_navigation.
+type NavigationResponse struct {
+	Nav1            NavRadioResponse `json:"nav1"`
+	Nav2            NavRadioResponse `json:"nav2"`
+	ADF             ADFResponse      `json:"adf"`
+	Com1            ComRadioResponse `json:"com1"`
+	Com2            ComRadioResponse `json:"com2"`
+	TransponderCode string           `json:"transponder_code"`
+	GPS             GPSResponse      `json:"gps"`
+	Timestamp       string           `json:"timestamp"`
+}
+
+// toFromNames maps the NAV TOFROM enum to names.
+var toFromNames = []string{"off", "to", "from"}
+
+// toFromName returns the name of a NAV TOFROM enum value.
+func toFromName(v float64) string {
+	if i := int(v); i >= 0 && i < len(toFromNames) && float64(i) == v {
+		return toFromNames[i]
+	}
+	return "off"
+}
+
+// roundFrequency trims floating-point noise from a radio frequency.
+func roundFrequency(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
+
+func newNavRadioResponse(r *types.NavRadio) NavRadioResponse {
+	return NavRadioResponse{
+		ActiveFrequency:  roundFrequency(r.ActiveFrequency),
+		StandbyFrequency: roundFrequency(r.StandbyFrequency),
+		OBS:              r.OBS,
+		Radial:           r.Radial,
+		CDI:              int(r.CDI),
+		GSI:              int(r.GSI),
+		ToFrom:           toFromName(r.ToFrom),
+		DME:              r.DME,
+		HasSignal:        r.HasNav != 0,
+		HasLocalizer:     r.HasLocalizer != 0,
+		HasGlideSlope:    r.HasGlideSlope != 0,
+	}
+}
+
+func (s *Server) handleGetNavigation(
+	_ context.Context,
+	_ *mcpsdk.CallToolRequest,
+	_ emptyInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	nav, err := s.state.GetNavigation()
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	return s.jsonResult(NavigationResponse{
+		Nav1: newNavRadioResponse(&nav.Nav1),
+		Nav2: newNavRadioResponse(&nav.Nav2),
+		ADF: ADFResponse{
+			Frequency:       roundFrequency(nav.ADFFrequency),
+			RelativeBearing: nav.ADFRadial,
+		},
+		Com1: ComRadioResponse{
+			ActiveFrequency:  roundFrequency(nav.Com1ActiveFrequency),
+			StandbyFrequency: roundFrequency(nav.Com1StandbyFrequency),
+		},
+		Com2: ComRadioResponse{
+			ActiveFrequency:  roundFrequency(nav.Com2ActiveFrequency),
+			StandbyFrequency: roundFrequency(nav.Com2StandbyFrequency),
+		},
+		TransponderCode: fmt.Sprintf("%04d", nav.TransponderCode),
+		GPS: GPSResponse{
+			CourseToSteer:        nav.GPSCourseToSteer,
+			DesiredTrack:         nav.GPSDesiredTrack,
+			NextWaypointID:       nav.GPSNextWaypointID,
+			NextWaypointDistance: nav.GPSNextWaypointDistance,
+			NextWaypointETE:      nav.GPSNextWaypointETE,
+		},
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,71 @@
+package mcp_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func TestGetNavigation(t *testing.T) {
+	sg := &mockStateGetter{nav: types.Navigation{
+		Nav1: types.NavRadio{
+			ActiveFrequency: 110.50000001, StandbyFrequency: 113.9, OBS: 271, Radial: 268.5,
+			CDI: -40, GSI: 12, ToFrom: 1, DME: 8.4, HasNav: 1, HasLocalizer: 1, HasGlideSlope: 1,
+		},
+		Nav2:                    types.NavRadio{ActiveFrequency: 114.8, ToFrom: 2},
+		ADFFrequency:            350,
+		ADFRadial:               45,
+		Com1ActiveFrequency:     121.49999999,
+		Com1StandbyFrequency:    118.3,
+		Com2ActiveFrequency:     124.85,
+		TransponderCode:         21,
+		GPSCourseToSteer:        275,
+		GPSDesiredTrack:         270,
+		GPSNextWaypointID:       "KSEA",
+		GPSNextWaypointDistance: 12.5,
+		GPSNextWaypointETE:      360,
+	}}
+	res := callTool(t, sg, "get_navigation", nil)
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	nav1 := m["nav1"].(map[string]any)
+	assert.Equal(t, 110.5, nav1["active_frequency_mhz"])
+	assert.Equal(t, 271.0, nav1["obs_deg"])
+	assert.Equal(t, 268.5, nav1["radial_deg"])
+	assert.Equal(t, -40.0, nav1["cdi_deflection"])
+	assert.Equal(t, 12.0, nav1["gsi_deflection"])
+	assert.Equal(t, "to", nav1["to_from"])
+	assert.Equal(t, 8.4, nav1["dme_nm"])
+	assert.Equal(t, true, nav1["has_signal"])
+	assert.Equal(t, true, nav1["has_localizer"])
+	assert.Equal(t, true, nav1["has_glideslope"])
+
+	nav2 := m["nav2"].(map[string]any)
+	assert.Equal(t, "from", nav2["to_from"])
+	assert.Equal(t, false, nav2["has_signal"])
+
+	assert.Equal(t, 350.0, m["adf"].(map[string]any)["frequency_khz"])
+	assert.Equal(t, 45.0, m["adf"].(map[string]any)["relative_bearing_deg"])
+	assert.Equal(t, 121.5, m["com1"].(map[string]any)["active_frequency_mhz"])
+	assert.Equal(t, 124.85, m["com2"].(map[string]any)["active_frequency_mhz"])
+	assert.Equal(t, "0021", m["transponder_code"])
+
+	gps := m["gps"].(map[string]any)
+	assert.Equal(t, 275.0, gps["course_to_steer_deg"])
+	assert.Equal(t, 270.0, gps["desired_track_deg"])
+	assert.Equal(t, "KSEA", gps["next_waypoint_id"])
+	assert.Equal(t, 12.5, gps["next_waypoint_distance_nm"])
+	assert.Equal(t, 360.0, gps["next_waypoint_ete_seconds"])
+	assert.Contains(t, m, "timestamp")
+}
+
+func TestGetNavigationStale(t *testing.T) {
+	res := callTool(t, &mockStateGetter{err: state.ErrStale}, "get_navigation", nil)
+	require.True(t, res.IsError)
+	assert.Equal(t, "DATA_STALE", parseJSON(t, res)["code"])
+}
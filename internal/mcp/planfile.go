@@ -0,0 +1,318 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	"github.com/eytandecker/flightsim-mcp/internal/pln"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// FlightPlanLoader loads .PLN files into the simulator's GPS.
+// Implemented by simconnect.Client.
+type FlightPlanLoader interface {
+	FlightPlanLoad(ctx context.Context, path string) error
+}
+
+// maxPlanBytes caps the size of a .PLN document passed to the plan tools.
+const maxPlanBytes = 1 << 20
+
+// planNamePattern matches the file names load_flight_plan writes, which
+// cannot leave the plan directory.
+var planNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// --- Input structs ---
+
+type summarizeFlightPlanInput struct {
+	PLN string `json:"pln" jsonschema:"contents of an MSFS .PLN flight plan file (XML)"`
+}
+
+type loadFlightPlanInput struct {
+	PLN  string `json:"pln" jsonschema:"contents of an MSFS .PLN flight plan file (XML)"`
+	Name string `json:"name,omitempty" jsonschema:"file name to save the plan as, letters, digits, dot, dash and underscore; defaults to DEPARTURE-DESTINATION"`
+}
+
+// --- Response structs ---
+
+// PlanAirportJSON is the departure or destination of a .PLN plan.
+type PlanAirportJSON struct {
+	ICAO        string  `json:"icao"`
+	Name        string  `json:"name,omitempty"`
+	Latitude    float64 `json:"latitude_deg"`
+	Longitude   float64 `json:"longitude_deg"`
+	ElevationFt float64 `json:"elevation_ft"`
+	Runway      string  `json:"runway,omitempty"`
+	// Position is the runway or parking spot the flight starts from.
+	Position string `json:"position,omitempty"`
+}
+
+// PlanLegJSON is one leg of a .PLN plan, ending at To.
+type PlanLegJSON struct {
+	From                 string   `json:"from"`
+	To                   string   `json:"to"`
+	ToType               string   `json:"to_type"`
+	Airway               string   `json:"airway,omitempty"`
+	ToLatitude           float64  `json:"to_latitude_deg"`
+	ToLongitude          float64  `json:"to_longitude_deg"`
+	ToAltitudeFt         *float64 `json:"to_altitude_ft,omitempty"`
+	CourseDeg            float64  `json:"course_true_deg"`
+	DistanceNM           float64  `json:"distance_nm"`
+	CumulativeDistanceNM float64  `json:"cumulative_distance_nm"`
+}
+
+// FlightPlanSummaryJSON describes a .PLN plan.
+type FlightPlanSummaryJSON struct {
+	Title              string          `json:"title"`
+	Description        string          `json:"description,omitempty"`
+	FlightRules        string          `json:"flight_rules"`
+	RouteType          string          `json:"route_type,omitempty"`
+	CruisingAltitudeFt float64         `json:"cruising_altitude_ft"`
+	Departure          PlanAirportJSON `json:"departure"`
+	Destination        PlanAirportJSON `json:"destination"`
+	SID                string          `json:"sid,omitempty"`
+	STAR               string          `json:"star,omitempty"`
+	Approach           string          `json:"approach,omitempty"`
+	Route              string          `json:"route"`
+	WaypointCount      int             `json:"waypoint_count"`
+	Legs               []PlanLegJSON   `json:"legs"`
+	TotalDistanceNM    float64         `json:"total_distance_nm"`
+	DirectDistanceNM   float64         `json:"direct_distance_nm"`
+}
+
+// SummarizeFlightPlanResponse is the JSON payload returned by
+// summarize_flight_plan. Problems lists why the simulator could not fly the
+// plan; load_flight_plan rejects plans that have any.
+type SummarizeFlightPlanResponse struct {
+	FlightPlanSummaryJSON
+	Valid     bool     `json:"valid"`
+	Problems  []string `json:"problems,omitempty"`
+	Timestamp string   `json:"timestamp"`
+}
+
+// LoadFlightPlanResponse is the JSON payload returned by load_flight_plan.
+// File is where the plan was written and SimFile the path the simulator was
+// asked to load.
+type LoadFlightPlanResponse struct {
+	Loaded    bool                  `json:"loaded"`
+	File      string                `json:"file"`
+	SimFile   string                `json:"sim_file"`
+	Plan      FlightPlanSummaryJSON `json:"plan"`
+	Timestamp string                `json:"timestamp"`
+}
+
+func (s *Server) registerPlanFileTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "summarize_flight_plan",
+		Description: "Decodes an MSFS .PLN flight plan without loading it: flight rules, cruising altitude, departure and " +
+			"destination with runways, SID, STAR and approach, the route in ICAO notation, and each leg with airway, true " +
+			"course and distance, plus total and direct distance and any problems that would stop the simulator flying it.",
+	}, s.handleSummarizeFlightPlan)
+
+	if s.planLoader != nil {
+		mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+			Name: "load_flight_plan",
+			Description: "Validates an MSFS .PLN flight plan, saves it to the server's flight plan directory and loads it " +
+				"into the simulator as the active GPS flight plan. get_flight_plan then lists every remaining leg of it.",
+		}, s.handleLoadFlightPlan)
+	}
+}
+
+func (s *Server) handleSummarizeFlightPlan(
+	_ context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input summarizeFlightPlanInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	fp, err := parsePlanInput(input.PLN)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	problems := fp.Problems()
+	return s.jsonResult(SummarizeFlightPlanResponse{
+		FlightPlanSummaryJSON: newFlightPlanSummary(&fp),
+		Valid:                 len(problems) == 0,
+		Problems:              problems,
+		Timestamp:             time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func (s *Server) handleLoadFlightPlan(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input loadFlightPlanInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	fp, err := parsePlanInput(input.PLN)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	if err := fp.Validate(); err != nil {
+		return s.errorResult(fmt.Errorf("%w: %w", ErrInvalidInput, err)), nil, nil
+	}
+
+	name := input.Name
+	if name == "" {
+		name = fp.Departure.ID + "-" + fp.Destination.ID
+	}
+	if strings.EqualFold(filepath.Ext(name), ".pln") {
+		name = name[:len(name)-len(".pln")]
+	}
+	if !planNamePattern.MatchString(name) {
+		return s.errorResult(fmt.Errorf("%w: name must be 1-64 letters, digits, dots, dashes or underscores", ErrInvalidInput)), nil, nil
+	}
+	name += ".pln"
+
+	file := filepath.Join(s.planDir, name)
+	// #nosec G306 -- the simulator reads the plan, possibly through a network share
+	if err := os.WriteFile(file, pln.Marshal(&fp), 0o644); err != nil {
+		return s.errorResult(fmt.Errorf("save flight plan: %w", err)), nil, nil
+	}
+
+	simFile := simPath(s.planSimDir, name)
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	if err := s.planLoader.FlightPlanLoad(ctx, simFile); err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	s.planMu.Lock()
+	s.loadedPlan = &fp
+	s.planMu.Unlock()
+
+	return s.jsonResult(LoadFlightPlanResponse{
+		Loaded:    true,
+		File:      file,
+		SimFile:   simFile,
+		Plan:      newFlightPlanSummary(&fp),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// parsePlanInput decodes the pln argument of the plan tools.
+func parsePlanInput(data string) (pln.FlightPlan, error) {
+	switch {
+	case strings.TrimSpace(data) == "":
+		return pln.FlightPlan{}, fmt.Errorf("%w: pln is required", ErrInvalidInput)
+	case len(data) > maxPlanBytes:
+		return pln.FlightPlan{}, fmt.Errorf("%w: pln is larger than %d bytes", ErrInvalidInput, maxPlanBytes)
+	}
+	fp, err := pln.Parse([]byte(data))
+	if err != nil {
+		return pln.FlightPlan{}, fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	return fp, nil
+}
+
+// simPath joins name, whose parts are separated by /, onto a directory on
+// the simulator's machine, using the separator that directory is written
+// with.
+func simPath(dir, name string) string {
+	sep := "/"
+	if strings.Contains(dir, `\`) {
+		sep = `\`
+	}
+	return strings.TrimRight(dir, `/\`) + sep + strings.ReplaceAll(name, "/", sep)
+}
+
+// loadedPlanFlown returns the plan last loaded with load_flight_plan while
+// the GPS is flying it: the waypoint counts agree and the active waypoint
+// has the same ident in both.
+func (s *Server) loadedPlanFlown(fp *types.FlightPlan) *pln.FlightPlan {
+	s.planMu.Lock()
+	plan := s.loadedPlan
+	s.planMu.Unlock()
+
+	if plan == nil || len(plan.Waypoints) != int(fp.WaypointCount) {
+		return nil
+	}
+	active := int(fp.ActiveWaypointIndex)
+	if active < 1 || active >= len(plan.Waypoints) || plan.Waypoints[active].ID != fp.NextWaypointID {
+		return nil
+	}
+	return plan
+}
+
+// planRoute converts the waypoints of a plan to the route get_flight_plan
+// reports legs for.
+func planRoute(plan *pln.FlightPlan) []routePoint {
+	route := make([]routePoint, len(plan.Waypoints))
+	for i := range plan.Waypoints {
+		wp := &plan.Waypoints[i]
+		route[i] = routePoint{
+			id: wp.ID, lat: wp.Position.Lat, lon: wp.Position.Lon,
+			altitudeFt: wp.Position.AltitudeFt, hasAltitude: wp.Position.AltitudeFt != 0,
+		}
+	}
+	return route
+}
+
+// newFlightPlanSummary describes a plan for the plan tools.
+func newFlightPlanSummary(fp *pln.FlightPlan) FlightPlanSummaryJSON {
+	out := FlightPlanSummaryJSON{
+		Title:              fp.Title,
+		Description:        fp.Description,
+		FlightRules:        fp.Type,
+		RouteType:          fp.RouteType,
+		CruisingAltitudeFt: fp.CruisingAltitudeFt,
+		SID:                fp.SID(),
+		STAR:               fp.STAR(),
+		Approach:           fp.Approach(),
+		Route:              fp.Route(),
+		WaypointCount:      len(fp.Waypoints),
+		Legs:               []PlanLegJSON{},
+		TotalDistanceNM:    round1(fp.DistanceNM()),
+	}
+	out.Departure = newPlanAirport(&fp.Departure, fp.Waypoints, 0)
+	out.Departure.Position = fp.DeparturePosition
+	out.Destination = newPlanAirport(&fp.Destination, fp.Waypoints, len(fp.Waypoints)-1)
+
+	var total float64
+	for i := 1; i < len(fp.Waypoints); i++ {
+		from, to := fp.Waypoints[i-1].Position, &fp.Waypoints[i]
+		dist := geo.DistanceNM(from.Lat, from.Lon, to.Position.Lat, to.Position.Lon)
+		total += dist
+		leg := PlanLegJSON{
+			From:                 fp.Waypoints[i-1].ID,
+			To:                   to.ID,
+			ToType:               to.Type,
+			Airway:               to.Airway,
+			ToLatitude:           to.Position.Lat,
+			ToLongitude:          to.Position.Lon,
+			CourseDeg:            math.Round(geo.BearingDeg(from.Lat, from.Lon, to.Position.Lat, to.Position.Lon)),
+			DistanceNM:           round1(dist),
+			CumulativeDistanceNM: round1(total),
+		}
+		if to.Position.AltitudeFt != 0 {
+			alt := math.Round(to.Position.AltitudeFt)
+			leg.ToAltitudeFt = &alt
+		}
+		out.Legs = append(out.Legs, leg)
+	}
+
+	dep, dest := out.Departure, out.Destination
+	out.DirectDistanceNM = round1(geo.DistanceNM(dep.Latitude, dep.Longitude, dest.Latitude, dest.Longitude))
+	return out
+}
+
+// newPlanAirport describes an airport of a plan. Its position falls back to
+// the waypoint at index, and its runway comes from that waypoint.
+func newPlanAirport(a *pln.Airport, waypoints []pln.Waypoint, index int) PlanAirportJSON {
+	out := PlanAirportJSON{ICAO: a.ID, Name: a.Name}
+	pos := a.Position
+	if index >= 0 && index < len(waypoints) {
+		wp := &waypoints[index]
+		if pos.Lat == 0 && pos.Lon == 0 {
+			pos = wp.Position
+		}
+		out.Runway = wp.Runway()
+	}
+	out.Latitude, out.Longitude, out.ElevationFt = pos.Lat, pos.Lon, math.Round(pos.AltitudeFt)
+	return out
+}
@@ -0,0 +1,282 @@
+package mcp_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/pln"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+type fakePlanLoader struct {
+	mu    sync.Mutex
+	paths []string
+	err   error
+}
+
+func (f *fakePlanLoader) FlightPlanLoad(_ context.Context, path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.paths = append(f.paths, path)
+	return f.err
+}
+
+func (f *fakePlanLoader) loaded() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.paths...)
+}
+
+// southboundPlan flies from KSEA 60 NM south to SOUTH, 40 NM on V23 to
+// FAR, then 20 NM to KXYZ.
+func southboundPlan() pln.FlightPlan {
+	at := func(nm float64) pln.Position {
+		lat, lon := geo.Destination(47.435, -122.31, 180, nm)
+		return pln.Position{Lat: lat, Lon: lon}
+	}
+	dep, dest := pln.Position{Lat: 47.435, Lon: -122.31, AltitudeFt: 433}, at(120)
+	dest.AltitudeFt = 150
+	return pln.FlightPlan{
+		Title: "KSEA to KXYZ", Type: pln.IFR, RouteType: "LowAlt", CruisingAltitudeFt: 9000,
+		Departure:         pln.Airport{ID: "KSEA", Name: "Seattle-Tacoma Intl", Position: dep},
+		Destination:       pln.Airport{ID: "KXYZ", Position: dest},
+		DeparturePosition: "16L",
+		Waypoints: []pln.Waypoint{
+			{ID: "KSEA", Type: pln.TypeAirport, Position: dep, SID: "SUMMA2", RunwayNumber: "16", RunwayDesignator: "LEFT"},
+			{ID: "SOUTH", Type: pln.TypeIntersection, Position: at(60), Region: "K1"},
+			{ID: "FAR", Type: pln.TypeVOR, Position: at(100), Airway: "V23"},
+			{ID: "KXYZ", Type: pln.TypeAirport, Position: dest, Approach: "RNAV"},
+		},
+	}
+}
+
+func planArg(fp pln.FlightPlan) string {
+	return string(pln.Marshal(&fp))
+}
+
+func TestSummarizeFlightPlan(t *testing.T) {
+	res := callTool(t, &mockStateGetter{}, "summarize_flight_plan", map[string]any{"pln": planArg(southboundPlan())})
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, "KSEA to KXYZ", m["title"])
+	assert.Equal(t, "IFR", m["flight_rules"])
+	assert.Equal(t, "LowAlt", m["route_type"])
+	assert.Equal(t, 9000.0, m["cruising_altitude_ft"])
+	assert.Equal(t, "SUMMA2", m["sid"])
+	assert.NotContains(t, m, "star")
+	assert.Equal(t, "RNAV", m["approach"])
+	assert.Equal(t, "KSEA SUMMA2 SOUTH V23 FAR KXYZ", m["route"])
+	assert.Equal(t, 4.0, m["waypoint_count"])
+	assert.Equal(t, 120.0, m["total_distance_nm"])
+	assert.Equal(t, 120.0, m["direct_distance_nm"])
+	assert.Equal(t, true, m["valid"])
+	assert.NotContains(t, m, "problems")
+
+	dep := m["departure"].(map[string]any)
+	assert.Equal(t, "KSEA", dep["icao"])
+	assert.Equal(t, "Seattle-Tacoma Intl", dep["name"])
+	assert.Equal(t, 433.0, dep["elevation_ft"])
+	assert.Equal(t, "16L", dep["runway"])
+	assert.Equal(t, "16L", dep["position"])
+	dest := m["destination"].(map[string]any)
+	assert.Equal(t, "KXYZ", dest["icao"])
+	assert.NotContains(t, dest, "runway")
+
+	legs := m["legs"].([]any)
+	require.Len(t, legs, 3)
+	leg := legs[1].(map[string]any)
+	assert.Equal(t, "SOUTH", leg["from"])
+	assert.Equal(t, "FAR", leg["to"])
+	assert.Equal(t, "VOR", leg["to_type"])
+	assert.Equal(t, "V23", leg["airway"])
+	assert.Equal(t, 180.0, leg["course_true_deg"])
+	assert.Equal(t, 40.0, leg["distance_nm"])
+	assert.Equal(t, 100.0, leg["cumulative_distance_nm"])
+	assert.NotContains(t, leg, "to_altitude_ft")
+}
+
+func TestSummarizeFlightPlanProblems(t *testing.T) {
+	fp := southboundPlan()
+	fp.Type = ""
+	fp.Waypoints = fp.Waypoints[:3]
+
+	res := callTool(t, &mockStateGetter{}, "summarize_flight_plan", map[string]any{"pln": planArg(fp)})
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, false, m["valid"])
+	assert.Equal(t, []any{
+		`flight rules "" must be IFR or VFR`,
+		"last waypoint FAR is not the destination airport KXYZ",
+	}, m["problems"])
+}
+
+func TestSummarizeFlightPlanInvalidInput(t *testing.T) {
+	for name, doc := range map[string]string{
+		"empty":   " ",
+		"not XML": "KSEA SUMMA2 KPDX",
+	} {
+		t.Run(name, func(t *testing.T) {
+			res := callTool(t, &mockStateGetter{}, "summarize_flight_plan", map[string]any{"pln": doc})
+			require.True(t, res.IsError)
+			assert.Equal(t, "INVALID_INPUT", parseJSON(t, res)["code"])
+		})
+	}
+}
+
+func TestLoadFlightPlan(t *testing.T) {
+	dir := t.TempDir()
+	loader := &fakePlanLoader{}
+	fp := southboundPlan()
+
+	res := callTool(t, &mockStateGetter{}, "load_flight_plan", map[string]any{"pln": planArg(fp)},
+		internalmcp.WithFlightPlanLoader(loader, dir, `C:\Users\pilot\Plans\`))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	file := filepath.Join(dir, "KSEA-KXYZ.pln")
+	assert.Equal(t, true, m["loaded"])
+	assert.Equal(t, file, m["file"])
+	assert.Equal(t, `C:\Users\pilot\Plans\KSEA-KXYZ.pln`, m["sim_file"])
+	assert.Equal(t, "KSEA SUMMA2 SOUTH V23 FAR KXYZ", m["plan"].(map[string]any)["route"])
+	assert.Equal(t, []string{`C:\Users\pilot\Plans\KSEA-KXYZ.pln`}, loader.loaded())
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, pln.Marshal(&fp), data)
+}
+
+func TestLoadFlightPlanName(t *testing.T) {
+	dir := t.TempDir()
+	loader := &fakePlanLoader{}
+
+	res := callTool(t, &mockStateGetter{}, "load_flight_plan",
+		map[string]any{"pln": planArg(southboundPlan()), "name": "pattern_work.PLN"},
+		internalmcp.WithFlightPlanLoader(loader, dir, ""))
+	require.False(t, res.IsError)
+
+	assert.FileExists(t, filepath.Join(dir, "pattern_work.pln"))
+	assert.Equal(t, []string{dir + "/pattern_work.pln"}, loader.loaded())
+}
+
+func TestLoadFlightPlanRejected(t *testing.T) {
+	invalid := southboundPlan()
+	invalid.Waypoints = invalid.Waypoints[:1]
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		loadErr  error
+		wantCode string
+		wantLoad bool
+	}{
+		{name: "invalid plan", args: map[string]any{"pln": planArg(invalid)}, wantCode: "INVALID_INPUT"},
+		{name: "not a plan", args: map[string]any{"pln": "<xml/>"}, wantCode: "INVALID_INPUT"},
+		{name: "path in name", args: map[string]any{"pln": planArg(southboundPlan()), "name": "../evil"}, wantCode: "INVALID_INPUT"},
+		{name: "separator in name", args: map[string]any{"pln": planArg(southboundPlan()), "name": `a\b`}, wantCode: "INVALID_INPUT"},
+		{
+			name:     "simulator rejects the file",
+			args:     map[string]any{"pln": planArg(southboundPlan())},
+			loadErr:  &simconnect.Exception{Code: simconnect.ExceptionLoadFlightPlan},
+			wantCode: "SIMCONNECT_EXCEPTION",
+			wantLoad: true,
+		},
+		{
+			name:     "not connected",
+			args:     map[string]any{"pln": planArg(southboundPlan())},
+			loadErr:  simconnect.ErrNotConnected,
+			wantCode: "SIMULATOR_NOT_CONNECTED",
+			wantLoad: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			loader := &fakePlanLoader{err: tt.loadErr}
+			res := callTool(t, &mockStateGetter{}, "load_flight_plan", tt.args,
+				internalmcp.WithFlightPlanLoader(loader, dir, ""))
+			require.True(t, res.IsError)
+			assert.Equal(t, tt.wantCode, parseJSON(t, res)["code"])
+			assert.Equal(t, tt.wantLoad, len(loader.loaded()) == 1)
+			if !tt.wantLoad {
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				assert.Empty(t, entries, "rejected plans must not be written")
+			}
+		})
+	}
+}
+
+func TestLoadFlightPlanRequiresLoader(t *testing.T) {
+	ctx := context.Background()
+	srv := internalmcp.NewServer(&mockStateGetter{})
+	st, ct := mcpsdk.NewInMemoryTransports()
+	_, err := srv.Connect(ctx, st)
+	require.NoError(t, err)
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "1.0"}, nil)
+	cs, err := client.Connect(ctx, ct, nil)
+	require.NoError(t, err)
+	defer cs.Close()
+
+	tools, err := cs.ListTools(ctx, nil)
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Contains(t, names, "summarize_flight_plan")
+	assert.NotContains(t, names, "load_flight_plan")
+}
+
+func TestGetFlightPlanListsLoadedRoute(t *testing.T) {
+	fp := southboundPlan()
+	// 20 NM into the SOUTH-FAR leg at 120 kts.
+	lat, lon := geo.Destination(47.435, -122.31, 180, 80)
+	sg := &mockStateGetter{pos: types.AircraftPosition{Latitude: lat, Longitude: lon, GroundSpeed: 120}}
+	gps := &fakeFlightPlans{fp: types.FlightPlan{
+		IsActive: 1, WaypointCount: 4, ActiveWaypointIndex: 2, NextWaypointID: "FAR",
+	}}
+	srv := internalmcp.NewServer(sg,
+		internalmcp.WithFlightPlanReader(gps),
+		internalmcp.WithFlightPlanLoader(&fakePlanLoader{}, t.TempDir(), ""))
+
+	before := parseJSON(t, callServerTool(t, srv, "get_flight_plan", nil))
+	assert.NotContains(t, before, "plan_title")
+	assert.Len(t, before["legs"], 1)
+
+	res := callServerTool(t, srv, "load_flight_plan", map[string]any{"pln": planArg(fp)})
+	require.False(t, res.IsError)
+
+	m := parseJSON(t, callServerTool(t, srv, "get_flight_plan", nil))
+	assert.Equal(t, "KSEA to KXYZ", m["plan_title"])
+	legs := m["legs"].([]any)
+	require.Len(t, legs, 2)
+	active, last := legs[0].(map[string]any), legs[1].(map[string]any)
+	assert.Equal(t, "SOUTH", active["from"])
+	assert.Equal(t, "FAR", active["to"])
+	assert.Equal(t, true, active["active"])
+	assert.Equal(t, 20.0, active["distance_to_go_nm"])
+	assert.Equal(t, "FAR", last["from"])
+	assert.Equal(t, "KXYZ", last["to"])
+	assert.Equal(t, 150.0, last["to_altitude_ft"])
+	assert.Equal(t, 40.0, last["total_distance_nm"])
+	assert.Equal(t, 1200.0, last["cumulative_ete_seconds"])
+
+	// A different plan in the GPS falls back to the active leg.
+	gps.fp.NextWaypointID = "OTHER"
+	m = parseJSON(t, callServerTool(t, srv, "get_flight_plan", nil))
+	assert.NotContains(t, m, "plan_title")
+	assert.Len(t, m["legs"], 1)
+}
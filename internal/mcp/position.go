@@ -0,0 +1,432 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// AircraftPositioner places the user aircraft. Implemented by
+// simconnect.Client.
+type AircraftPositioner interface {
+	SetInitPosition(ctx context.Context, pos simconnect.InitPosition) error
+}
+
+// Limits on set_aircraft_position arguments.
+const (
+	minPositionAltitudeFt  = -1500
+	maxPositionAltitudeFt  = 60000
+	maxPositionAirspeedKts = 1000
+	maxFinalDistanceNM     = 50
+	minGlidePathDeg        = 1
+	maxGlidePathDeg        = 10
+)
+
+// defaultGlidePathDeg is used for final approaches to runways without a
+// glideslope.
+const defaultGlidePathDeg = 3.0
+
+// Read-back tolerances for set_aircraft_position. The aircraft may already
+// have flown a little when it is read back. On the ground the altitude is
+// the terrain's, so only the height above ground is checked; groundAGLFt
+// allows for the aircraft's reference point sitting above its wheels.
+const (
+	positionToleranceNM         = 0.5
+	positionAltitudeToleranceFt = 200.0
+	positionHeadingToleranceDeg = 10.0
+	groundAGLFt                 = 50.0
+)
+
+// --- Input structs ---
+
+type finalApproachInput struct {
+	Airport              string   `json:"airport,omitempty" jsonschema:"airport ident, e.g. KSEA; the runway end is looked up in the simulator's facility database"`
+	Runway               string   `json:"runway,omitempty" jsonschema:"runway to land on at airport, e.g. 16R"`
+	ThresholdLatitude    *float64 `json:"threshold_latitude,omitempty" jsonschema:"runway threshold latitude in degrees, instead of airport and runway"`
+	ThresholdLongitude   *float64 `json:"threshold_longitude,omitempty" jsonschema:"runway threshold longitude in degrees"`
+	ThresholdElevationFt *float64 `json:"threshold_elevation_ft,omitempty" jsonschema:"runway threshold elevation in feet MSL"`
+	RunwayHeadingDeg     *float64 `json:"runway_heading_deg,omitempty" jsonschema:"true heading of the runway in the landing direction, 0-360"`
+	DistanceNM           float64  `json:"distance_nm" jsonschema:"distance before the runway end or given threshold in nautical miles, 0-50; 0 with on_ground lines up on the runway"`
+	GlidePathDeg         float64  `json:"glide_path_deg,omitempty" jsonschema:"glide path angle in degrees, 1-10; defaults to the runway's ILS glideslope, else 3"`
+}
+
+type setAircraftPositionInput struct {
+	Latitude      *float64            `json:"latitude,omitempty" jsonschema:"latitude in degrees, -90 to 90; omit with final_approach"`
+	Longitude     *float64            `json:"longitude,omitempty" jsonschema:"longitude in degrees, -180 to 180; omit with final_approach"`
+	AltitudeFt    *float64            `json:"altitude_ft,omitempty" jsonschema:"altitude in feet MSL; with final_approach, overrides the glide path altitude"`
+	HeadingDeg    *float64            `json:"heading_deg,omitempty" jsonschema:"true heading in degrees, 0-360; with final_approach, defaults to the runway heading"`
+	AirspeedKts   *float64            `json:"airspeed_kts,omitempty" jsonschema:"airspeed in knots, 0-1000; omit to keep the current airspeed (0 on the ground)"`
+	OnGround      bool                `json:"on_ground,omitempty" jsonschema:"place the aircraft on the ground at latitude and longitude, ignoring altitude"`
+	PitchDeg      float64             `json:"pitch_deg,omitempty" jsonschema:"pitch in degrees, -90 to 90, positive nose down as in get_aircraft_position (default 0)"`
+	BankDeg       float64             `json:"bank_deg,omitempty" jsonschema:"bank in degrees, -180 to 180 (default 0)"`
+	FinalApproach *finalApproachInput `json:"final_approach,omitempty" jsonschema:"place the aircraft on a runway's extended centerline instead of at latitude and longitude"`
+}
+
+// --- Response structs ---
+
+// AircraftPositionJSON is a position requested from or read back after
+// set_aircraft_position. AirspeedKts is omitted when the current airspeed
+// is kept. OnGround is only set when requested; the read-back position
+// carries AltitudeAGLFt instead.
+type AircraftPositionJSON struct {
+	Latitude      float64  `json:"latitude_deg"`
+	Longitude     float64  `json:"longitude_deg"`
+	AltitudeFt    float64  `json:"altitude_ft"`
+	AltitudeAGLFt *float64 `json:"altitude_agl_ft,omitempty"`
+	HeadingDeg    float64  `json:"heading_true_deg"`
+	AirspeedKts   *float64 `json:"airspeed_kts,omitempty"`
+	OnGround      bool     `json:"on_ground,omitempty"`
+}
+
+// FinalApproachJSON describes the extended centerline point
+// set_aircraft_position computed. The runway end is the given threshold, or
+// the physical end of a runway from the facility database, which is not its
+// threshold when the threshold is displaced.
+type FinalApproachJSON struct {
+	Airport                string  `json:"airport,omitempty"`
+	Runway                 string  `json:"runway,omitempty"`
+	RunwayEndLatitude      float64 `json:"runway_end_latitude_deg"`
+	RunwayEndLongitude     float64 `json:"runway_end_longitude_deg"`
+	RunwayEndElevationFt   float64 `json:"runway_end_elevation_ft"`
+	RunwayHeadingDeg       float64 `json:"runway_heading_true_deg"`
+	DistanceNM             float64 `json:"distance_nm"`
+	GlidePathDeg           float64 `json:"glide_path_deg"`
+	HeightAboveRunwayEndFt float64 `json:"height_above_runway_end_ft"`
+}
+
+// AircraftPositionCommandResponse is the JSON payload returned by
+// set_aircraft_position once the aircraft is read back at the new position.
+type AircraftPositionCommandResponse struct {
+	Command       string               `json:"command"`
+	Requested     AircraftPositionJSON `json:"requested"`
+	Actual        AircraftPositionJSON `json:"actual"`
+	FinalApproach *FinalApproachJSON   `json:"final_approach,omitempty"`
+	Verified      bool                 `json:"verified"`
+	Timestamp     string               `json:"timestamp"`
+}
+
+func (s *Server) registerPositionTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "set_aircraft_position",
+		Description: "Instantly moves the user aircraft to a latitude, longitude, altitude and true heading, with an airspeed, " +
+			"in flight or on the ground, e.g. to reset a training scenario. Pass final_approach instead of latitude and " +
+			"longitude to be placed on a runway's extended centerline: airport and runway (e.g. KSEA and 16R), or a " +
+			"threshold position, elevation and runway heading, plus distance_nm; the altitude follows the glide path " +
+			"unless altitude_ft is given. Database runways are measured from their physical end, not a displaced " +
+			"threshold. Give airspeed_kts for an approach speed. The move is verified by reading back the position, " +
+			"true heading and altitude, or the height above ground with on_ground. This replaces the aircraft's " +
+			"position immediately and cannot be undone.",
+	}, s.handleSetAircraftPosition)
+}
+
+func (s *Server) handleSetAircraftPosition(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input setAircraftPositionInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	if _, err := s.state.GetPosition(); err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	var final *FinalApproachJSON
+	if input.FinalApproach != nil {
+		if input.Latitude != nil || input.Longitude != nil {
+			return s.errorResult(fmt.Errorf("%w: give latitude and longitude or final_approach, not both", ErrInvalidInput)), nil, nil
+		}
+		var err error
+		if final, err = s.finalApproach(ctx, input.FinalApproach); err != nil {
+			return s.errorResult(err), nil, nil
+		}
+		lat, lon := geo.ExtendedCenterline(final.RunwayEndLatitude, final.RunwayEndLongitude, final.RunwayHeadingDeg, final.DistanceNM)
+		alt := math.Round(final.RunwayEndElevationFt + final.HeightAboveRunwayEndFt)
+		heading := final.RunwayHeadingDeg
+		input.Latitude, input.Longitude = &lat, &lon
+		if input.AltitudeFt == nil {
+			input.AltitudeFt = &alt
+		}
+		if input.HeadingDeg == nil {
+			input.HeadingDeg = &heading
+		}
+	}
+
+	requested, err := requestedPosition(&input)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	initPos := simconnect.InitPosition{
+		Latitude:  requested.Latitude,
+		Longitude: requested.Longitude,
+		Altitude:  requested.AltitudeFt,
+		Pitch:     input.PitchDeg,
+		Bank:      input.BankDeg,
+		Heading:   requested.HeadingDeg,
+		Airspeed:  simconnect.InitPositionAirspeedKeep,
+	}
+	if requested.OnGround {
+		initPos.OnGround = 1
+	}
+	if requested.AirspeedKts != nil {
+		initPos.Airspeed = uint32(math.Round(*requested.AirspeedKts)) // #nosec G115 -- validated to 0-1000
+	}
+	setCtx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	if err := s.positioner.SetInitPosition(setCtx, initPos); err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	pos, ok := awaitState(ctx, s.commandTimeout, s.state.GetPosition, func(pos *types.AircraftPosition) bool {
+		return positionReached(pos, &requested)
+	})
+	speed := math.Round(pos.IndicatedSpeed)
+	agl := math.Round(pos.AltitudeAGL)
+	actual := AircraftPositionJSON{
+		Latitude:      pos.Latitude,
+		Longitude:     pos.Longitude,
+		AltitudeFt:    math.Round(pos.AltitudeMSL),
+		AltitudeAGLFt: &agl,
+		HeadingDeg:    round1(geo.NormalizeDeg(pos.HeadingTrue)),
+		AirspeedKts:   &speed,
+	}
+	if !ok {
+		return notConfirmedResult(&CommandErrorResponse{
+			Suggestion: fmt.Sprintf("The aircraft was not read back within %.1f NM, %.0f° and %s of the requested "+
+				"position within %s. Check get_aircraft_position and retry.",
+				positionToleranceNM, positionHeadingToleranceDeg, altitudeTolerance(requested.OnGround), s.commandTimeout),
+			Command:   "set_aircraft_position",
+			Requested: requested,
+			Actual:    actual,
+		}), nil, nil
+	}
+
+	return s.jsonResult(AircraftPositionCommandResponse{
+		Command:       "set_aircraft_position",
+		Requested:     requested,
+		Actual:        actual,
+		FinalApproach: final,
+		Verified:      true,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// positionReached reports whether a read-back position matches a requested
+// one within the set_aircraft_position tolerances.
+func positionReached(pos *types.AircraftPosition, requested *AircraftPositionJSON) bool {
+	if geo.DistanceNM(pos.Latitude, pos.Longitude, requested.Latitude, requested.Longitude) > positionToleranceNM ||
+		headingDiff(pos.HeadingTrue, requested.HeadingDeg) > positionHeadingToleranceDeg {
+		return false
+	}
+	if requested.OnGround {
+		return pos.AltitudeAGL <= groundAGLFt
+	}
+	return math.Abs(pos.AltitudeMSL-requested.AltitudeFt) <= positionAltitudeToleranceFt
+}
+
+// altitudeTolerance describes the altitude check of positionReached.
+func altitudeTolerance(onGround bool) string {
+	if onGround {
+		return fmt.Sprintf("%.0f ft above the ground", groundAGLFt)
+	}
+	return fmt.Sprintf("%.0f ft", positionAltitudeToleranceFt)
+}
+
+// requestedPosition validates the position arguments, filled in from
+// final_approach when given.
+func requestedPosition(input *setAircraftPositionInput) (AircraftPositionJSON, error) {
+	switch {
+	case input.Latitude == nil || input.Longitude == nil:
+		return AircraftPositionJSON{}, fmt.Errorf("%w: latitude and longitude, or final_approach, are required", ErrInvalidInput)
+	case input.AltitudeFt == nil && !input.OnGround:
+		return AircraftPositionJSON{}, fmt.Errorf("%w: altitude_ft is required unless on_ground is set", ErrInvalidInput)
+	case input.HeadingDeg == nil:
+		return AircraftPositionJSON{}, fmt.Errorf("%w: heading_deg is required", ErrInvalidInput)
+	}
+	pos := AircraftPositionJSON{
+		Latitude:    *input.Latitude,
+		Longitude:   *input.Longitude,
+		HeadingDeg:  *input.HeadingDeg,
+		AirspeedKts: input.AirspeedKts,
+		OnGround:    input.OnGround,
+	}
+	if input.AltitudeFt != nil {
+		pos.AltitudeFt = *input.AltitudeFt
+	}
+	if input.OnGround && pos.AirspeedKts == nil {
+		zero := 0.0
+		pos.AirspeedKts = &zero
+	}
+
+	if err := validateLatLon(pos.Latitude, pos.Longitude, "latitude", "longitude"); err != nil {
+		return AircraftPositionJSON{}, err
+	}
+	switch {
+	case math.IsNaN(pos.AltitudeFt) || pos.AltitudeFt < minPositionAltitudeFt || pos.AltitudeFt > maxPositionAltitudeFt:
+		return AircraftPositionJSON{}, fmt.Errorf("%w: altitude_ft must be %d to %d", ErrInvalidInput, minPositionAltitudeFt, maxPositionAltitudeFt)
+	case !(pos.HeadingDeg >= 0 && pos.HeadingDeg <= 360):
+		return AircraftPositionJSON{}, fmt.Errorf("%w: heading_deg must be 0 to 360", ErrInvalidInput)
+	case pos.AirspeedKts != nil && !(*pos.AirspeedKts >= 0 && *pos.AirspeedKts <= maxPositionAirspeedKts):
+		return AircraftPositionJSON{}, fmt.Errorf("%w: airspeed_kts must be 0 to %d", ErrInvalidInput, maxPositionAirspeedKts)
+	case !(input.PitchDeg >= -90 && input.PitchDeg <= 90):
+		return AircraftPositionJSON{}, fmt.Errorf("%w: pitch_deg must be -90 to 90", ErrInvalidInput)
+	case !(input.BankDeg >= -180 && input.BankDeg <= 180):
+		return AircraftPositionJSON{}, fmt.Errorf("%w: bank_deg must be -180 to 180", ErrInvalidInput)
+	}
+	pos.HeadingDeg = geo.NormalizeDeg(pos.HeadingDeg)
+	return pos, nil
+}
+
+// finalApproach resolves the runway end of a final approach, from the
+// facility database or the given threshold, and the glide path height at
+// its distance.
+func (s *Server) finalApproach(ctx context.Context, input *finalApproachInput) (*FinalApproachJSON, error) {
+	if !(input.DistanceNM >= 0 && input.DistanceNM <= maxFinalDistanceNM) {
+		return nil, fmt.Errorf("%w: final_approach.distance_nm must be 0 to %d", ErrInvalidInput, maxFinalDistanceNM)
+	}
+	if input.GlidePathDeg != 0 && !(input.GlidePathDeg >= minGlidePathDeg && input.GlidePathDeg <= maxGlidePathDeg) {
+		return nil, fmt.Errorf("%w: final_approach.glide_path_deg must be %d to %d", ErrInvalidInput, minGlidePathDeg, maxGlidePathDeg)
+	}
+
+	var final *FinalApproachJSON
+	var glideslope float64
+	if input.Airport != "" || input.Runway != "" {
+		if input.ThresholdLatitude != nil || input.ThresholdLongitude != nil {
+			return nil, fmt.Errorf("%w: give final_approach airport and runway or a threshold position, not both", ErrInvalidInput)
+		}
+		var err error
+		if final, glideslope, err = s.runwayEnd(ctx, input.Airport, input.Runway); err != nil {
+			return nil, err
+		}
+	} else {
+		if input.ThresholdLatitude == nil || input.ThresholdLongitude == nil ||
+			input.ThresholdElevationFt == nil || input.RunwayHeadingDeg == nil {
+			return nil, fmt.Errorf("%w: final_approach needs airport and runway, or threshold_latitude, threshold_longitude, "+
+				"threshold_elevation_ft and runway_heading_deg", ErrInvalidInput)
+		}
+		final = &FinalApproachJSON{
+			RunwayEndLatitude:    *input.ThresholdLatitude,
+			RunwayEndLongitude:   *input.ThresholdLongitude,
+			RunwayEndElevationFt: *input.ThresholdElevationFt,
+			RunwayHeadingDeg:     *input.RunwayHeadingDeg,
+		}
+		if err := validateLatLon(final.RunwayEndLatitude, final.RunwayEndLongitude,
+			"final_approach.threshold_latitude", "final_approach.threshold_longitude"); err != nil {
+			return nil, err
+		}
+		if !(final.RunwayHeadingDeg >= 0 && final.RunwayHeadingDeg <= 360) {
+			return nil, fmt.Errorf("%w: final_approach.runway_heading_deg must be 0 to 360", ErrInvalidInput)
+		}
+		if !(final.RunwayEndElevationFt >= minPositionAltitudeFt && final.RunwayEndElevationFt <= maxPositionAltitudeFt) {
+			return nil, fmt.Errorf("%w: final_approach.threshold_elevation_ft must be %d to %d", ErrInvalidInput, minPositionAltitudeFt, maxPositionAltitudeFt)
+		}
+		final.RunwayHeadingDeg = geo.NormalizeDeg(final.RunwayHeadingDeg)
+	}
+
+	final.DistanceNM = input.DistanceNM
+	switch {
+	case input.GlidePathDeg != 0:
+		final.GlidePathDeg = input.GlidePathDeg
+	case glideslope >= minGlidePathDeg && glideslope <= maxGlidePathDeg:
+		final.GlidePathDeg = round1(glideslope)
+	default:
+		final.GlidePathDeg = defaultGlidePathDeg
+	}
+	final.HeightAboveRunwayEndFt = math.Round(geo.GlidePathHeightFt(final.DistanceNM, final.GlidePathDeg))
+	return final, nil
+}
+
+// runwayEnd looks up the position of a runway end and the angle of its ILS
+// glideslope, or 0 when it has none. The end is half the runway length from
+// its center; the facility database gives no displaced thresholds.
+func (s *Server) runwayEnd(ctx context.Context, ident, runway string) (*FinalApproachJSON, float64, error) {
+	if s.facilities == nil {
+		return nil, 0, fmt.Errorf("%w: the facility database is not available; give final_approach threshold_latitude, "+
+			"threshold_longitude, threshold_elevation_ft and runway_heading_deg instead", ErrInvalidInput)
+	}
+	icao := strings.ToUpper(strings.TrimSpace(ident))
+	if !icaoPattern.MatchString(icao) {
+		return nil, 0, fmt.Errorf("%w: final_approach.airport must be 2-6 letters or digits, e.g. KSEA", ErrInvalidInput)
+	}
+	name := normalizeRunwayName(runway)
+	if name == "" {
+		return nil, 0, fmt.Errorf("%w: final_approach.runway is required with airport, e.g. 16R", ErrInvalidInput)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	airport, err := s.facilities.AirportInfo(ctx, icao)
+	if errors.Is(err, simconnect.ErrFacilityNotFound) {
+		err = fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var ends []string
+	for i := range airport.Runways {
+		rw := &airport.Runways[i]
+		for _, end := range []struct {
+			name    string
+			heading float64
+			ils     *types.ILS
+		}{
+			{runwayName(rw.PrimaryNumber, rw.PrimaryDesignator), rw.Heading, &rw.PrimaryILS},
+			{runwayName(rw.SecondaryNumber, rw.SecondaryDesignator), rw.Heading + 180, &rw.SecondaryILS},
+		} {
+			ends = append(ends, end.name)
+			if end.name != name {
+				continue
+			}
+			heading := geo.NormalizeDeg(end.heading)
+			lat, lon := geo.ExtendedCenterline(rw.Latitude, rw.Longitude, heading, rw.Length/2/geo.MetersPerNM)
+			var glideslope float64
+			if end.ils.HasGlideSlope {
+				glideslope = end.ils.GlideSlope
+			}
+			return &FinalApproachJSON{
+				Airport:              airport.ICAO,
+				Runway:               end.name,
+				RunwayEndLatitude:    lat,
+				RunwayEndLongitude:   lon,
+				RunwayEndElevationFt: math.Round(rw.Altitude / geo.MetersPerFoot),
+				RunwayHeadingDeg:     round1(heading),
+			}, glideslope, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("%w: %s has no runway %s; runways: %s", ErrInvalidInput, airport.ICAO, name, strings.Join(ends, ", "))
+}
+
+// normalizeRunwayName formats a runway argument such as "rwy 9l" like
+// runwayName does: "09L".
+func normalizeRunwayName(runway string) string {
+	name := strings.ToUpper(strings.TrimSpace(runway))
+	for _, prefix := range []string{"RUNWAY", "RWY", "RW"} {
+		if strings.HasPrefix(name, prefix) {
+			name = strings.TrimSpace(strings.TrimPrefix(name, prefix))
+			break
+		}
+	}
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' && (len(name) == 1 || name[1] < '0' || name[1] > '9') {
+		name = "0" + name
+	}
+	return name
+}
+
+// validateLatLon checks a latitude and longitude in degrees, naming the
+// arguments in the error.
+func validateLatLon(lat, lon float64, latName, lonName string) error {
+	if !(lat >= -90 && lat <= 90) {
+		return fmt.Errorf("%w: %s must be -90 to 90", ErrInvalidInput, latName)
+	}
+	if !(lon >= -180 && lon <= 180) {
+		return fmt.Errorf("%w: %s must be -180 to 180", ErrInvalidInput, lonName)
+	}
+	return nil
+}
@@ -0,0 +1,369 @@
+package mcp_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// fakePositioner is a StateGetter and AircraftPositioner whose aircraft
+// moves to each position set, the way the simulator would, over flat
+// terrain at sea level.
+type fakePositioner struct {
+	mockStateGetter
+
+	mu     sync.Mutex
+	ignore bool                          // when true, positions are recorded but have no effect
+	drift  func(*types.AircraftPosition) // when set, adjusts each position the aircraft moves to
+	setErr error
+	set    []simconnect.InitPosition
+}
+
+func (f *fakePositioner) GetPosition() (types.AircraftPosition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pos, f.err
+}
+
+func (f *fakePositioner) SetInitPosition(_ context.Context, pos simconnect.InitPosition) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set = append(f.set, pos)
+	if f.setErr != nil || f.ignore {
+		return f.setErr
+	}
+	f.pos = types.AircraftPosition{
+		Latitude: pos.Latitude, Longitude: pos.Longitude, AltitudeMSL: pos.Altitude, AltitudeAGL: pos.Altitude,
+		HeadingTrue: pos.Heading, IndicatedSpeed: float64(pos.Airspeed),
+	}
+	if pos.OnGround != 0 {
+		f.pos.AltitudeMSL, f.pos.AltitudeAGL = 8, 8
+	}
+	if f.drift != nil {
+		f.drift(&f.pos)
+	}
+	return nil
+}
+
+func (f *fakePositioner) positions() []simconnect.InitPosition {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]simconnect.InitPosition(nil), f.set...)
+}
+
+func callPositionTool(t *testing.T, f *fakePositioner, args map[string]any, opts ...internalmcp.Option) *mcpsdk.CallToolResult {
+	t.Helper()
+	opts = append(opts, internalmcp.WithAircraftPositioner(f), internalmcp.WithCommandTimeout(200*time.Millisecond))
+	return callTool(t, f, "set_aircraft_position", args, opts...)
+}
+
+// approachAirport has one 3000 m runway, 16R/34L, whose center lies on the
+// 47.45 parallel and whose 16R end has an ILS with a 3.5° glideslope.
+var approachAirport = types.Airport{
+	ICAO: "KSEA", Latitude: 47.449, Longitude: -122.309, Altitude: 131.7,
+	Runways: []types.Runway{{
+		Latitude: 47.45, Longitude: -122.32, Altitude: 120, Heading: 180, Length: 3000,
+		PrimaryNumber: 16, PrimaryDesignator: 2, SecondaryNumber: 34, SecondaryDesignator: 1,
+		PrimaryILS: types.ILS{Ident: "ISZI", GlideSlope: 3.5, HasGlideSlope: true},
+	}},
+}
+
+// end16R and end34L are the runway ends, 1500 m north and south of its
+// center.
+var end16R, end34L = runwayEnd(0), runwayEnd(180)
+
+func runwayEnd(bearing float64) [2]float64 {
+	rw := approachAirport.Runways[0]
+	lat, lon := geo.Destination(rw.Latitude, rw.Longitude, bearing, rw.Length/2/geo.MetersPerNM)
+	return [2]float64{lat, lon}
+}
+
+func TestSetAircraftPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want simconnect.InitPosition
+	}{
+		{
+			name: "in flight",
+			args: map[string]any{"latitude": 47.6, "longitude": -122.4, "altitude_ft": 3000, "heading_deg": 163, "airspeed_kts": 120.4},
+			want: simconnect.InitPosition{Latitude: 47.6, Longitude: -122.4, Altitude: 3000, Heading: 163, Airspeed: 120},
+		},
+		{
+			name: "keeps the current airspeed",
+			args: map[string]any{"latitude": 47.6, "longitude": -122.4, "altitude_ft": 3000, "heading_deg": 360, "bank_deg": 20},
+			want: simconnect.InitPosition{
+				Latitude: 47.6, Longitude: -122.4, Altitude: 3000, Bank: 20,
+				Airspeed: simconnect.InitPositionAirspeedKeep,
+			},
+		},
+		{
+			name: "on the ground",
+			args: map[string]any{"latitude": 47.6, "longitude": -122.4, "heading_deg": 90, "on_ground": true},
+			want: simconnect.InitPosition{Latitude: 47.6, Longitude: -122.4, Heading: 90, OnGround: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakePositioner{}
+			res := callPositionTool(t, f, tt.args)
+			require.False(t, res.IsError, "%v", res.Content)
+			m := parseJSON(t, res)
+
+			assert.Equal(t, []simconnect.InitPosition{tt.want}, f.positions())
+			assert.Equal(t, "set_aircraft_position", m["command"])
+			assert.Equal(t, true, m["verified"])
+			actual := m["actual"].(map[string]any)
+			assert.Equal(t, tt.want.Latitude, actual["latitude_deg"])
+			assert.Contains(t, actual, "altitude_agl_ft")
+			assert.NotContains(t, actual, "on_ground", "on_ground is not read back")
+			assert.NotContains(t, m, "final_approach")
+		})
+	}
+}
+
+func TestSetAircraftPositionFinalApproach(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		runwayEnd [2]float64
+		want      map[string]any
+		wantAltFt float64
+		wantHdg   float64
+		wantSpeed uint32
+	}{
+		{
+			name: "threshold and glide path",
+			args: map[string]any{
+				"airspeed_kts": 140,
+				"final_approach": map[string]any{
+					"threshold_latitude": 47.4638, "threshold_longitude": -122.3081, "threshold_elevation_ft": 432,
+					"runway_heading_deg": 163, "distance_nm": 10,
+				},
+			},
+			runwayEnd: [2]float64{47.4638, -122.3081},
+			want: map[string]any{
+				"runway_end_latitude_deg": 47.4638, "runway_end_longitude_deg": -122.3081, "runway_end_elevation_ft": 432.0,
+				"runway_heading_true_deg": 163.0, "distance_nm": 10.0, "glide_path_deg": 3.0, "height_above_runway_end_ft": 3184.0,
+			},
+			wantAltFt: 3616,
+			wantHdg:   163,
+			wantSpeed: 140,
+		},
+		{
+			name: "runway with an ILS glideslope",
+			args: map[string]any{
+				"airspeed_kts":   130,
+				"final_approach": map[string]any{"airport": "ksea", "runway": "16R", "distance_nm": 10},
+			},
+			runwayEnd: end16R,
+			want: map[string]any{
+				"airport": "KSEA", "runway": "16R", "runway_end_elevation_ft": 394.0, "runway_heading_true_deg": 180.0,
+				"distance_nm": 10.0, "glide_path_deg": 3.5, "height_above_runway_end_ft": 3716.0,
+			},
+			wantAltFt: 4110,
+			wantHdg:   180,
+			wantSpeed: 130,
+		},
+		{
+			name: "lined up on the runway",
+			args: map[string]any{
+				"on_ground":      true,
+				"final_approach": map[string]any{"airport": "KSEA", "runway": "rwy 34l", "distance_nm": 0},
+			},
+			runwayEnd: end34L,
+			want: map[string]any{
+				"airport": "KSEA", "runway": "34L", "runway_end_elevation_ft": 394.0, "runway_heading_true_deg": 0.0,
+				"distance_nm": 0.0, "glide_path_deg": 3.0, "height_above_runway_end_ft": 0.0,
+			},
+			wantAltFt: 394,
+			wantHdg:   0,
+		},
+		{
+			name: "altitude and heading override",
+			args: map[string]any{
+				"altitude_ft": 3000, "heading_deg": 170,
+				"final_approach": map[string]any{"airport": "KSEA", "runway": "16R", "distance_nm": 10, "glide_path_deg": 3},
+			},
+			runwayEnd: end16R,
+			want: map[string]any{
+				"airport": "KSEA", "runway": "16R", "runway_end_elevation_ft": 394.0, "runway_heading_true_deg": 180.0,
+				"distance_nm": 10.0, "glide_path_deg": 3.0, "height_above_runway_end_ft": 3184.0,
+			},
+			wantAltFt: 3000,
+			wantHdg:   170,
+			wantSpeed: simconnect.InitPositionAirspeedKeep,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakePositioner{}
+			facilities := &fakeFacilities{airports: map[string]types.Airport{"KSEA": approachAirport}}
+			res := callPositionTool(t, f, tt.args, internalmcp.WithFacilityReader(facilities))
+			require.False(t, res.IsError, "%v", res.Content)
+			m := parseJSON(t, res)
+
+			final := m["final_approach"].(map[string]any)
+			for k, v := range tt.want {
+				assert.Equal(t, v, final[k], k)
+			}
+			assert.InDelta(t, tt.runwayEnd[0], final["runway_end_latitude_deg"], 1e-6)
+			assert.InDelta(t, tt.runwayEnd[1], final["runway_end_longitude_deg"], 1e-6)
+
+			set := f.positions()
+			require.Len(t, set, 1)
+			pos := set[0]
+			assert.InDelta(t, tt.want["distance_nm"], geo.DistanceNM(pos.Latitude, pos.Longitude, tt.runwayEnd[0], tt.runwayEnd[1]), 0.01)
+			assert.Equal(t, tt.wantAltFt, pos.Altitude)
+			assert.InDelta(t, tt.wantHdg, pos.Heading, 1e-9)
+			assert.Equal(t, tt.wantSpeed, pos.Airspeed)
+		})
+	}
+}
+
+func TestSetAircraftPositionRejectsInput(t *testing.T) {
+	threshold := map[string]any{
+		"threshold_latitude": 47.4638, "threshold_longitude": -122.3081, "threshold_elevation_ft": 432,
+		"runway_heading_deg": 163, "distance_nm": 10,
+	}
+	with := func(m map[string]any, k string, v any) map[string]any {
+		out := map[string]any{k: v}
+		for key, val := range m {
+			if key != k {
+				out[key] = val
+			}
+		}
+		return out
+	}
+	flying := map[string]any{"latitude": 47.6, "longitude": -122.4, "altitude_ft": 3000, "heading_deg": 163}
+
+	tests := []struct {
+		name       string
+		args       map[string]any
+		facilities bool
+		wantErr    string
+	}{
+		{"no position", map[string]any{"altitude_ft": 3000, "heading_deg": 163}, false, "latitude and longitude, or final_approach, are required"},
+		{"no altitude", map[string]any{"latitude": 47.6, "longitude": -122.4, "heading_deg": 163}, false, "altitude_ft is required"},
+		{"no heading", map[string]any{"latitude": 47.6, "longitude": -122.4, "altitude_ft": 3000}, false, "heading_deg is required"},
+		{"latitude", with(flying, "latitude", 91), false, "latitude must be -90 to 90"},
+		{"longitude", with(flying, "longitude", -181), false, "longitude must be -180 to 180"},
+		{"altitude", with(flying, "altitude_ft", 70000), false, "altitude_ft must be -1500 to 60000"},
+		{"heading", with(flying, "heading_deg", 361), false, "heading_deg must be 0 to 360"},
+		{"airspeed", with(flying, "airspeed_kts", -5), false, "airspeed_kts must be 0 to 1000"},
+		{"pitch", with(flying, "pitch_deg", 95), false, "pitch_deg must be -90 to 90"},
+		{"position and final approach", with(flying, "final_approach", threshold), false, "not both"},
+		{"final distance", map[string]any{"final_approach": with(threshold, "distance_nm", 60)}, false, "distance_nm must be 0 to 50"},
+		{"glide path", map[string]any{"final_approach": with(threshold, "glide_path_deg", 12)}, false, "glide_path_deg must be 1 to 10"},
+		{"threshold latitude", map[string]any{"final_approach": with(threshold, "threshold_latitude", 100)}, false, "threshold_latitude must be -90 to 90"},
+		{"runway heading", map[string]any{"final_approach": with(threshold, "runway_heading_deg", -10)}, false, "runway_heading_deg must be 0 to 360"},
+		{"incomplete threshold", map[string]any{"final_approach": map[string]any{"threshold_latitude": 47.4, "distance_nm": 10}}, false, "final_approach needs airport and runway"},
+		{"airport and threshold", map[string]any{"final_approach": with(threshold, "airport", "KSEA")}, true, "not both"},
+		{"no facility database", map[string]any{"final_approach": map[string]any{"airport": "KSEA", "runway": "16R", "distance_nm": 10}}, false, "facility database is not available"},
+		{"no runway", map[string]any{"final_approach": map[string]any{"airport": "KSEA", "distance_nm": 10}}, true, "runway is required"},
+		{"unknown runway", map[string]any{"final_approach": map[string]any{"airport": "KSEA", "runway": "16L", "distance_nm": 10}}, true, "KSEA has no runway 16L; runways: 16R, 34L"},
+		{"unknown airport", map[string]any{"final_approach": map[string]any{"airport": "KBFI", "runway": "14R", "distance_nm": 10}}, true, "KBFI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakePositioner{}
+			var opts []internalmcp.Option
+			if tt.facilities {
+				opts = append(opts, internalmcp.WithFacilityReader(&fakeFacilities{airports: map[string]types.Airport{"KSEA": approachAirport}}))
+			}
+			res := callPositionTool(t, f, tt.args, opts...)
+			require.True(t, res.IsError)
+			m := parseJSON(t, res)
+			assert.Equal(t, "INVALID_INPUT", m["code"])
+			assert.Contains(t, m["error"], tt.wantErr)
+			assert.Empty(t, f.positions(), "rejected input must not move the aircraft")
+		})
+	}
+}
+
+func TestSetAircraftPositionErrors(t *testing.T) {
+	args := map[string]any{"latitude": 47.6, "longitude": -122.4, "altitude_ft": 3000, "heading_deg": 163}
+
+	t.Run("not confirmed", func(t *testing.T) {
+		f := &fakePositioner{ignore: true}
+		res := callPositionTool(t, f, args)
+		require.True(t, res.IsError)
+		m := parseJSON(t, res)
+		assert.Equal(t, "COMMAND_NOT_CONFIRMED", m["code"])
+		assert.Equal(t, 47.6, m["requested"].(map[string]any)["latitude_deg"])
+		assert.Len(t, f.positions(), 1)
+	})
+
+	ground := map[string]any{"latitude": 47.6, "longitude": -122.4, "heading_deg": 163, "on_ground": true}
+	for _, tt := range []struct {
+		name  string
+		args  map[string]any
+		drift func(*types.AircraftPosition)
+	}{
+		{"wrong altitude", args, func(p *types.AircraftPosition) { p.AltitudeMSL -= 500 }},
+		{"wrong heading", args, func(p *types.AircraftPosition) { p.HeadingTrue += 30 }},
+		{"not on the ground", ground, func(p *types.AircraftPosition) { p.AltitudeAGL = 1000 }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakePositioner{drift: tt.drift}
+			res := callPositionTool(t, f, tt.args)
+			require.True(t, res.IsError)
+			assert.Equal(t, "COMMAND_NOT_CONFIRMED", parseJSON(t, res)["code"])
+		})
+	}
+
+	t.Run("within tolerances", func(t *testing.T) {
+		f := &fakePositioner{drift: func(p *types.AircraftPosition) {
+			p.AltitudeMSL += 50
+			p.HeadingTrue += 2
+		}}
+		res := callPositionTool(t, f, args)
+		require.False(t, res.IsError, "%v", res.Content)
+		assert.Equal(t, true, parseJSON(t, res)["verified"])
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		f := &fakePositioner{setErr: &simconnect.Exception{Code: simconnect.ExceptionDataError}}
+		res := callPositionTool(t, f, args)
+		require.True(t, res.IsError)
+		assert.Equal(t, "SIMCONNECT_EXCEPTION", parseJSON(t, res)["code"])
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		f := &fakePositioner{mockStateGetter: mockStateGetter{err: state.ErrPaused}}
+		res := callPositionTool(t, f, args)
+		require.True(t, res.IsError)
+		assert.Equal(t, "SIMULATOR_PAUSED", parseJSON(t, res)["code"])
+		assert.Empty(t, f.positions())
+	})
+}
+
+func TestSetAircraftPositionRequiresPositioner(t *testing.T) {
+	ctx := context.Background()
+	srv := internalmcp.NewServer(&mockStateGetter{})
+	st, ct := mcpsdk.NewInMemoryTransports()
+	_, err := srv.Connect(ctx, st)
+	require.NoError(t, err)
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test", Version: "1.0"}, nil)
+	cs, err := client.Connect(ctx, ct, nil)
+	require.NoError(t, err)
+	defer cs.Close()
+
+	tools, err := cs.ListTools(ctx, nil)
+	require.NoError(t, err)
+	for _, tool := range tools.Tools {
+		assert.NotEqual(t, "set_aircraft_position", tool.Name)
+	}
+}
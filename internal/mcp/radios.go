@@ -0,0 +1,303 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// frequencyToleranceMHz is how far a read-back frequency may be from the
+// requested one, absorbing floating-point noise in the MHz SimVars.
+const frequencyToleranceMHz = 0.001
+
+// radio describes a tunable COM or NAV radio: its band in kHz, its channel
+// spacing, the events that tune it and where its frequencies are read back.
+type radio struct {
+	name                  string
+	minKHz, maxKHz        int
+	spacingKHz            int
+	setActive, setStandby string
+	swap                  string
+	freqs                 func(nav *types.Navigation) (active, standby float64)
+}
+
+// COM radios accept 25 kHz channels and 8.33 kHz channel names, which all
+// fall on 5 kHz steps. NAV radios use 50 kHz channels.
+var radios = map[string]radio{
+	"com1": {"COM1", 118000, 136990, 5,
+		simconnect.EventCom1ActiveSetHz, simconnect.EventCom1StandbySetHz, simconnect.EventCom1Swap,
+		func(nav *types.Navigation) (float64, float64) {
+			return nav.Com1ActiveFrequency, nav.Com1StandbyFrequency
+		}},
+	"com2": {"COM2", 118000, 136990, 5,
+		simconnect.EventCom2ActiveSetHz, simconnect.EventCom2StandbySetHz, simconnect.EventCom2Swap,
+		func(nav *types.Navigation) (float64, float64) {
+			return nav.Com2ActiveFrequency, nav.Com2StandbyFrequency
+		}},
+	"nav1": {"NAV1", 108000, 117950, 50,
+		simconnect.EventNav1ActiveSetHz, simconnect.EventNav1StandbySetHz, simconnect.EventNav1Swap,
+		func(nav *types.Navigation) (float64, float64) {
+			return nav.Nav1.ActiveFrequency, nav.Nav1.StandbyFrequency
+		}},
+	"nav2": {"NAV2", 108000, 117950, 50,
+		simconnect.EventNav2ActiveSetHz, simconnect.EventNav2StandbySetHz, simconnect.EventNav2Swap,
+		func(nav *types.Navigation) (float64, float64) {
+			return nav.Nav2.ActiveFrequency, nav.Nav2.StandbyFrequency
+		}},
+}
+
+// --- Input structs ---
+
+type tuneRadioInput struct {
+	Radio     string `json:"radio" jsonschema:"COM1, COM2, NAV1 or NAV2, optionally followed by active or standby (default standby), e.g. COM1 standby"`
+	Frequency string `json:"frequency,omitempty" jsonschema:"frequency in MHz, e.g. 121.5; COM 118.000-136.990, NAV 108.00-117.95; omit to only swap"`
+	Swap      bool   `json:"swap,omitempty" jsonschema:"swap the active and standby frequencies after tuning"`
+}
+
+type setTransponderInput struct {
+	Code string `json:"code" jsonschema:"four-digit squawk code, each digit 0-7, e.g. 7000"`
+}
+
+// --- Response structs ---
+
+// RadioFrequencies is a radio's active and standby frequency pair.
+type RadioFrequencies struct {
+	Active  float64 `json:"active_frequency_mhz"`
+	Standby float64 `json:"standby_frequency_mhz"`
+}
+
+// RadioCommandResponse is the JSON payload returned by tune_radio once the
+// simulator reflects the requested frequencies.
+type RadioCommandResponse struct {
+	Command   string           `json:"command"`
+	Radio     string           `json:"radio"`
+	Events    []string         `json:"events"`
+	Requested RadioFrequencies `json:"requested"`
+	Actual    RadioFrequencies `json:"actual"`
+	Verified  bool             `json:"verified"`
+	Timestamp string           `json:"timestamp"`
+}
+
+// TransponderCommandResponse is the JSON payload returned by set_transponder
+// once the simulator reflects the requested code.
+type TransponderCommandResponse struct {
+	Command   string `json:"command"`
+	Event     string `json:"event"`
+	Requested string `json:"requested"`
+	Actual    string `json:"actual"`
+	Verified  bool   `json:"verified"`
+	Timestamp string `json:"timestamp"`
+}
+
+func (s *Server) registerRadioTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "tune_radio",
+		Description: "Tunes a COM or NAV radio (COM1, COM2, NAV1, NAV2) to a frequency in MHz, e.g. radio \"COM1 standby\" and " +
+			"frequency \"121.5\", optionally swapping active and standby, and confirms the result by reading the radios back.",
+	}, s.handleTuneRadio)
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name:        "set_transponder",
+		Description: "Sets the transponder squawk code, e.g. \"7000\", and confirms the simulator reflects the new code.",
+	}, s.handleSetTransponder)
+}
+
+// radioEvent is a sim event and its data value.
+type radioEvent struct {
+	name string
+	data uint32
+}
+
+func (s *Server) handleTuneRadio(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input tuneRadioInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	r, standby, err := parseRadioTarget(input.Radio)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	if input.Frequency == "" && !input.Swap {
+		return s.errorResult(fmt.Errorf("%w: give a frequency, swap, or both", ErrInvalidInput)), nil, nil
+	}
+
+	nav, err := s.state.GetNavigation()
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	actual := func(nav *types.Navigation) RadioFrequencies {
+		active, standby := r.freqs(nav)
+		return RadioFrequencies{Active: roundFrequency(active), Standby: roundFrequency(standby)}
+	}
+	want := actual(&nav)
+
+	var events []radioEvent
+	if input.Frequency != "" {
+		khz, err := parseFrequency(r, input.Frequency)
+		if err != nil {
+			return s.errorResult(err), nil, nil
+		}
+		mhz := float64(khz) / 1000
+		event := radioEvent{r.setActive, uint32(khz) * 1000} // #nosec G115 -- band limits keep Hz well within uint32
+		if standby {
+			event.name = r.setStandby
+			want.Standby = mhz
+		} else {
+			want.Active = mhz
+		}
+		events = append(events, event)
+	}
+	if input.Swap {
+		want.Active, want.Standby = want.Standby, want.Active
+		events = append(events, radioEvent{name: r.swap})
+	}
+
+	names := make([]string, len(events))
+	for i, e := range events {
+		if err := s.events.TransmitEvent(e.name, e.data); err != nil {
+			return s.errorResult(err), nil, nil
+		}
+		names[i] = e.name
+	}
+
+	got, ok := awaitState(ctx, s.commandTimeout, s.state.GetNavigation, func(nav *types.Navigation) bool {
+		a := actual(nav)
+		return math.Abs(a.Active-want.Active) <= frequencyToleranceMHz &&
+			math.Abs(a.Standby-want.Standby) <= frequencyToleranceMHz
+	})
+	if !ok {
+		return notConfirmedResult(&CommandErrorResponse{
+			Suggestion: fmt.Sprintf("The simulator did not reflect the change within %s. Check that %s is powered and supports the frequency, then retry.", s.commandTimeout, r.name),
+			Command:    "tune_radio",
+			Events:     names,
+			Requested:  want,
+			Actual:     actual(&got),
+		}), nil, nil
+	}
+
+	return s.jsonResult(RadioCommandResponse{
+		Command:   "tune_radio",
+		Radio:     r.name,
+		Events:    names,
+		Requested: want,
+		Actual:    actual(&got),
+		Verified:  true,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+func (s *Server) handleSetTransponder(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input setTransponderInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	code, err := parseSquawk(input.Code)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	if _, err := s.state.GetNavigation(); err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	event := simconnect.EventTransponderSet
+	if err := s.events.TransmitEvent(event, uint32(simconnect.EncodeBCD16(code))); err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	requested := fmt.Sprintf("%04d", code)
+	nav, ok := awaitState(ctx, s.commandTimeout, s.state.GetNavigation, func(nav *types.Navigation) bool {
+		return nav.TransponderCode == code
+	})
+	actual := fmt.Sprintf("%04d", nav.TransponderCode)
+	if !ok {
+		return notConfirmedResult(&CommandErrorResponse{
+			Suggestion: fmt.Sprintf("The simulator did not reflect the change within %s. Check that the transponder is powered, then retry.", s.commandTimeout),
+			Command:    "set_transponder",
+			Event:      event,
+			Requested:  requested,
+			Actual:     actual,
+		}), nil, nil
+	}
+
+	return s.jsonResult(TransponderCommandResponse{
+		Command:   "set_transponder",
+		Event:     event,
+		Requested: requested,
+		Actual:    actual,
+		Verified:  true,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// parseRadioTarget parses "COM1", "nav2 active" or "COM1 standby" into a
+// radio and whether its standby frequency is targeted.
+func parseRadioTarget(s string) (radio, bool, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 || len(fields) > 2 {
+		return radio{}, false, fmt.Errorf("%w: radio must be one of %s, optionally followed by active or standby",
+			ErrInvalidInput, strings.Join(radioNames(), ", "))
+	}
+	r, ok := radios[fields[0]]
+	if !ok {
+		return radio{}, false, fmt.Errorf("%w: unknown radio %q; use one of: %s",
+			ErrInvalidInput, fields[0], strings.Join(radioNames(), ", "))
+	}
+	if len(fields) == 1 {
+		return r, true, nil
+	}
+	switch fields[1] {
+	case "standby", "stby":
+		return r, true, nil
+	case "active":
+		return r, false, nil
+	}
+	return radio{}, false, fmt.Errorf("%w: radio slot must be active or standby, got %q", ErrInvalidInput, fields[1])
+}
+
+// parseFrequency parses a frequency in MHz, such as "121.5" or "118.025 MHz",
+// and checks it against the radio's band and channel spacing. It returns kHz.
+func parseFrequency(r radio, s string) (int, error) {
+	text := strings.TrimSpace(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "mhz"))
+	mhz, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(mhz) || math.IsInf(mhz, 0) {
+		return 0, fmt.Errorf("%w: frequency %q is not a number in MHz", ErrInvalidInput, s)
+	}
+	khz := int(math.Round(mhz * 1000))
+	if khz < r.minKHz || khz > r.maxKHz {
+		return 0, fmt.Errorf("%w: %s frequency must be between %.3f and %.3f MHz",
+			ErrInvalidInput, r.name, float64(r.minKHz)/1000, float64(r.maxKHz)/1000)
+	}
+	if khz%r.spacingKHz != 0 || math.Abs(mhz*1000-float64(khz)) > 0.01 {
+		return 0, fmt.Errorf("%w: %s frequency %q is not on a %d kHz channel", ErrInvalidInput, r.name, s, r.spacingKHz)
+	}
+	return khz, nil
+}
+
+// parseSquawk parses a four-digit octal squawk code such as "7000" and
+// returns it as the decimal number its digits spell.
+func parseSquawk(s string) (int, error) {
+	code := strings.TrimSpace(s)
+	if len(code) != 4 || strings.Trim(code, "01234567") != "" {
+		return 0, fmt.Errorf("%w: code must be four digits, each 0-7, got %q", ErrInvalidInput, s)
+	}
+	n, _ := strconv.Atoi(code)
+	return n, nil
+}
+
+func radioNames() []string {
+	names := make([]string, 0, len(radios))
+	for _, r := range radios {
+		names = append(names, r.name)
+	}
+	sort.Strings(names)
+	return names
+}
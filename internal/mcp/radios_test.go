@@ -0,0 +1,223 @@
+package mcp_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// fakeRadios is a StateGetter and EventTransmitter whose radios react to
+// transmitted events the way the simulator would.
+type fakeRadios struct {
+	mockStateGetter
+
+	mu     sync.Mutex
+	ignore bool // when true, events are recorded but have no effect
+	events []string
+	data   []uint32
+}
+
+func (f *fakeRadios) GetNavigation() (types.Navigation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.nav, f.err
+}
+
+func (f *fakeRadios) TransmitEvent(name string, data uint32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, name)
+	f.data = append(f.data, data)
+	if f.ignore {
+		return nil
+	}
+
+	mhz := float64(data) / 1e6
+	nav := &f.nav
+	switch name {
+	case simconnect.EventCom1ActiveSetHz:
+		nav.Com1ActiveFrequency = mhz
+	case simconnect.EventCom1StandbySetHz:
+		nav.Com1StandbyFrequency = mhz
+	case simconnect.EventCom1Swap:
+		nav.Com1ActiveFrequency, nav.Com1StandbyFrequency = nav.Com1StandbyFrequency, nav.Com1ActiveFrequency
+	case simconnect.EventNav2StandbySetHz:
+		nav.Nav2.StandbyFrequency = mhz
+	case simconnect.EventNav2Swap:
+		nav.Nav2.ActiveFrequency, nav.Nav2.StandbyFrequency = nav.Nav2.StandbyFrequency, nav.Nav2.ActiveFrequency
+	case simconnect.EventTransponderSet:
+		nav.TransponderCode = simconnect.DecodeBCD16(uint16(data)) // #nosec G115 -- test data is BCD16
+	}
+	return nil
+}
+
+func (f *fakeRadios) sent() ([]string, []uint32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.events...), append([]uint32(nil), f.data...)
+}
+
+func callRadioTool(t *testing.T, f *fakeRadios, toolName string, args map[string]any) *mcpsdk.CallToolResult {
+	t.Helper()
+	return callTool(t, f, toolName, args,
+		internalmcp.WithEventTransmitter(f),
+		internalmcp.WithCommandTimeout(200*time.Millisecond))
+}
+
+var sampleRadios = types.Navigation{
+	Com1ActiveFrequency:  124.85,
+	Com1StandbyFrequency: 118.3,
+	Nav2:                 types.NavRadio{ActiveFrequency: 114.8, StandbyFrequency: 108},
+	TransponderCode:      1200,
+}
+
+func TestTuneRadio(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		wantEvents  []string
+		wantData    []uint32
+		wantActive  float64
+		wantStandby float64
+	}{
+		{
+			name:        "standby by default",
+			args:        map[string]any{"radio": "COM1", "frequency": "121.5"},
+			wantEvents:  []string{simconnect.EventCom1StandbySetHz},
+			wantData:    []uint32{121500000},
+			wantActive:  124.85,
+			wantStandby: 121.5,
+		},
+		{
+			name:        "active slot",
+			args:        map[string]any{"radio": "com1 active", "frequency": "118.025 MHz"},
+			wantEvents:  []string{simconnect.EventCom1ActiveSetHz},
+			wantData:    []uint32{118025000},
+			wantActive:  118.025,
+			wantStandby: 118.3,
+		},
+		{
+			name:        "tune and swap",
+			args:        map[string]any{"radio": "NAV2 standby", "frequency": "110.5", "swap": true},
+			wantEvents:  []string{simconnect.EventNav2StandbySetHz, simconnect.EventNav2Swap},
+			wantData:    []uint32{110500000, 0},
+			wantActive:  110.5,
+			wantStandby: 114.8,
+		},
+		{
+			name:        "swap only",
+			args:        map[string]any{"radio": "COM1", "swap": true},
+			wantEvents:  []string{simconnect.EventCom1Swap},
+			wantData:    []uint32{0},
+			wantActive:  118.3,
+			wantStandby: 124.85,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeRadios{mockStateGetter: mockStateGetter{nav: sampleRadios}}
+			res := callRadioTool(t, f, "tune_radio", tt.args)
+			require.False(t, res.IsError)
+
+			m := parseJSON(t, res)
+			assert.Equal(t, true, m["verified"])
+			actual := m["actual"].(map[string]any)
+			assert.Equal(t, tt.wantActive, actual["active_frequency_mhz"])
+			assert.Equal(t, tt.wantStandby, actual["standby_frequency_mhz"])
+
+			events, data := f.sent()
+			assert.Equal(t, tt.wantEvents, events)
+			assert.Equal(t, tt.wantData, data)
+		})
+	}
+}
+
+func TestTuneRadioInvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{"unknown radio", map[string]any{"radio": "COM3", "frequency": "121.5"}},
+		{"unknown slot", map[string]any{"radio": "COM1 backup", "frequency": "121.5"}},
+		{"nothing to do", map[string]any{"radio": "COM1"}},
+		{"not a number", map[string]any{"radio": "COM1", "frequency": "tower"}},
+		{"COM below band", map[string]any{"radio": "COM1", "frequency": "117.975"}},
+		{"COM above band", map[string]any{"radio": "COM2", "frequency": "137.0"}},
+		{"COM off channel", map[string]any{"radio": "COM1", "frequency": "121.501"}},
+		{"NAV in COM band", map[string]any{"radio": "NAV1", "frequency": "121.5"}},
+		{"NAV off channel", map[string]any{"radio": "NAV1", "frequency": "110.525"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeRadios{mockStateGetter: mockStateGetter{nav: sampleRadios}}
+			res := callRadioTool(t, f, "tune_radio", tt.args)
+
+			require.True(t, res.IsError)
+			assert.Equal(t, "INVALID_INPUT", parseJSON(t, res)["code"])
+			events, _ := f.sent()
+			assert.Empty(t, events)
+		})
+	}
+}
+
+func TestTuneRadioNotConfirmed(t *testing.T) {
+	f := &fakeRadios{mockStateGetter: mockStateGetter{nav: sampleRadios}, ignore: true}
+	res := callRadioTool(t, f, "tune_radio", map[string]any{"radio": "COM1", "frequency": "121.5"})
+
+	require.True(t, res.IsError)
+	m := parseJSON(t, res)
+	assert.Equal(t, "COMMAND_NOT_CONFIRMED", m["code"])
+	assert.Equal(t, "tune_radio", m["command"])
+	assert.Equal(t, []any{simconnect.EventCom1StandbySetHz}, m["events"])
+	assert.Equal(t, 121.5, m["requested"].(map[string]any)["standby_frequency_mhz"])
+	assert.Equal(t, 118.3, m["actual"].(map[string]any)["standby_frequency_mhz"])
+}
+
+func TestSetTransponder(t *testing.T) {
+	f := &fakeRadios{mockStateGetter: mockStateGetter{nav: sampleRadios}}
+	res := callRadioTool(t, f, "set_transponder", map[string]any{"code": "7000"})
+	require.False(t, res.IsError)
+
+	m := parseJSON(t, res)
+	assert.Equal(t, "7000", m["requested"])
+	assert.Equal(t, "7000", m["actual"])
+	assert.Equal(t, true, m["verified"])
+
+	events, data := f.sent()
+	assert.Equal(t, []string{simconnect.EventTransponderSet}, events)
+	assert.Equal(t, []uint32{0x7000}, data)
+}
+
+func TestSetTransponderInvalidCode(t *testing.T) {
+	for _, code := range []string{"", "700", "70000", "7800", "12a4", "-123"} {
+		t.Run(code, func(t *testing.T) {
+			f := &fakeRadios{mockStateGetter: mockStateGetter{nav: sampleRadios}}
+			res := callRadioTool(t, f, "set_transponder", map[string]any{"code": code})
+
+			require.True(t, res.IsError)
+			assert.Equal(t, "INVALID_INPUT", parseJSON(t, res)["code"])
+			events, _ := f.sent()
+			assert.Empty(t, events)
+		})
+	}
+}
+
+func TestSetTransponderNotConfirmed(t *testing.T) {
+	f := &fakeRadios{mockStateGetter: mockStateGetter{nav: sampleRadios}, ignore: true}
+	res := callRadioTool(t, f, "set_transponder", map[string]any{"code": "7700"})
+
+	require.True(t, res.IsError)
+	m := parseJSON(t, res)
+	assert.Equal(t, "COMMAND_NOT_CONFIRMED", m["code"])
+	assert.Equal(t, simconnect.EventTransponderSet, m["event"])
+	assert.Equal(t, "7700", m["requested"])
+	assert.Equal(t, "1200", m["actual"])
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/eytandecker/flightsim-mcp/internal/pln"
 	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
 	"github.com/eytandecker/flightsim-mcp/internal/state"
 	"github.com/eytandecker/flightsim-mcp/pkg/types"
@@ -21,24 +24,149 @@ type StateGetter interface {
 	GetEngine() (types.EngineData, error)
 	GetEnvironment() (types.Environment, error)
 	GetAutopilot() (types.AutopilotState, error)
+	GetAircraftInfo() (types.AircraftInfo, error)
+	GetFlightControls() (types.FlightControls, error)
+	GetSystems() (types.SystemsStatus, error)
+	GetNavigation() (types.Navigation, error)
+	GetLifecycle() types.SimLifecycle
 }
 
 // Server wraps the MCP SDK server and exposes SimConnect data as tools.
 type Server struct {
-	sdk   *mcpsdk.Server
-	state StateGetter
+	sdk            *mcpsdk.Server
+	state          StateGetter
+	events         EventTransmitter
+	diagnostics    DiagnosticsProvider
+	status         StatusProvider
+	simvars        SimVarReader
+	traffic        TrafficReader
+	facilities     FacilityReader
+	weather        WeatherReader
+	flightPlans    FlightPlanReader
+	planLoader     FlightPlanLoader
+	planDir        string
+	planSimDir     string
+	flights        FlightStore
+	flightDirs     []string
+	positioner     AircraftPositioner
+	commandTimeout time.Duration
+	conn           connectionState
+
+	planMu     sync.Mutex
+	loadedPlan *pln.FlightPlan // last plan load_flight_plan loaded
 }
 
+// Option configures optional Server capabilities.
+type Option func(*Server)
+
+// WithEventTransmitter enables the control tools, which fire sim events
+// through et and verify the result against the cached state.
+func WithEventTransmitter(et EventTransmitter) Option {
+	return func(s *Server) { s.events = et }
+}
+
+// WithDiagnostics enables get_simconnect_diagnostics, which reports rejected
+// SimVars and recent SimConnect exceptions from dp.
+func WithDiagnostics(dp DiagnosticsProvider) Option {
+	return func(s *Server) { s.diagnostics = dp }
+}
+
+// WithSimulatorStatus enables get_simulator_status, which reports the
+// connection state and simulator version from sp.
+func WithSimulatorStatus(sp StatusProvider) Option {
+	return func(s *Server) { s.status = sp }
+}
+
+// WithSimVarReader enables read_simvars, which reads allowlisted SimVars on
+// demand through r.
+func WithSimVarReader(r SimVarReader) Option {
+	return func(s *Server) { s.simvars = r }
+}
+
+// WithTrafficReader enables get_nearby_traffic, which lists AI and
+// multiplayer objects around the user aircraft read through tr.
+func WithTrafficReader(tr TrafficReader) Option {
+	return func(s *Server) { s.traffic = tr }
+}
+
+// WithFacilityReader enables get_airport_info and find_nearest_airports,
+// which read the simulator's facility database through fr.
+func WithFacilityReader(fr FacilityReader) Option {
+	return func(s *Server) { s.facilities = fr }
+}
+
+// WithWeatherReader enables get_weather_report, which requests METAR
+// observations through wr.
+func WithWeatherReader(wr WeatherReader) Option {
+	return func(s *Server) { s.weather = wr }
+}
+
+// WithFlightPlanReader enables get_flight_plan, which reads the GPS flight
+// plan through fr.
+func WithFlightPlanReader(fr FlightPlanReader) Option {
+	return func(s *Server) { s.flightPlans = fr }
+}
+
+// WithFlightPlanLoader enables load_flight_plan, which writes plans to dir
+// and loads them through fl. simDir is dir as the simulator's machine sees
+// it, e.g. a network share; it defaults to dir.
+func WithFlightPlanLoader(fl FlightPlanLoader, dir, simDir string) Option {
+	return func(s *Server) {
+		if simDir == "" {
+			simDir = dir
+		}
+		s.planLoader, s.planDir, s.planSimDir = fl, dir, simDir
+	}
+}
+
+// WithFlightStore enables save_flight_situation and load_flight_situation,
+// which save and load .FLT files through fs. Flights are kept only within
+// dirs, directories on the simulator's machine; the first is the default.
+func WithFlightStore(fs FlightStore, dirs []string) Option {
+	return func(s *Server) { s.flights, s.flightDirs = fs, dirs }
+}
+
+// WithAircraftPositioner enables set_aircraft_position, which moves the user
+// aircraft through ap. Repositioning is destructive, so callers enable it
+// only when configured to.
+func WithAircraftPositioner(ap AircraftPositioner) Option {
+	return func(s *Server) { s.positioner = ap }
+}
+
+// WithCommandTimeout sets how long control tools wait for the simulator to
+// reflect a command before reporting it as unconfirmed.
+func WithCommandTimeout(d time.Duration) Option {
+	return func(s *Server) { s.commandTimeout = d }
+}
+
+// defaultCommandTimeout is used when WithCommandTimeout is not given.
+const defaultCommandTimeout = 3 * time.Second
+
 // NewServer creates a Server and registers all MCP tools.
-func NewServer(sg StateGetter) *Server {
+func NewServer(sg StateGetter, opts ...Option) *Server {
 	s := &Server{
 		sdk: mcpsdk.NewServer(&mcpsdk.Implementation{
 			Name:    "flightsim-mcp",
 			Version: "1.0.0",
 		}, nil),
-		state: sg,
+		state:          sg,
+		commandTimeout: defaultCommandTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
 
+	if s.status != nil {
+		s.registerStatusTools()
+	}
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_aircraft_info",
+		Description: "Returns which aircraft is loaded: title, tail number, airline and flight number, ATC model, category, " +
+			"engine type and count, max gross weight and design speeds (Vs0, Vs1, Vc, Vmo). " +
+			"Use the engine type to decide whether N1 or RPM is meaningful.",
+	}, s.handleGetAircraftInfo)
+
 	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
 		Name:        "get_aircraft_position",
 		Description: "Returns live aircraft position, speed, and attitude data from Microsoft Flight Simulator 2024.",
@@ -50,8 +178,9 @@ func NewServer(sg StateGetter) *Server {
 	}, s.handleGetFlightInstruments)
 
 	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
-		Name:        "get_engine_data",
-		Description: "Returns engine performance data for up to 2 engines including RPM, N1/N2, temperatures, pressures, and fuel quantities.",
+		Name: "get_engine_data",
+		Description: "Returns an engines array with throttle, RPM, N1/N2, fuel flow, EGT, ITT, torque and oil temperature and pressure " +
+			"for each of up to 4 engines, plus fuel quantities. Pass engine to get a single engine.",
 	}, s.handleGetEngineData)
 
 	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
@@ -59,11 +188,61 @@ func NewServer(sg StateGetter) *Server {
 		Description: "Returns weather and environment data including wind, temperature, pressure, visibility, and time.",
 	}, s.handleGetEnvironment)
 
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_flight_controls",
+		Description: "Returns elevator, aileron and rudder positions and trim, flaps handle index and percent, " +
+			"spoilers handle and armed state, gear handle and per-leg gear extension, and the parking brake.",
+	}, s.handleGetFlightControls)
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_systems_status",
+		Description: "Returns aircraft systems status: fuel quantities and weight, electrical (battery master, bus and battery voltage, " +
+			"battery load, generator buses), hydraulic pressures, pressurization (cabin altitude and rate, differential pressure) " +
+			"and flight controls. Pass systems to select sections: fuel, electrical, hydraulics, pressurization, controls.",
+	}, s.handleGetSystemsStatus)
+
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_navigation",
+		Description: "Returns the radio stack and GPS guidance: NAV1/NAV2 frequency, OBS, radial, CDI/GSI deflection, to/from, " +
+			"DME and localizer/glideslope reception; ADF frequency and bearing; COM1/COM2 active and standby; transponder code; " +
+			"GPS course to steer, desired track, next waypoint ID, distance and ETE.",
+	}, s.handleGetNavigation)
+
 	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
 		Name:        "get_autopilot_state",
 		Description: "Returns autopilot mode flags and target values including heading, altitude, vertical speed, and airspeed settings.",
 	}, s.handleGetAutopilotState)
 
+	if s.events != nil {
+		s.registerAutopilotControlTools()
+		s.registerRadioTools()
+	}
+	if s.simvars != nil {
+		s.registerSimVarTools()
+	}
+	if s.traffic != nil {
+		s.registerTrafficTools()
+	}
+	if s.facilities != nil {
+		s.registerAirportTools()
+	}
+	if s.weather != nil {
+		s.registerWeatherTools()
+	}
+	if s.flightPlans != nil {
+		s.registerFlightPlanTools()
+	}
+	s.registerPlanFileTools()
+	if s.flights != nil && len(s.flightDirs) > 0 {
+		s.registerFlightTools()
+	}
+	if s.positioner != nil {
+		s.registerPositionTools()
+	}
+	if s.diagnostics != nil {
+		s.registerDiagnosticsTools()
+	}
+
 	return s
 }
 
@@ -123,6 +302,10 @@ type getPositionInput struct {
 
 type emptyInput struct{}
 
+type getEngineDataInput struct {
+	Engine int `json:"engine,omitempty" jsonschema:"engine number, 1-4; omit for all engines"`
+}
+
 // --- Response structs ---
 
 // AircraftPositionResponse is the JSON payload returned by get_aircraft_position.
@@ -158,42 +341,44 @@ type FlightInstrumentsResponse struct {
 	Timestamp           string  `json:"timestamp"`
 }
 
+// EngineResponse holds one engine's readings in get_engine_data.
+type EngineResponse struct {
+	EngineNumber     int     `json:"engine_number"`
+	ThrottlePosition float64 `json:"throttle_position_pct"`
+	RPM              float64 `json:"rpm"`
+	N1               float64 `json:"n1_pct"`
+	N2               float64 `json:"n2_pct"`
+	FuelFlow         float64 `json:"fuel_flow_gph"`
+	EGT              float64 `json:"egt_celsius"`
+	ITT              float64 `json:"itt_celsius"`
+	Torque           float64 `json:"torque_ft_lbs"`
+	OilTemp          float64 `json:"oil_temp_celsius"`
+	OilPressure      float64 `json:"oil_pressure_psi"`
+}
+
 // EngineDataResponse is the JSON payload returned by get_engine_data.
 type EngineDataResponse struct {
-	NumberOfEngines   int     `json:"number_of_engines"`
-	ThrottlePosition1 float64 `json:"throttle_position_1_pct"`
-	ThrottlePosition2 float64 `json:"throttle_position_2_pct"`
-	RPM1              float64 `json:"rpm_1"`
-	RPM2              float64 `json:"rpm_2"`
-	N1Engine1         float64 `json:"n1_engine_1_pct"`
-	N1Engine2         float64 `json:"n1_engine_2_pct"`
-	N2Engine1         float64 `json:"n2_engine_1_pct"`
-	N2Engine2         float64 `json:"n2_engine_2_pct"`
-	FuelFlow1         float64 `json:"fuel_flow_1_gph"`
-	FuelFlow2         float64 `json:"fuel_flow_2_gph"`
-	EGT1              float64 `json:"egt_1_celsius"`
-	EGT2              float64 `json:"egt_2_celsius"`
-	OilTemp1          float64 `json:"oil_temp_1_celsius"`
-	OilTemp2          float64 `json:"oil_temp_2_celsius"`
-	OilPressure1      float64 `json:"oil_pressure_1_psi"`
-	OilPressure2      float64 `json:"oil_pressure_2_psi"`
-	FuelTotalQuantity float64 `json:"fuel_total_gal"`
-	FuelLeftQuantity  float64 `json:"fuel_left_gal"`
-	FuelRightQuantity float64 `json:"fuel_right_gal"`
-	Timestamp         string  `json:"timestamp"`
+	NumberOfEngines   int              `json:"number_of_engines"`
+	Engines           []EngineResponse `json:"engines"`
+	FuelTotalQuantity float64          `json:"fuel_total_gal"`
+	FuelLeftQuantity  float64          `json:"fuel_left_gal"`
+	FuelRightQuantity float64          `json:"fuel_right_gal"`
+	Timestamp         string           `json:"timestamp"`
 }
 
 // EnvironmentResponse is the JSON payload returned by get_environment.
 type EnvironmentResponse struct {
-	WindVelocity  float64 `json:"wind_velocity_kts"`
-	WindDirection float64 `json:"wind_direction_deg"`
-	Temperature   float64 `json:"temperature_celsius"`
-	Pressure      float64 `json:"pressure_inhg"`
-	Visibility    float64 `json:"visibility_m"`
-	PrecipState   int     `json:"precip_state"`
-	LocalTime     float64 `json:"local_time_sec"`
-	ZuluTime      float64 `json:"zulu_time_sec"`
-	Timestamp     string  `json:"timestamp"`
+	WindVelocity     float64 `json:"wind_velocity_kts"`
+	WindDirection    float64 `json:"wind_direction_deg"`
+	Temperature      float64 `json:"temperature_celsius"`
+	Pressure         float64 `json:"pressure_inhg"`
+	Visibility       float64 `json:"visibility_m"`
+	PrecipState      int     `json:"precip_state"`
+	LocalTime        float64 `json:"local_time_sec"`
+	ZuluTime         float64 `json:"zulu_time_sec"`
+	SeaLevelPressure float64 `json:"sea_level_pressure_mb"`
+	ZuluDayOfMonth   int     `json:"zulu_day_of_month"`
+	Timestamp        string  `json:"timestamp"`
 }
 
 // AutopilotStateResponse is the JSON payload returned by get_autopilot_state.
@@ -288,36 +473,47 @@ func (s *Server) handleGetFlightInstruments(
 func (s *Server) handleGetEngineData(
 	_ context.Context,
 	_ *mcpsdk.CallToolRequest,
-	_ emptyInput,
+	input getEngineDataInput,
 ) (*mcpsdk.CallToolResult, any, error) {
+	if input.Engine < 0 || input.Engine > types.MaxEngines {
+		return s.errorResult(fmt.Errorf("%w: engine must be between 1 and %d", ErrInvalidInput, types.MaxEngines)), nil, nil
+	}
 	eng, err := s.state.GetEngine()
 	if err != nil {
 		return s.errorResult(err), nil, nil
 	}
+	if input.Engine > len(eng.Engines) {
+		return s.errorResult(fmt.Errorf("%w: engine %d requested but the aircraft has %d",
+			ErrInvalidInput, input.Engine, len(eng.Engines))), nil, nil
+	}
 
 	resp := EngineDataResponse{
 		NumberOfEngines:   int(eng.NumberOfEngines),
-		ThrottlePosition1: eng.ThrottlePosition1,
-		ThrottlePosition2: eng.ThrottlePosition2,
-		RPM1:              eng.RPM1,
-		RPM2:              eng.RPM2,
-		N1Engine1:         eng.N1Engine1,
-		N1Engine2:         eng.N1Engine2,
-		N2Engine1:         eng.N2Engine1,
-		N2Engine2:         eng.N2Engine2,
-		FuelFlow1:         eng.FuelFlow1,
-		FuelFlow2:         eng.FuelFlow2,
-		EGT1:              eng.EGT1,
-		EGT2:              eng.EGT2,
-		OilTemp1:          eng.OilTemp1,
-		OilTemp2:          eng.OilTemp2,
-		OilPressure1:      eng.OilPressure1,
-		OilPressure2:      eng.OilPressure2,
+		Engines:           make([]EngineResponse, 0, len(eng.Engines)),
 		FuelTotalQuantity: eng.FuelTotalQuantity,
 		FuelLeftQuantity:  eng.FuelLeftQuantity,
 		FuelRightQuantity: eng.FuelRightQuantity,
 		Timestamp:         time.Now().UTC().Format(time.RFC3339),
 	}
+	for i := range eng.Engines {
+		if input.Engine != 0 && input.Engine != i+1 {
+			continue
+		}
+		e := &eng.Engines[i]
+		resp.Engines = append(resp.Engines, EngineResponse{
+			EngineNumber:     i + 1,
+			ThrottlePosition: e.ThrottlePosition,
+			RPM:              e.RPM,
+			N1:               e.N1,
+			N2:               e.N2,
+			FuelFlow:         e.FuelFlow,
+			EGT:              e.EGT,
+			ITT:              e.ITT,
+			Torque:           e.Torque,
+			OilTemp:          e.OilTemp,
+			OilPressure:      e.OilPressure,
+		})
+	}
 
 	return s.jsonResult(resp)
 }
@@ -333,15 +529,17 @@ func (s *Server) handleGetEnvironment(
 	}
 
 	resp := EnvironmentResponse{
-		WindVelocity:  env.WindVelocity,
-		WindDirection: env.WindDirection,
-		Temperature:   env.Temperature,
-		Pressure:      env.Pressure,
-		Visibility:    env.Visibility,
-		PrecipState:   int(env.PrecipState),
-		LocalTime:     env.LocalTime,
-		ZuluTime:      env.ZuluTime,
-		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		WindVelocity:     env.WindVelocity,
+		WindDirection:    env.WindDirection,
+		Temperature:      env.Temperature,
+		Pressure:         env.Pressure,
+		Visibility:       env.Visibility,
+		PrecipState:      int(env.PrecipState),
+		LocalTime:        env.LocalTime,
+		ZuluTime:         env.ZuluTime,
+		SeaLevelPressure: env.SeaLevelPressure,
+		ZuluDayOfMonth:   int(env.ZuluDayOfMonth),
+		Timestamp:        time.Now().UTC().Format(time.RFC3339),
 	}
 
 	return s.jsonResult(resp)
@@ -357,7 +555,13 @@ func (s *Server) handleGetAutopilotState(
 		return s.errorResult(err), nil, nil
 	}
 
-	resp := AutopilotStateResponse{
+	return s.jsonResult(newAutopilotStateResponse(&ap))
+}
+
+// --- Helpers ---
+
+func newAutopilotStateResponse(ap *types.AutopilotState) AutopilotStateResponse {
+	return AutopilotStateResponse{
 		Master:          ap.Master != 0,
 		HeadingLock:     ap.HeadingLock != 0,
 		Nav1Lock:        ap.Nav1Lock != 0,
@@ -372,12 +576,8 @@ func (s *Server) handleGetAutopilotState(
 		AirspeedHoldVar: ap.AirspeedHoldVar,
 		Timestamp:       time.Now().UTC().Format(time.RFC3339),
 	}
-
-	return s.jsonResult(resp)
 }
 
-// --- Helpers ---
-
 func (s *Server) jsonResult(resp any) (*mcpsdk.CallToolResult, any, error) {
 	data, err := json.Marshal(resp)
 	if err != nil {
@@ -389,6 +589,9 @@ func (s *Server) jsonResult(resp any) (*mcpsdk.CallToolResult, any, error) {
 }
 
 func (s *Server) errorResult(err error) *mcpsdk.CallToolResult {
+	if errors.Is(err, state.ErrStale) && s.conn.disconnected() {
+		err = fmt.Errorf("%w: %w", simconnect.ErrNotConnected, err)
+	}
 	resp := SimulatorUnavailableResponse{
 		Available: false,
 		Error:     err.Error(),
@@ -396,14 +599,34 @@ func (s *Server) errorResult(err error) *mcpsdk.CallToolResult {
 	}
 
 	switch {
-	case errors.Is(err, state.ErrStale):
-		resp.Code = "DATA_STALE"
+	case errors.Is(err, ErrInvalidInput):
+		resp.Code = "INVALID_INPUT"
 		resp.Recoverable = true
-		resp.Suggestion = "Wait for the simulator to send fresh data."
+		resp.Suggestion = "Correct the tool arguments and try again."
 	case errors.Is(err, simconnect.ErrNotConnected):
 		resp.Code = "SIMULATOR_NOT_CONNECTED"
 		resp.Recoverable = true
 		resp.Suggestion = "Ensure Microsoft Flight Simulator is running."
+	case errors.Is(err, state.ErrPaused):
+		resp.Code = "SIMULATOR_PAUSED"
+		resp.Recoverable = true
+		resp.Suggestion = "The simulator is paused and its data is frozen; unpause it to resume live data."
+	case errors.Is(err, state.ErrInMenu):
+		resp.Code = "IN_MENU"
+		resp.Recoverable = true
+		resp.Suggestion = "The simulator is in the main menu; start or load a flight to get live data."
+	case errors.Is(err, state.ErrStale):
+		resp.Code = "DATA_STALE"
+		resp.Recoverable = true
+		resp.Suggestion = "Wait for the simulator to send fresh data."
+	case errors.Is(err, simconnect.ErrException):
+		resp.Code = "SIMCONNECT_EXCEPTION"
+		resp.Recoverable = true
+		resp.Suggestion = "The simulator rejected the request; see get_simconnect_diagnostics for details."
+	case errors.Is(err, context.DeadlineExceeded):
+		resp.Code = "TIMEOUT"
+		resp.Recoverable = true
+		resp.Suggestion = "The simulator did not answer in time; check get_simulator_status and try again."
 	default:
 		resp.Code = "UNKNOWN_ERROR"
 		resp.Recoverable = false
@@ -26,6 +26,11 @@ type mockStateGetter struct {
 	eng  types.EngineData
 	env  types.Environment
 	ap   types.AutopilotState
+	acft types.AircraftInfo
+	fc   types.FlightControls
+	sys  types.SystemsStatus
+	nav  types.Navigation
+	life types.SimLifecycle
 	err  error
 }
 
@@ -49,6 +54,26 @@ func (m *mockStateGetter) GetAutopilot() (types.AutopilotState, error) {
 	return m.ap, m.err
 }
 
+func (m *mockStateGetter) GetAircraftInfo() (types.AircraftInfo, error) {
+	return m.acft, m.err
+}
+
+func (m *mockStateGetter) GetFlightControls() (types.FlightControls, error) {
+	return m.fc, m.err
+}
+
+func (m *mockStateGetter) GetSystems() (types.SystemsStatus, error) {
+	return m.sys, m.err
+}
+
+func (m *mockStateGetter) GetNavigation() (types.Navigation, error) {
+	return m.nav, m.err
+}
+
+func (m *mockStateGetter) GetLifecycle() types.SimLifecycle {
+	return m.life
+}
+
 var samplePos = types.AircraftPosition{
 	Latitude:       47.6062,
 	Longitude:      -122.3321,
@@ -79,27 +104,27 @@ var sampleInst = types.FlightInstruments{
 }
 
 var sampleEng = types.EngineData{
-	NumberOfEngines:   2.0,
-	ThrottlePosition1: 85.0,
-	ThrottlePosition2: 85.0,
-	RPM1:              2400.0,
-	RPM2:              2400.0,
-	N1Engine1:         92.0,
-	N1Engine2:         91.5,
+	NumberOfEngines: 2.0,
+	Engines: []types.Engine{
+		{ThrottlePosition: 85.0, RPM: 2400.0, N1: 92.0, EGT: 650.0},
+		{ThrottlePosition: 84.0, RPM: 2390.0, N1: 91.5, EGT: 648.0, ITT: 720.0, Torque: 1450.0},
+	},
 	FuelTotalQuantity: 500.0,
 	FuelLeftQuantity:  250.0,
 	FuelRightQuantity: 250.0,
 }
 
 var sampleEnv = types.Environment{
-	WindVelocity:  15.0,
-	WindDirection: 270.0,
-	Temperature:   -5.5,
-	Pressure:      29.92,
-	Visibility:    10000.0,
-	PrecipState:   4.0,
-	LocalTime:     43200.0,
-	ZuluTime:      50400.0,
+	WindVelocity:     15.0,
+	WindDirection:    270.0,
+	Temperature:      -5.5,
+	Pressure:         29.92,
+	Visibility:       10000.0,
+	PrecipState:      4.0,
+	LocalTime:        43200.0,
+	ZuluTime:         50400.0,
+	SeaLevelPressure: 1013.2,
+	ZuluDayOfMonth:   14,
 }
 
 var sampleAP = types.AutopilotState{
@@ -118,11 +143,22 @@ var sampleAP = types.AutopilotState{
 }
 
 // callTool connects the MCP server via in-memory transports and calls the given tool.
-func callTool(t *testing.T, sg internalmcp.StateGetter, toolName string, args map[string]any) *mcpsdk.CallToolResult {
+func callTool(
+	t *testing.T,
+	sg internalmcp.StateGetter,
+	toolName string,
+	args map[string]any,
+	opts ...internalmcp.Option,
+) *mcpsdk.CallToolResult {
+	t.Helper()
+	return callServerTool(t, internalmcp.NewServer(sg, opts...), toolName, args)
+}
+
+// callServerTool connects srv via in-memory transports and calls the given tool.
+func callServerTool(t *testing.T, srv *internalmcp.Server, toolName string, args map[string]any) *mcpsdk.CallToolResult {
 	t.Helper()
 	ctx := context.Background()
 
-	srv := internalmcp.NewServer(sg)
 	st, ct := mcpsdk.NewInMemoryTransports()
 
 	_, err := srv.Connect(ctx, st)
@@ -223,6 +259,27 @@ func TestGetAircraftPositionErrNotConnected(t *testing.T) {
 	assert.Equal(t, true, m["recoverable"])
 }
 
+func TestLiveDataLifecycleErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{state.ErrPaused, "SIMULATOR_PAUSED"},
+		{state.ErrInMenu, "IN_MENU"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			for _, tool := range []string{"get_aircraft_position", "get_flight_instruments", "get_engine_data", "get_environment", "get_autopilot_state", "get_flight_controls", "get_systems_status", "get_navigation"} {
+				res := callTool(t, &mockStateGetter{err: tt.err}, tool, nil)
+				require.True(t, res.IsError, tool)
+				m := parseJSON(t, res)
+				assert.Equal(t, tt.code, m["code"], tool)
+				assert.Equal(t, true, m["recoverable"], tool)
+			}
+		})
+	}
+}
+
 func TestGetAircraftPositionUnknownError(t *testing.T) {
 	sg := &mockStateGetter{err: errors.New("some unexpected error")}
 	res := callTool(t, sg, "get_aircraft_position", nil)
@@ -285,13 +342,51 @@ func TestGetEngineDataSuccess(t *testing.T) {
 	m := parseJSON(t, res)
 
 	assert.Equal(t, float64(2), m["number_of_engines"].(float64))
-	assert.InDelta(t, 85.0, m["throttle_position_1_pct"].(float64), 1e-9)
-	assert.InDelta(t, 2400.0, m["rpm_1"].(float64), 1e-9)
-	assert.InDelta(t, 92.0, m["n1_engine_1_pct"].(float64), 1e-9)
+	engines := m["engines"].([]any)
+	require.Len(t, engines, 2)
+	e1 := engines[0].(map[string]any)
+	assert.Equal(t, float64(1), e1["engine_number"])
+	assert.InDelta(t, 85.0, e1["throttle_position_pct"].(float64), 1e-9)
+	assert.InDelta(t, 2400.0, e1["rpm"].(float64), 1e-9)
+	assert.InDelta(t, 92.0, e1["n1_pct"].(float64), 1e-9)
+	assert.InDelta(t, 650.0, e1["egt_celsius"].(float64), 1e-9)
+	e2 := engines[1].(map[string]any)
+	assert.Equal(t, float64(2), e2["engine_number"])
+	assert.InDelta(t, 720.0, e2["itt_celsius"].(float64), 1e-9)
+	assert.InDelta(t, 1450.0, e2["torque_ft_lbs"].(float64), 1e-9)
 	assert.InDelta(t, 500.0, m["fuel_total_gal"].(float64), 1e-9)
 	assert.InDelta(t, 250.0, m["fuel_left_gal"].(float64), 1e-9)
 }
 
+func TestGetEngineDataFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		engine   int
+		wantCode string
+	}{
+		{name: "engine 2", engine: 2},
+		{name: "engine the aircraft lacks", engine: 3, wantCode: "INVALID_INPUT"},
+		{name: "engine above four", engine: 5, wantCode: "INVALID_INPUT"},
+		{name: "negative engine", engine: -1, wantCode: "INVALID_INPUT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := callTool(t, &mockStateGetter{eng: sampleEng}, "get_engine_data", map[string]any{"engine": tt.engine})
+			m := parseJSON(t, res)
+			if tt.wantCode != "" {
+				require.True(t, res.IsError)
+				assert.Equal(t, tt.wantCode, m["code"])
+				return
+			}
+			require.False(t, res.IsError)
+			engines := m["engines"].([]any)
+			require.Len(t, engines, 1)
+			assert.Equal(t, float64(tt.engine), engines[0].(map[string]any)["engine_number"])
+			assert.Equal(t, float64(2), m["number_of_engines"])
+		})
+	}
+}
+
 func TestGetEngineDataErrStale(t *testing.T) {
 	sg := &mockStateGetter{err: state.ErrStale}
 	res := callTool(t, sg, "get_engine_data", nil)
@@ -317,6 +412,8 @@ func TestGetEnvironmentSuccess(t *testing.T) {
 	assert.InDelta(t, 10000.0, m["visibility_m"].(float64), 1e-9)
 	assert.Equal(t, float64(4), m["precip_state"].(float64))
 	assert.InDelta(t, 43200.0, m["local_time_sec"].(float64), 1e-9)
+	assert.InDelta(t, 1013.2, m["sea_level_pressure_mb"].(float64), 1e-9)
+	assert.Equal(t, 14.0, m["zulu_day_of_month"])
 }
 
 func TestGetEnvironmentErrStale(t *testing.T) {
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+// SimVarReader reads allowlisted SimVars on demand.
+// Implemented by simconnect.Client.
+type SimVarReader interface {
+	QuerySimVars(ctx context.Context, queries []simconnect.SimVarQuery) ([]simconnect.SimVarValue, error)
+}
+
+// maxReadSimVars caps how many SimVars one read_simvars call may request.
+const maxReadSimVars = 16
+
+// --- Input structs ---
+
+type simVarQueryInput struct {
+	Name string `json:"name" jsonschema:"SimVar name, with an index suffix for indexed SimVars, e.g. GENERAL ENG RPM:2"`
+	Unit string `json:"unit,omitempty" jsonschema:"optional unit override, e.g. meters or knots; defaults to the SimVar's standard unit"`
+}
+
+type readSimVarsInput struct {
+	SimVars []simVarQueryInput `json:"simvars" jsonschema:"1-16 allowlisted SimVars to read from the user aircraft"`
+}
+
+// --- Response structs ---
+
+// ReadSimVarsResponse is the JSON payload returned by read_simvars. Values are
+// numbers, strings (e.g. TITLE) or objects for struct SimVars; string and
+// struct SimVars have no entry in Units.
+type ReadSimVarsResponse struct {
+	Values    map[string]any    `json:"values"`
+	Units     map[string]string `json:"units"`
+	Timestamp string            `json:"timestamp"`
+}
+
+// LatLonAltJSON is a STRUCT LATLONALT value in read_simvars.
+type LatLonAltJSON struct {
+	LatitudeDeg  float64 `json:"latitude_deg"`
+	LongitudeDeg float64 `json:"longitude_deg"`
+	AltitudeM    float64 `json:"altitude_m"`
+}
+
+// XYZJSON is an XYZ struct value in read_simvars.
+type XYZJSON struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// simVarJSON converts struct SimVar values to their JSON shape; other values
+// are returned unchanged.
+func simVarJSON(v any) any {
+	switch v := v.(type) {
+	case simconnect.LatLonAlt:
+		return LatLonAltJSON{LatitudeDeg: v.Latitude, LongitudeDeg: v.Longitude, AltitudeM: v.Altitude}
+	case simconnect.XYZ:
+		return XYZJSON{X: v.X, Y: v.Y, Z: v.Z}
+	default:
+		return v
+	}
+}
+
+func (s *Server) registerSimVarTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "read_simvars",
+		Description: "Reads up to 16 allowlisted SimVars once from the user aircraft and returns name to value, " +
+			"including strings such as TITLE, ATC ID, ATC MODEL and GPS WP NEXT ID. " +
+			"Indexed SimVars take an index suffix (e.g. GENERAL ENG RPM:2); each SimVar may set a unit override.",
+	}, s.handleReadSimVars)
+}
+
+func (s *Server) handleReadSimVars(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input readSimVarsInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	if len(input.SimVars) == 0 || len(input.SimVars) > maxReadSimVars {
+		return s.errorResult(fmt.Errorf("%w: simvars must list 1-%d SimVars", ErrInvalidInput, maxReadSimVars)), nil, nil
+	}
+	seen := make(map[string]bool, len(input.SimVars))
+	queries := make([]simconnect.SimVarQuery, 0, len(input.SimVars))
+	for _, in := range input.SimVars {
+		key := strings.ToUpper(strings.TrimSpace(in.Name))
+		if seen[key] {
+			return s.errorResult(fmt.Errorf("%w: %s is listed more than once", ErrInvalidInput, in.Name)), nil, nil
+		}
+		seen[key] = true
+		queries = append(queries, simconnect.SimVarQuery{Name: in.Name, Unit: in.Unit})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	vals, err := s.simvars.QuerySimVars(ctx, queries)
+	if errors.Is(err, simconnect.ErrInvalidSimVar) || errors.Is(err, simconnect.ErrInvalidUnit) {
+		err = fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	resp := ReadSimVarsResponse{
+		Values:    make(map[string]any, len(vals)),
+		Units:     make(map[string]string, len(vals)),
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, v := range vals {
+		resp.Values[v.Name] = simVarJSON(v.Value)
+		if v.Unit != "" {
+			resp.Units[v.Name] = v.Unit
+		}
+	}
+	return s.jsonResult(resp)
+}
@@ -0,0 +1,124 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+type fakeSimVarReader struct {
+	queries []simconnect.SimVarQuery
+	vals    []simconnect.SimVarValue
+	err     error
+}
+
+func (f *fakeSimVarReader) QuerySimVars(_ context.Context, queries []simconnect.SimVarQuery) ([]simconnect.SimVarValue, error) {
+	f.queries = queries
+	return f.vals, f.err
+}
+
+func TestReadSimVars(t *testing.T) {
+	r := &fakeSimVarReader{vals: []simconnect.SimVarValue{
+		{Name: "GENERAL ENG RPM:2", Unit: "rpm", Value: 2400},
+		{Name: "PLANE ALTITUDE", Unit: "meters", Value: 1524},
+	}}
+	res := callTool(t, &mockStateGetter{}, "read_simvars", map[string]any{
+		"simvars": []map[string]any{
+			{"name": "GENERAL ENG RPM:2"},
+			{"name": "PLANE ALTITUDE", "unit": "meters"},
+		},
+	}, internalmcp.WithSimVarReader(r))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, map[string]any{"GENERAL ENG RPM:2": 2400.0, "PLANE ALTITUDE": 1524.0}, m["values"])
+	assert.Equal(t, map[string]any{"GENERAL ENG RPM:2": "rpm", "PLANE ALTITUDE": "meters"}, m["units"])
+	assert.Equal(t, []simconnect.SimVarQuery{
+		{Name: "GENERAL ENG RPM:2"},
+		{Name: "PLANE ALTITUDE", Unit: "meters"},
+	}, r.queries)
+}
+
+func TestReadSimVarsStringsAndStructs(t *testing.T) {
+	r := &fakeSimVarReader{vals: []simconnect.SimVarValue{
+		{Name: "TITLE", Value: "Cessna Skyhawk G1000 Asobo"},
+		{Name: "STRUCT LATLONALT", Value: simconnect.LatLonAlt{Latitude: 47.45, Longitude: -122.31, Altitude: 130}},
+	}}
+	res := callTool(t, &mockStateGetter{}, "read_simvars", map[string]any{
+		"simvars": []map[string]any{{"name": "TITLE"}, {"name": "STRUCT LATLONALT"}},
+	}, internalmcp.WithSimVarReader(r))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, map[string]any{
+		"TITLE":            "Cessna Skyhawk G1000 Asobo",
+		"STRUCT LATLONALT": map[string]any{"latitude_deg": 47.45, "longitude_deg": -122.31, "altitude_m": 130.0},
+	}, m["values"])
+	assert.Empty(t, m["units"], "string and struct SimVars have no unit")
+}
+
+func TestReadSimVarsErrors(t *testing.T) {
+	many := make([]map[string]any, 17)
+	for i := range many {
+		many[i] = map[string]any{"name": fmt.Sprintf("GENERAL ENG RPM:%d", i)}
+	}
+	tests := []struct {
+		name     string
+		simvars  []map[string]any
+		err      error
+		wantCode string
+	}{
+		{name: "empty", simvars: []map[string]any{}, wantCode: "INVALID_INPUT"},
+		{name: "too many", simvars: many, wantCode: "INVALID_INPUT"},
+		{
+			name:     "duplicate",
+			simvars:  []map[string]any{{"name": "SIM ON GROUND"}, {"name": "sim on ground"}},
+			wantCode: "INVALID_INPUT",
+		},
+		{
+			name:     "not allowlisted",
+			simvars:  []map[string]any{{"name": "PLANE SELF DESTRUCT"}},
+			err:      fmt.Errorf("%w: PLANE SELF DESTRUCT", simconnect.ErrInvalidSimVar),
+			wantCode: "INVALID_INPUT",
+		},
+		{
+			name:     "bad unit",
+			simvars:  []map[string]any{{"name": "PLANE ALTITUDE", "unit": "cubits"}},
+			err:      fmt.Errorf("%w: cubits", simconnect.ErrInvalidUnit),
+			wantCode: "INVALID_INPUT",
+		},
+		{
+			name:     "rejected by simulator",
+			simvars:  []map[string]any{{"name": "LIGHT STROBE"}},
+			err:      &simconnect.Exception{Code: simconnect.ExceptionNameUnrecognized},
+			wantCode: "SIMCONNECT_EXCEPTION",
+		},
+		{
+			name:     "timeout",
+			simvars:  []map[string]any{{"name": "LIGHT STROBE"}},
+			err:      context.DeadlineExceeded,
+			wantCode: "TIMEOUT",
+		},
+		{
+			name:     "not connected",
+			simvars:  []map[string]any{{"name": "LIGHT STROBE"}},
+			err:      simconnect.ErrNotConnected,
+			wantCode: "SIMULATOR_NOT_CONNECTED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &fakeSimVarReader{err: tt.err}
+			res := callTool(t, &mockStateGetter{}, "read_simvars", map[string]any{"simvars": tt.simvars},
+				internalmcp.WithSimVarReader(r))
+			require.True(t, res.IsError)
+			assert.Equal(t, tt.wantCode, parseJSON(t, res)["code"])
+		})
+	}
+}
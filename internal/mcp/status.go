@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"context"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// StatusProvider reports the SimConnect connection status.
+// Implemented by simconnect.Client.
+type StatusProvider interface {
+	Status() simconnect.Status
+}
+
+// Simulator status messages.
+const (
+	statusMessageReady        = "Flight simulator is connected and data is available."
+	statusMessageNoData       = "Flight simulator is connected but no current flight data has been received yet."
+	statusMessagePaused       = "Flight simulator is connected but paused; live data is unavailable until it resumes."
+	statusMessageInMenu       = "Flight simulator is connected but in the main menu; no flight is running."
+	statusMessageDisconnected = "Flight simulator is not connected. Start Microsoft Flight Simulator 2024 to enable flight data access."
+)
+
+// --- Response structs ---
+
+// SimulatorInfoResponse describes the simulator reported in the OPEN acknowledgement.
+type SimulatorInfoResponse struct {
+	ApplicationName    string `json:"application_name"`
+	ApplicationVersion string `json:"application_version"`
+	SimConnectVersion  string `json:"simconnect_version"`
+}
+
+// SimulatorStatusResponse is the JSON payload returned by get_simulator_status.
+type SimulatorStatusResponse struct {
+	Connected       bool                   `json:"connected"`
+	ConnectionState string                 `json:"connection_state"`
+	DataAvailable   bool                   `json:"data_available"`
+	ConnectAttempts int64                  `json:"connect_attempts"`
+	LastConnectedAt string                 `json:"last_connected_at,omitempty"`
+	LastError       string                 `json:"last_error,omitempty"`
+	Simulator       *SimulatorInfoResponse `json:"simulator,omitempty"`
+	SimState        string                 `json:"sim_state"`
+	Paused          bool                   `json:"paused"`
+	Crashed         bool                   `json:"crashed"`
+	AircraftFile    string                 `json:"aircraft_file,omitempty"`
+	FlightFile      string                 `json:"flight_file,omitempty"`
+	Message         string                 `json:"message"`
+	Timestamp       string                 `json:"timestamp"`
+}
+
+func (s *Server) registerStatusTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_simulator_status",
+		Description: "Check if Microsoft Flight Simulator is connected and data is available, " +
+			"and whether a flight is running, paused, crashed or the sim is in the main menu. " +
+			"Call this before other tools to verify simulator availability.",
+	}, s.handleGetSimulatorStatus)
+}
+
+func (s *Server) handleGetSimulatorStatus(
+	_ context.Context,
+	_ *mcpsdk.CallToolRequest,
+	_ emptyInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	st := s.status.Status()
+	_, posErr := s.state.GetPosition()
+	life := s.state.GetLifecycle()
+
+	resp := SimulatorStatusResponse{
+		Connected:       st.State == simconnect.StateConnected,
+		ConnectionState: st.State.String(),
+		ConnectAttempts: st.ConnectAttempts,
+		SimState:        simStateName(&life),
+		Paused:          life.Paused,
+		Crashed:         life.Crashed,
+		AircraftFile:    life.AircraftFile,
+		FlightFile:      life.FlightFile,
+		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+	}
+	resp.DataAvailable = resp.Connected && posErr == nil
+	if !st.LastConnectedAt.IsZero() {
+		resp.LastConnectedAt = st.LastConnectedAt.UTC().Format(time.RFC3339)
+	}
+	if st.LastError != nil {
+		resp.LastError = st.LastError.Error()
+	}
+	if st.Open != nil {
+		resp.Simulator = &SimulatorInfoResponse{
+			ApplicationName:    st.Open.AppName,
+			ApplicationVersion: st.Open.AppVersion.String(),
+			SimConnectVersion:  st.Open.SimConnectVersion.String(),
+		}
+	}
+
+	switch {
+	case resp.DataAvailable:
+		resp.Message = statusMessageReady
+	case resp.Connected && resp.SimState == simStateInMenu:
+		resp.Message = statusMessageInMenu
+	case resp.Connected && life.Paused:
+		resp.Message = statusMessagePaused
+	case resp.Connected:
+		resp.Message = statusMessageNoData
+	default:
+		resp.Message = statusMessageDisconnected
+	}
+
+	return s.jsonResult(resp)
+}
+
+// Simulator lifecycle states reported as sim_state.
+const (
+	simStateUnknown = "unknown"
+	simStateInMenu  = "in_menu"
+	simStateRunning = "running"
+	simStatePaused  = "paused"
+	simStateCrashed = "crashed"
+)
+
+// simStateName summarizes the lifecycle as one sim_state value. A crash wins
+// over a pause, since the crash screen pauses the sim too.
+func simStateName(l *types.SimLifecycle) string {
+	switch {
+	case !l.Known:
+		return simStateUnknown
+	case !l.Running:
+		return simStateInMenu
+	case l.Crashed:
+		return simStateCrashed
+	case l.Paused:
+		return simStatePaused
+	default:
+		return simStateRunning
+	}
+}
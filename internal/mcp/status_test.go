@@ -0,0 +1,122 @@
+package mcp_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+type fakeStatus struct {
+	st simconnect.Status
+}
+
+func (f *fakeStatus) Status() simconnect.Status { return f.st }
+
+func TestGetSimulatorStatusConnected(t *testing.T) {
+	sp := &fakeStatus{st: simconnect.Status{
+		State:           simconnect.StateConnected,
+		ConnectAttempts: 1,
+		LastConnectedAt: time.Date(2026, 10, 16, 10, 25, 0, 0, time.UTC),
+		Open: &simconnect.OpenInfo{
+			AppName:           "KittyHawk",
+			AppVersion:        simconnect.Version{Major: 11, BuildMajor: 282174, BuildMinor: 999},
+			SimConnectVersion: simconnect.Version{Major: 11, BuildMajor: 62651, BuildMinor: 3},
+		},
+	}}
+	sg := &mockStateGetter{pos: samplePos}
+
+	res := callTool(t, sg, "get_simulator_status", nil, internalmcp.WithSimulatorStatus(sp))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, true, m["connected"])
+	assert.Equal(t, "connected", m["connection_state"])
+	assert.Equal(t, true, m["data_available"])
+	assert.Equal(t, float64(1), m["connect_attempts"])
+	assert.Equal(t, "2026-10-16T10:25:00Z", m["last_connected_at"])
+	assert.NotContains(t, m, "last_error")
+	assert.Equal(t, map[string]any{
+		"application_name":    "KittyHawk",
+		"application_version": "11.0.282174.999",
+		"simconnect_version":  "11.0.62651.3",
+	}, m["simulator"])
+	assert.Equal(t, "Flight simulator is connected and data is available.", m["message"])
+	assert.Equal(t, "unknown", m["sim_state"])
+	assert.NotContains(t, m, "aircraft_file")
+}
+
+func TestGetSimulatorStatusLifecycle(t *testing.T) {
+	tests := []struct {
+		name    string
+		life    types.SimLifecycle
+		err     error
+		state   string
+		message string
+	}{
+		{"running", types.SimLifecycle{Known: true, Running: true}, nil, "running", "data is available"},
+		{"paused", types.SimLifecycle{Known: true, Running: true, Paused: true}, state.ErrPaused, "paused", "paused"},
+		{"crashed", types.SimLifecycle{Known: true, Running: true, Paused: true, Crashed: true}, state.ErrPaused, "crashed", "paused"},
+		{"in menu", types.SimLifecycle{Known: true}, state.ErrInMenu, "in_menu", "main menu"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.life.AircraftFile = `SimObjects\Airplanes\C172\aircraft.cfg`
+			tt.life.FlightFile = `flights\other\MainMenu.FLT`
+			sp := &fakeStatus{st: simconnect.Status{State: simconnect.StateConnected}}
+			sg := &mockStateGetter{pos: samplePos, life: tt.life, err: tt.err}
+
+			res := callTool(t, sg, "get_simulator_status", nil, internalmcp.WithSimulatorStatus(sp))
+			require.False(t, res.IsError)
+			m := parseJSON(t, res)
+
+			assert.Equal(t, tt.state, m["sim_state"])
+			assert.Equal(t, tt.life.Paused, m["paused"])
+			assert.Equal(t, tt.life.Crashed, m["crashed"])
+			assert.Equal(t, tt.life.AircraftFile, m["aircraft_file"])
+			assert.Equal(t, tt.life.FlightFile, m["flight_file"])
+			assert.Contains(t, m["message"], tt.message)
+		})
+	}
+}
+
+func TestGetSimulatorStatusConnectedWithoutData(t *testing.T) {
+	sp := &fakeStatus{st: simconnect.Status{State: simconnect.StateConnected, ConnectAttempts: 1}}
+	sg := &mockStateGetter{err: state.ErrStale}
+
+	res := callTool(t, sg, "get_simulator_status", nil, internalmcp.WithSimulatorStatus(sp))
+	m := parseJSON(t, res)
+
+	assert.Equal(t, true, m["connected"])
+	assert.Equal(t, false, m["data_available"])
+	assert.Contains(t, m["message"], "no current flight data")
+}
+
+func TestGetSimulatorStatusDisconnected(t *testing.T) {
+	sp := &fakeStatus{st: simconnect.Status{
+		State:           simconnect.StateDisconnected,
+		ConnectAttempts: 5,
+		LastError:       errors.New("connection refused"),
+	}}
+	sg := &mockStateGetter{pos: samplePos}
+
+	res := callTool(t, sg, "get_simulator_status", nil, internalmcp.WithSimulatorStatus(sp))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, false, m["connected"])
+	assert.Equal(t, "disconnected", m["connection_state"])
+	assert.Equal(t, false, m["data_available"], "cached data is not available while disconnected")
+	assert.Equal(t, float64(5), m["connect_attempts"])
+	assert.Equal(t, "connection refused", m["last_error"])
+	assert.NotContains(t, m, "last_connected_at")
+	assert.NotContains(t, m, "simulator")
+	assert.Contains(t, m["message"], "not connected")
+}
@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// System names accepted in get_systems_status's systems input.
+const (
+	systemFuel           = "fuel"
+	systemElectrical     = "electrical"
+	systemHydraulics     = "hydraulics"
+	systemPressurization = "pressurization"
+	systemControls       = "controls"
+)
+
+var systemNames = []string{systemFuel, systemElectrical, systemHydraulics, systemPressurization, systemControls}
+
+type getSystemsStatusInput struct {
+	Systems []string `json:"systems,omitempty" jsonschema:"systems to query: fuel, electrical, hydraulics, pressurization, controls; omit for all"`
+}
+
+// FuelSystemResponse is the fuel section of get_systems_status.
+type FuelSystemResponse struct {
+	TotalQuantity float64 `json:"total_gal"`
+	TotalWeight   float64 `json:"total_lbs"`
+	LeftMain      float64 `json:"left_main_gal"`
+	RightMain     float64 `json:"right_main_gal"`
+	Center        float64 `json:"center_gal"`
+}
+
+// ElectricalSystemResponse is the electrical section of get_systems_status.
+type ElectricalSystemResponse struct {
+	MasterBatteryOn   bool    `json:"master_battery_on"`
+	MainBusVoltage    float64 `json:"main_bus_voltage_v"`
+	BatteryVoltage    float64 `json:"battery_voltage_v"`
+	BatteryLoad       float64 `json:"battery_load_amps"`
+	GenAltBusVoltage1 float64 `json:"generator_1_bus_voltage_v"`
+	GenAltBusVoltage2 float64 `json:"generator_2_bus_voltage_v"`
+}
+
+// HydraulicsSystemResponse is the hydraulics section of get_systems_status.
+type HydraulicsSystemResponse struct {
+	Pressure1 float64 `json:"system_1_pressure_psi"`
+	Pressure2 float64 `json:"system_2_pressure_psi"`
+	Pressure3 float64 `json:"system_3_pressure_psi"`
+}
+
+// PressurizationSystemResponse is the pressurization section of get_systems_status.
+type PressurizationSystemResponse struct {
+	CabinAltitude        float64 `json:"cabin_altitude_ft"`
+	CabinAltitudeRate    float64 `json:"cabin_altitude_rate_fpm"`
+	PressureDifferential float64 `json:"pressure_differential_psi"`
+	DumpSwitchOn         bool    `json:"dump_switch_on"`
+}
+
+// SystemsStatusResponse is the JSON payload returned by get_systems_status.
+// Sections that were not requested are omitted.
+type SystemsStatusResponse struct {
+	Fuel           *FuelSystemResponse           `json:"fuel,omitempty"`
+	Electrical     *ElectricalSystemResponse     `json:"electrical,omitempty"`
+	Hydraulics     *HydraulicsSystemResponse     `json:"hydraulics,omitempty"`
+	Pressurization *PressurizationSystemResponse `json:"pressurization,omitempty"`
+	FlightControls *FlightControlsStatus         `json:"flight_controls,omitempty"`
+	Timestamp      string                        `json:"timestamp"`
+}
+
+// selectedSystems validates the requested system names, which are
+// case-insensitive. An empty list selects every system.
+func selectedSystems(names []string) (map[string]bool, error) {
+	if len(names) == 0 {
+		names = systemNames
+	}
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(systemNames, key) {
+			return nil, fmt.Errorf("%w: unknown system %q; use one of: %s",
+				ErrInvalidInput, name, strings.Join(systemNames, ", "))
+		}
+		selected[key] = true
+	}
+	return selected, nil
+}
+
+func (s *Server) handleGetSystemsStatus(
+	_ context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input getSystemsStatusInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	selected, err := selectedSystems(input.Systems)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+	resp := SystemsStatusResponse{Timestamp: time.Now().UTC().Format(time.RFC3339)}
+
+	if selected[systemFuel] || selected[systemElectrical] || selected[systemHydraulics] || selected[systemPressurization] {
+		sys, err := s.state.GetSystems()
+		if err != nil {
+			return s.errorResult(err), nil, nil
+		}
+		setSystemSections(&resp, &sys, selected)
+	}
+	if selected[systemControls] {
+		fc, err := s.state.GetFlightControls()
+		if err != nil {
+			return s.errorResult(err), nil, nil
+		}
+		status := newFlightControlsStatus(&fc)
+		resp.FlightControls = &status
+	}
+
+	return s.jsonResult(resp)
+}
+
+// setSystemSections fills the selected sections backed by the systems group.
+func setSystemSections(resp *SystemsStatusResponse, sys *types.SystemsStatus, selected map[string]bool) {
+	if selected[systemFuel] {
+		resp.Fuel = &FuelSystemResponse{
+			TotalQuantity: sys.Fuel.TotalQuantity,
+			TotalWeight:   sys.Fuel.TotalWeight,
+			LeftMain:      sys.Fuel.LeftMain,
+			RightMain:     sys.Fuel.RightMain,
+			Center:        sys.Fuel.Center,
+		}
+	}
+	if selected[systemElectrical] {
+		resp.Electrical = &ElectricalSystemResponse{
+			MasterBatteryOn:   sys.Electrical.MasterBattery != 0,
+			MainBusVoltage:    sys.Electrical.MainBusVoltage,
+			BatteryVoltage:    sys.Electrical.BatteryVoltage,
+			BatteryLoad:       sys.Electrical.BatteryLoad,
+			GenAltBusVoltage1: sys.Electrical.GenAltBusVoltage1,
+			GenAltBusVoltage2: sys.Electrical.GenAltBusVoltage2,
+		}
+	}
+	if selected[systemHydraulics] {
+		resp.Hydraulics = &HydraulicsSystemResponse{
+			Pressure1: sys.Hydraulics.Pressure1,
+			Pressure2: sys.Hydraulics.Pressure2,
+			Pressure3: sys.Hydraulics.Pressure3,
+		}
+	}
+	if selected[systemPressurization] {
+		resp.Pressurization = &PressurizationSystemResponse{
+			CabinAltitude:        sys.Pressurization.CabinAltitude,
+			CabinAltitudeRate:    sys.Pressurization.CabinAltitudeRate,
+			PressureDifferential: sys.Pressurization.PressureDifferential,
+			DumpSwitchOn:         sys.Pressurization.DumpSwitch != 0,
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package mcp_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func systemsStateGetter() *mockStateGetter {
+	return &mockStateGetter{
+		sys: types.SystemsStatus{
+			Fuel:       types.FuelSystem{TotalQuantity: 53, TotalWeight: 318, LeftMain: 26, RightMain: 27},
+			Electrical: types.ElectricalSystem{MasterBattery: 1, MainBusVoltage: 28.1, BatteryLoad: 3.2, GenAltBusVoltage1: 28},
+			Hydraulics: types.HydraulicSystem{Pressure1: 3000},
+			Pressurization: types.PressurizationSystem{
+				CabinAltitude: 8000, CabinAltitudeRate: -300, PressureDifferential: 7.8, DumpSwitch: 1,
+			},
+		},
+		fc: types.FlightControls{FlapsHandleIndex: 1, GearHandle: 1},
+	}
+}
+
+func TestGetSystemsStatusAll(t *testing.T) {
+	res := callTool(t, systemsStateGetter(), "get_systems_status", nil)
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	fuel := m["fuel"].(map[string]any)
+	assert.Equal(t, 53.0, fuel["total_gal"])
+	assert.Equal(t, 318.0, fuel["total_lbs"])
+	assert.Equal(t, 27.0, fuel["right_main_gal"])
+
+	elec := m["electrical"].(map[string]any)
+	assert.Equal(t, true, elec["master_battery_on"])
+	assert.Equal(t, 28.1, elec["main_bus_voltage_v"])
+	assert.Equal(t, 3.2, elec["battery_load_amps"])
+	assert.Equal(t, 28.0, elec["generator_1_bus_voltage_v"])
+
+	hyd := m["hydraulics"].(map[string]any)
+	assert.Equal(t, 3000.0, hyd["system_1_pressure_psi"])
+
+	press := m["pressurization"].(map[string]any)
+	assert.Equal(t, 8000.0, press["cabin_altitude_ft"])
+	assert.Equal(t, -300.0, press["cabin_altitude_rate_fpm"])
+	assert.Equal(t, 7.8, press["pressure_differential_psi"])
+	assert.Equal(t, true, press["dump_switch_on"])
+
+	fc := m["flight_controls"].(map[string]any)
+	assert.Equal(t, 1.0, fc["flaps_handle_index"])
+	assert.Equal(t, true, fc["gear_handle_down"])
+	assert.NotContains(t, fc, "timestamp")
+
+	assert.Contains(t, m, "timestamp")
+}
+
+func TestGetSystemsStatusFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		systems []string
+		want    []string
+	}{
+		{"single", []string{"electrical"}, []string{"electrical"}},
+		{"case insensitive", []string{"Fuel", "CONTROLS"}, []string{"fuel", "flight_controls"}},
+		{"pressurization and hydraulics", []string{"pressurization", "hydraulics"}, []string{"hydraulics", "pressurization"}},
+	}
+	sections := []string{"fuel", "electrical", "hydraulics", "pressurization", "flight_controls"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := callTool(t, systemsStateGetter(), "get_systems_status", map[string]any{"systems": tt.systems})
+			require.False(t, res.IsError)
+			m := parseJSON(t, res)
+			for _, s := range sections {
+				if slices.Contains(tt.want, s) {
+					assert.Contains(t, m, s)
+				} else {
+					assert.NotContains(t, m, s)
+				}
+			}
+		})
+	}
+}
+
+func TestGetSystemsStatusInvalidSystem(t *testing.T) {
+	res := callTool(t, systemsStateGetter(), "get_systems_status", map[string]any{"systems": []string{"fuel", "avionics"}})
+	require.True(t, res.IsError)
+	m := parseJSON(t, res)
+	assert.Equal(t, "INVALID_INPUT", m["code"])
+	assert.Contains(t, m["error"], "avionics")
+}
+
+func TestGetSystemsStatusStale(t *testing.T) {
+	tests := []struct {
+		name    string
+		systems []string
+	}{
+		{"systems group", []string{"fuel"}},
+		{"controls group", []string{"controls"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := &mockStateGetter{err: state.ErrStale}
+			res := callTool(t, sg, "get_systems_status", map[string]any{"systems": tt.systems})
+			require.True(t, res.IsError)
+			assert.Equal(t, "DATA_STALE", parseJSON(t, res)["code"])
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package mcp
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// TrafficReader lists AI and multiplayer objects near the user aircraft.
+// Implemented by simconnect.Client.
+type TrafficReader interface {
+	QueryTraffic(ctx context.Context, radiusMeters uint32, objType simconnect.SimObjectType) ([]types.TrafficObject, error)
+}
+
+const (
+	defaultTrafficRadiusNM = 20
+	maxTrafficRadiusNM     = 100
+)
+
+// trafficTypes maps get_nearby_traffic type names to SimConnect object types.
+var trafficTypes = map[string]simconnect.SimObjectType{
+	"aircraft":   simconnect.SimObjectTypeAircraft,
+	"helicopter": simconnect.SimObjectTypeHelicopter,
+	"ground":     simconnect.SimObjectTypeGround,
+}
+
+// defaultTrafficTypes are searched when the types argument is omitted.
+var defaultTrafficTypes = []string{"aircraft", "helicopter"}
+
+// --- Input structs ---
+
+type getNearbyTrafficInput struct {
+	RadiusNM float64  `json:"radius_nm,omitempty" jsonschema:"search radius in nautical miles, 1-100 (default 20)"`
+	Types    []string `json:"types,omitempty" jsonschema:"object types to list: aircraft, helicopter, ground (default aircraft and helicopter)"`
+}
+
+// --- Response structs ---
+
+// TrafficTargetJSON is one object in get_nearby_traffic, positioned relative
+// to the user aircraft.
+type TrafficTargetJSON struct {
+	ObjectID           uint32  `json:"object_id"`
+	Type               string  `json:"type"`
+	Callsign           string  `json:"callsign"`
+	ATCID              string  `json:"atc_id"`
+	Model              string  `json:"model"`
+	RangeNM            float64 `json:"range_nm"`
+	BearingDeg         float64 `json:"bearing_deg"`
+	RelativeBearingDeg float64 `json:"relative_bearing_deg"`
+	RelativeAltitudeFt float64 `json:"relative_altitude_ft"`
+	AltitudeFt         float64 `json:"altitude_ft"`
+	ClosureRateKts     float64 `json:"closure_rate_kts"`
+	HeadingDeg         float64 `json:"heading_deg"`
+	GroundSpeedKts     float64 `json:"ground_speed_kts"`
+	VerticalSpeedFPM   float64 `json:"vertical_speed_fpm"`
+	OnGround           bool    `json:"on_ground"`
+}
+
+// NearbyTrafficResponse is the JSON payload returned by get_nearby_traffic,
+// with targets ordered nearest first.
+type NearbyTrafficResponse struct {
+	RadiusNM  float64             `json:"radius_nm"`
+	Types     []string            `json:"types"`
+	Count     int                 `json:"count"`
+	Traffic   []TrafficTargetJSON `json:"traffic"`
+	Timestamp string              `json:"timestamp"`
+}
+
+func (s *Server) registerTrafficTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_nearby_traffic",
+		Description: "Lists AI and multiplayer traffic within a radius of the user aircraft (default 20 NM), nearest first: " +
+			"callsign, model, range, true and relative bearing, relative altitude, closure rate (positive when closing), " +
+			"heading, ground speed and vertical speed. Types may be aircraft, helicopter and ground.",
+	}, s.handleGetNearbyTraffic)
+}
+
+func (s *Server) handleGetNearbyTraffic(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input getNearbyTrafficInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	radiusNM := input.RadiusNM
+	if radiusNM == 0 {
+		radiusNM = defaultTrafficRadiusNM
+	}
+	if math.IsNaN(radiusNM) || radiusNM < 1 || radiusNM > maxTrafficRadiusNM {
+		return s.errorResult(fmt.Errorf("%w: radius_nm must be between 1 and %d", ErrInvalidInput, maxTrafficRadiusNM)), nil, nil
+	}
+	names, err := selectedTrafficTypes(input.Types)
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	own, err := s.state.GetPosition()
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	radiusMeters := uint32(math.Ceil(radiusNM * geo.MetersPerNM))
+	targets := []TrafficTargetJSON{}
+	for _, name := range names {
+		objects, err := s.traffic.QueryTraffic(ctx, radiusMeters, trafficTypes[name])
+		if err != nil {
+			return s.errorResult(err), nil, nil
+		}
+		for _, obj := range objects {
+			t := newTrafficTarget(&own, &obj)
+			if t.RangeNM <= radiusNM {
+				targets = append(targets, t)
+			}
+		}
+	}
+	slices.SortStableFunc(targets, func(a, b TrafficTargetJSON) int {
+		return cmp.Compare(a.RangeNM, b.RangeNM)
+	})
+
+	return s.jsonResult(NearbyTrafficResponse{
+		RadiusNM:  radiusNM,
+		Types:     names,
+		Count:     len(targets),
+		Traffic:   targets,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// selectedTrafficTypes normalizes and validates the requested object types,
+// defaulting to aircraft and helicopters.
+func selectedTrafficTypes(in []string) ([]string, error) {
+	if len(in) == 0 {
+		return defaultTrafficTypes, nil
+	}
+	names := make([]string, 0, len(in))
+	for _, t := range in {
+		name := strings.ToLower(strings.TrimSpace(t))
+		if _, ok := trafficTypes[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown traffic type %q; use aircraft, helicopter or ground", ErrInvalidInput, t)
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// newTrafficTarget positions obj relative to the user aircraft. The closure
+// rate is the rate the range shrinks at, from both ground velocity vectors.
+func newTrafficTarget(own *types.AircraftPosition, obj *types.TrafficObject) TrafficTargetJSON {
+	rangeNM := geo.DistanceNM(own.Latitude, own.Longitude, obj.Latitude, obj.Longitude)
+	bearing := geo.BearingDeg(own.Latitude, own.Longitude, obj.Latitude, obj.Longitude)
+
+	var closure float64
+	if rangeNM > 0 {
+		ownE, ownN := velocity(own.HeadingTrue, own.GroundSpeed)
+		objE, objN := velocity(obj.HeadingTrue, obj.GroundSpeed)
+		losE, losN := velocity(bearing, 1)
+		closure = -((objE-ownE)*losE + (objN-ownN)*losN)
+	}
+
+	callsign := obj.ATCID
+	if obj.ATCAirline != "" && obj.ATCFlightNumber != "" {
+		callsign = obj.ATCAirline + " " + obj.ATCFlightNumber
+	}
+
+	return TrafficTargetJSON{
+		ObjectID:           obj.ObjectID,
+		Type:               obj.Type,
+		Callsign:           callsign,
+		ATCID:              obj.ATCID,
+		Model:              obj.ATCModel,
+		RangeNM:            round1(rangeNM),
+		BearingDeg:         math.Round(bearing),
+		RelativeBearingDeg: math.Round(geo.RelativeDeg(bearing, own.HeadingTrue)),
+		RelativeAltitudeFt: math.Round(obj.Altitude - own.AltitudeMSL),
+		AltitudeFt:         math.Round(obj.Altitude),
+		ClosureRateKts:     math.Round(closure),
+		HeadingDeg:         math.Round(geo.NormalizeDeg(obj.HeadingTrue)),
+		GroundSpeedKts:     math.Round(obj.GroundSpeed),
+		VerticalSpeedFPM:   math.Round(obj.VerticalSpeed),
+		OnGround:           obj.OnGround != 0,
+	}
+}
+
+// velocity splits a ground track and speed into east and north components.
+func velocity(trackDeg, speed float64) (east, north float64) {
+	sin, cos := math.Sincos(trackDeg * math.Pi / 180)
+	return speed * sin, speed * cos
+}
+
+func round1(v float64) float64 { return math.Round(v*10) / 10 }
@@ -0,0 +1,149 @@
+package mcp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+type fakeTrafficReader struct {
+	objects map[simconnect.SimObjectType][]types.TrafficObject
+	queried []simconnect.SimObjectType
+	radius  uint32
+	err     error
+}
+
+func (f *fakeTrafficReader) QueryTraffic(_ context.Context, radiusMeters uint32, objType simconnect.SimObjectType) ([]types.TrafficObject, error) {
+	f.queried = append(f.queried, objType)
+	f.radius = radiusMeters
+	return f.objects[objType], f.err
+}
+
+var trafficOwnShip = types.AircraftPosition{
+	Latitude: 47, Longitude: -122, AltitudeMSL: 3000, HeadingTrue: 0, GroundSpeed: 100,
+}
+
+// trafficAt places a target bearingDeg and distanceNM from trafficOwnShip.
+func trafficAt(id uint32, bearingDeg, distanceNM float64, obj types.TrafficObject) types.TrafficObject {
+	obj.ObjectID = id
+	obj.Latitude, obj.Longitude = geo.Destination(trafficOwnShip.Latitude, trafficOwnShip.Longitude, bearingDeg, distanceNM)
+	return obj
+}
+
+func TestGetNearbyTraffic(t *testing.T) {
+	r := &fakeTrafficReader{objects: map[simconnect.SimObjectType][]types.TrafficObject{
+		simconnect.SimObjectTypeAircraft: {
+			// Abeam to the right and flying away.
+			trafficAt(7, 90, 10, types.TrafficObject{
+				Type: "aircraft", ATCID: "N12345", ATCModel: "C172",
+				Altitude: 2500, HeadingTrue: 90, GroundSpeed: 120,
+			}),
+			// Dead ahead, head-on.
+			trafficAt(9, 0, 5, types.TrafficObject{
+				Type: "aircraft", ATCID: "N737AS", ATCAirline: "Alaska", ATCFlightNumber: "123", ATCModel: "B738",
+				Altitude: 5000, HeadingTrue: 180, GroundSpeed: 150, VerticalSpeed: -800,
+			}),
+		},
+		simconnect.SimObjectTypeHelicopter: {
+			trafficAt(11, 180, 30, types.TrafficObject{Type: "helicopter", ATCID: "N911MH"}),
+		},
+	}}
+	res := callTool(t, &mockStateGetter{pos: trafficOwnShip}, "get_nearby_traffic", nil,
+		internalmcp.WithTrafficReader(r))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, []simconnect.SimObjectType{simconnect.SimObjectTypeAircraft, simconnect.SimObjectTypeHelicopter}, r.queried)
+	assert.Equal(t, uint32(37040), r.radius, "20 NM in meters")
+	assert.Equal(t, 20.0, m["radius_nm"])
+	assert.Equal(t, 2.0, m["count"], "the helicopter is outside the radius")
+
+	traffic := m["traffic"].([]any)
+	require.Len(t, traffic, 2)
+	headOn := traffic[0].(map[string]any)
+	assert.Equal(t, map[string]any{
+		"object_id":            9.0,
+		"type":                 "aircraft",
+		"callsign":             "Alaska 123",
+		"atc_id":               "N737AS",
+		"model":                "B738",
+		"range_nm":             5.0,
+		"bearing_deg":          0.0,
+		"relative_bearing_deg": 0.0,
+		"relative_altitude_ft": 2000.0,
+		"altitude_ft":          5000.0,
+		"closure_rate_kts":     250.0,
+		"heading_deg":          180.0,
+		"ground_speed_kts":     150.0,
+		"vertical_speed_fpm":   -800.0,
+		"on_ground":            false,
+	}, headOn)
+
+	abeam := traffic[1].(map[string]any)
+	assert.Equal(t, "N12345", abeam["callsign"], "falls back to the ATC ID without an airline")
+	assert.Equal(t, 10.0, abeam["range_nm"])
+	assert.Equal(t, 90.0, abeam["relative_bearing_deg"])
+	assert.Equal(t, -500.0, abeam["relative_altitude_ft"])
+	assert.Equal(t, -120.0, abeam["closure_rate_kts"])
+}
+
+func TestGetNearbyTrafficRelativeBearing(t *testing.T) {
+	own := trafficOwnShip
+	own.HeadingTrue = 270
+	r := &fakeTrafficReader{objects: map[simconnect.SimObjectType][]types.TrafficObject{
+		simconnect.SimObjectTypeGround: {trafficAt(3, 0, 2, types.TrafficObject{Type: "ground", OnGround: 1})},
+	}}
+	res := callTool(t, &mockStateGetter{pos: own}, "get_nearby_traffic", map[string]any{
+		"radius_nm": 5, "types": []string{"Ground"},
+	}, internalmcp.WithTrafficReader(r))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, []any{"ground"}, m["types"])
+	target := m["traffic"].([]any)[0].(map[string]any)
+	assert.Equal(t, 90.0, target["relative_bearing_deg"], "due north is off the right wing when heading west")
+	assert.Equal(t, true, target["on_ground"])
+}
+
+func TestGetNearbyTrafficNone(t *testing.T) {
+	res := callTool(t, &mockStateGetter{pos: trafficOwnShip}, "get_nearby_traffic", nil,
+		internalmcp.WithTrafficReader(&fakeTrafficReader{}))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+	assert.Equal(t, 0.0, m["count"])
+	assert.Equal(t, []any{}, m["traffic"])
+}
+
+func TestGetNearbyTrafficErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]any
+		stateErr error
+		err      error
+		wantCode string
+	}{
+		{name: "radius too small", args: map[string]any{"radius_nm": 0.5}, wantCode: "INVALID_INPUT"},
+		{name: "radius too large", args: map[string]any{"radius_nm": 150}, wantCode: "INVALID_INPUT"},
+		{name: "unknown type", args: map[string]any{"types": []string{"boat"}}, wantCode: "INVALID_INPUT"},
+		{name: "stale position", stateErr: state.ErrStale, wantCode: "DATA_STALE"},
+		{name: "timeout", err: context.DeadlineExceeded, wantCode: "TIMEOUT"},
+		{name: "not connected", err: simconnect.ErrNotConnected, wantCode: "SIMULATOR_NOT_CONNECTED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &fakeTrafficReader{err: tt.err}
+			res := callTool(t, &mockStateGetter{pos: trafficOwnShip, err: tt.stateErr}, "get_nearby_traffic", tt.args,
+				internalmcp.WithTrafficReader(r))
+			require.True(t, res.IsError)
+			assert.Equal(t, tt.wantCode, parseJSON(t, res)["code"])
+		})
+	}
+}
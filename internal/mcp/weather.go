@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/eytandecker/flightsim-mcp/internal/metar"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+// WeatherReader requests METAR observations from the simulator's weather
+// stations. Implemented by simconnect.Client.
+type WeatherReader interface {
+	StationObservation(ctx context.Context, icao string) (string, error)
+	NearestObservation(ctx context.Context, lat, lon float64) (string, error)
+}
+
+// stationPattern matches weather station idents. SimConnect takes at most
+// four characters, unlike the longer airport idents icaoPattern allows.
+var stationPattern = regexp.MustCompile(`^[A-Z0-9]{2,4}$`)
+
+// --- Input structs ---
+
+type getWeatherReportInput struct {
+	Station string `json:"station,omitempty" jsonschema:"ICAO ident of the reporting station, 2-4 characters, e.g. KSEA; omit for the station nearest the aircraft"`
+}
+
+// --- Response structs ---
+
+// CloudLayerJSON is one cloud layer of a decoded METAR.
+type CloudLayerJSON struct {
+	Cover     string `json:"cover"`
+	BaseFtAGL *int   `json:"base_ft_agl,omitempty"`
+	Type      string `json:"type,omitempty"`
+}
+
+// DecodedMETARJSON is a METAR with its groups decoded. Groups the report
+// does not contain are omitted.
+type DecodedMETARJSON struct {
+	Raw                  string           `json:"raw"`
+	Station              string           `json:"station"`
+	DayOfMonth           int              `json:"day_of_month,omitempty"`
+	TimeUTC              string           `json:"time_utc,omitempty"`
+	WindDirectionTrueDeg *int             `json:"wind_direction_true_deg,omitempty"`
+	WindVariable         bool             `json:"wind_variable,omitempty"`
+	WindVariableRangeDeg []int            `json:"wind_variable_range_deg,omitempty"`
+	WindSpeedKt          *int             `json:"wind_speed_kt,omitempty"`
+	WindGustKt           int              `json:"wind_gust_kt,omitempty"`
+	VisibilitySM         *float64         `json:"visibility_sm,omitempty"`
+	VisibilityM          *float64         `json:"visibility_m,omitempty"`
+	VisibilityQualifier  string           `json:"visibility_qualifier,omitempty"`
+	CAVOK                bool             `json:"cavok,omitempty"`
+	Weather              []string         `json:"weather,omitempty"`
+	WeatherDescription   []string         `json:"weather_description,omitempty"`
+	SkyClear             bool             `json:"sky_clear,omitempty"`
+	Clouds               []CloudLayerJSON `json:"clouds,omitempty"`
+	CeilingFtAGL         *int             `json:"ceiling_ft_agl,omitempty"`
+	TemperatureC         *int             `json:"temperature_c,omitempty"`
+	DewpointC            *int             `json:"dewpoint_c,omitempty"`
+	AltimeterInHg        *float64         `json:"altimeter_inhg,omitempty"`
+	AltimeterHPa         *float64         `json:"altimeter_hpa,omitempty"`
+	FlightCategory       string           `json:"flight_category,omitempty"`
+	Remarks              string           `json:"remarks,omitempty"`
+	Undecoded            []string         `json:"undecoded,omitempty"`
+}
+
+// WeatherReportResponse is the JSON payload returned by get_weather_report.
+// Observation is the simulator's report for the station, or null with a note
+// explaining why there is none; Synthesized is built from the weather at the
+// aircraft and is always present.
+type WeatherReportResponse struct {
+	Station         string            `json:"station,omitempty"`
+	Observation     *DecodedMETARJSON `json:"observation"`
+	ObservationNote string            `json:"observation_note,omitempty"`
+	Synthesized     DecodedMETARJSON  `json:"synthesized"`
+	Timestamp       string            `json:"timestamp"`
+}
+
+func (s *Server) registerWeatherTools() {
+	mcpsdk.AddTool(s.sdk, &mcpsdk.Tool{
+		Name: "get_weather_report",
+		Description: "Returns weather in METAR format: the simulator's observation for a station, e.g. KSEA, or for the " +
+			"station nearest the aircraft, decoded into wind, visibility, weather, clouds, ceiling, temperature, altimeter " +
+			"and flight category, plus a METAR synthesized from the weather at the aircraft. MSFS serves observations for " +
+			"few stations; the synthesized report is always included.",
+	}, s.handleGetWeatherReport)
+}
+
+func (s *Server) handleGetWeatherReport(
+	ctx context.Context,
+	_ *mcpsdk.CallToolRequest,
+	input getWeatherReportInput,
+) (*mcpsdk.CallToolResult, any, error) {
+	station := strings.ToUpper(strings.TrimSpace(input.Station))
+	if station != "" && !stationPattern.MatchString(station) {
+		return s.errorResult(fmt.Errorf("%w: station must be 2-4 letters or digits, e.g. KSEA", ErrInvalidInput)), nil, nil
+	}
+
+	env, err := s.state.GetEnvironment()
+	if err != nil {
+		return s.errorResult(err), nil, nil
+	}
+
+	resp := WeatherReportResponse{
+		Station:     station,
+		Synthesized: newDecodedMETAR(metar.FromEnvironment("", env)),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	raw, err := s.readObservation(ctx, station)
+	switch {
+	case ctx.Err() != nil:
+		return s.errorResult(ctx.Err()), nil, nil
+	case err != nil:
+		resp.ObservationNote = observationNote(err)
+	default:
+		report, err := metar.Parse(raw)
+		if err != nil {
+			resp.Observation = &DecodedMETARJSON{Raw: raw}
+			resp.ObservationNote = "The simulator's report could not be decoded."
+		} else {
+			decoded := newDecodedMETAR(report)
+			resp.Observation = &decoded
+		}
+	}
+	return s.jsonResult(resp)
+}
+
+// readObservation requests the METAR for station, or for the station nearest
+// the aircraft when station is empty, waiting at most the command timeout.
+func (s *Server) readObservation(ctx context.Context, station string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.commandTimeout)
+	defer cancel()
+	if station != "" {
+		return s.weather.StationObservation(ctx, station)
+	}
+	pos, err := s.state.GetPosition()
+	if err != nil {
+		return "", err
+	}
+	return s.weather.NearestObservation(ctx, pos.Latitude, pos.Longitude)
+}
+
+// observationNote explains a missing observation.
+func observationNote(err error) string {
+	switch {
+	case errors.Is(err, simconnect.ErrNoObservation):
+		return "The simulator has no observation for this station; use the synthesized report."
+	case errors.Is(err, context.DeadlineExceeded):
+		return "The simulator did not answer the observation request; use the synthesized report."
+	}
+	return fmt.Sprintf("No observation: %v. Use the synthesized report.", err)
+}
+
+// newDecodedMETAR converts a decoded report to its JSON form.
+func newDecodedMETAR(r metar.Report) DecodedMETARJSON {
+	out := DecodedMETARJSON{
+		Raw:            r.Raw,
+		Station:        r.Station,
+		CAVOK:          r.CAVOK,
+		Weather:        r.Weather,
+		SkyClear:       r.Clear,
+		TemperatureC:   r.Temp,
+		DewpointC:      r.Dewp,
+		FlightCategory: r.FlightCategory(),
+		Remarks:        r.Remarks,
+		Undecoded:      r.Unparsed,
+	}
+	if r.Day > 0 {
+		out.DayOfMonth = r.Day
+		out.TimeUTC = fmt.Sprintf("%02d:%02dZ", r.Hour, r.Minute)
+	}
+	if w := r.Wind; w != nil {
+		speed := w.Speed
+		out.WindSpeedKt = &speed
+		out.WindGustKt = w.Gust
+		out.WindVariable = w.Variable
+		if !w.Variable && !w.Calm() {
+			dir := w.Direction
+			out.WindDirectionTrueDeg = &dir
+		}
+		if w.VariableFrom != w.VariableTo {
+			out.WindVariableRangeDeg = []int{w.VariableFrom, w.VariableTo}
+		}
+	}
+	if v := r.Vis; v != nil {
+		sm := math.Round(v.StatuteMiles()*100) / 100
+		m := math.Round(v.Meters)
+		out.VisibilitySM, out.VisibilityM = &sm, &m
+		switch {
+		case v.AtLeast:
+			out.VisibilityQualifier = "at_least"
+		case v.Below:
+			out.VisibilityQualifier = "less_than"
+		}
+	}
+	for _, code := range r.Weather {
+		out.WeatherDescription = append(out.WeatherDescription, metar.DescribeWeather(code))
+	}
+	for _, c := range r.Clouds {
+		out.Clouds = append(out.Clouds, CloudLayerJSON{Cover: c.Cover, BaseFtAGL: c.Base, Type: c.Type})
+	}
+	if ceiling, ok := r.Ceiling(); ok {
+		out.CeilingFtAGL = &ceiling
+	}
+	if a := r.Alt; a != nil {
+		inHg, hPa := a.InHg, a.HPa
+		out.AltimeterInHg, out.AltimeterHPa = &inHg, &hPa
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	internalmcp "github.com/eytandecker/flightsim-mcp/internal/mcp"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+type fakeWeather struct {
+	metars  map[string]string // station → METAR; "" is the nearest station
+	err     error
+	nearest [][2]float64
+}
+
+func (f *fakeWeather) StationObservation(_ context.Context, icao string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	m, ok := f.metars[icao]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown station", simconnect.ErrNoObservation)
+	}
+	return m, nil
+}
+
+func (f *fakeWeather) NearestObservation(ctx context.Context, lat, lon float64) (string, error) {
+	f.nearest = append(f.nearest, [2]float64{lat, lon})
+	return f.StationObservation(ctx, "")
+}
+
+const synthesizedSample = "ZZZZ 141400Z AUTO 27015KT 6SM RA M06/ A2992 RMK SIM ACFT POSN"
+
+func TestGetWeatherReportStation(t *testing.T) {
+	w := &fakeWeather{metars: map[string]string{
+		"KSEA": "KSEA 141453Z 18012G20KT 160V220 2SM -RA BR FEW008 BKN015 14/12 A3001 RMK AO2",
+	}}
+	res := callTool(t, &mockStateGetter{env: sampleEnv}, "get_weather_report", map[string]any{"station": "ksea"},
+		internalmcp.WithWeatherReader(w))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, "KSEA", m["station"])
+	assert.NotContains(t, m, "observation_note")
+	assert.Equal(t, map[string]any{
+		"raw":                     "KSEA 141453Z 18012G20KT 160V220 2SM -RA BR FEW008 BKN015 14/12 A3001 RMK AO2",
+		"station":                 "KSEA",
+		"day_of_month":            14.0,
+		"time_utc":                "14:53Z",
+		"wind_direction_true_deg": 180.0,
+		"wind_variable_range_deg": []any{160.0, 220.0},
+		"wind_speed_kt":           12.0,
+		"wind_gust_kt":            20.0,
+		"visibility_sm":           2.0,
+		"visibility_m":            3219.0,
+		"weather":                 []any{"-RA", "BR"},
+		"weather_description":     []any{"light rain", "mist"},
+		"clouds": []any{
+			map[string]any{"cover": "FEW", "base_ft_agl": 800.0},
+			map[string]any{"cover": "BKN", "base_ft_agl": 1500.0},
+		},
+		"ceiling_ft_agl":  1500.0,
+		"temperature_c":   14.0,
+		"dewpoint_c":      12.0,
+		"altimeter_inhg":  30.01,
+		"altimeter_hpa":   1016.3,
+		"flight_category": "IFR",
+		"remarks":         "AO2",
+	}, m["observation"])
+
+	synth := m["synthesized"].(map[string]any)
+	assert.Equal(t, synthesizedSample, synth["raw"])
+	assert.Equal(t, 270.0, synth["wind_direction_true_deg"])
+	assert.Equal(t, -6.0, synth["temperature_c"])
+	assert.NotContains(t, synth, "dewpoint_c")
+	assert.Equal(t, "VFR", synth["flight_category"])
+}
+
+func TestGetWeatherReportNearest(t *testing.T) {
+	w := &fakeWeather{metars: map[string]string{"": "KBFI 141453Z 00000KT CAVOK 14/08 Q1017"}}
+	sg := &mockStateGetter{env: sampleEnv, pos: types.AircraftPosition{Latitude: 47.5, Longitude: -122.3}}
+	res := callTool(t, sg, "get_weather_report", nil, internalmcp.WithWeatherReader(w))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, [][2]float64{{47.5, -122.3}}, w.nearest)
+	assert.NotContains(t, m, "station")
+	obs := m["observation"].(map[string]any)
+	assert.Equal(t, "KBFI", obs["station"])
+	assert.Equal(t, 0.0, obs["wind_speed_kt"])
+	assert.NotContains(t, obs, "wind_direction_true_deg")
+	assert.Equal(t, true, obs["cavok"])
+	assert.Equal(t, "at_least", obs["visibility_qualifier"])
+	assert.Equal(t, "VFR", obs["flight_category"])
+}
+
+func TestGetWeatherReportWithoutObservation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantNote string
+	}{
+		{"no observation", nil, "The simulator has no observation for this station; use the synthesized report."},
+		{"no answer", context.DeadlineExceeded, "The simulator did not answer the observation request; use the synthesized report."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &fakeWeather{err: tt.err}
+			res := callTool(t, &mockStateGetter{env: sampleEnv}, "get_weather_report", map[string]any{"station": "EGLL"},
+				internalmcp.WithWeatherReader(w))
+			require.False(t, res.IsError)
+			m := parseJSON(t, res)
+
+			assert.Nil(t, m["observation"])
+			assert.Equal(t, tt.wantNote, m["observation_note"])
+			assert.Equal(t, synthesizedSample, m["synthesized"].(map[string]any)["raw"])
+		})
+	}
+}
+
+func TestGetWeatherReportUndecodable(t *testing.T) {
+	w := &fakeWeather{metars: map[string]string{"KSEA": "NO DATA"}}
+	res := callTool(t, &mockStateGetter{env: sampleEnv}, "get_weather_report", map[string]any{"station": "KSEA"},
+		internalmcp.WithWeatherReader(w))
+	require.False(t, res.IsError)
+	m := parseJSON(t, res)
+
+	assert.Equal(t, map[string]any{"raw": "NO DATA", "station": ""}, m["observation"])
+	assert.Equal(t, "The simulator's report could not be decoded.", m["observation_note"])
+}
+
+func TestGetWeatherReportErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		station  string
+		stateErr error
+		wantCode string
+	}{
+		{name: "bad station", station: "K-SEA", wantCode: "INVALID_INPUT"},
+		{name: "station too long", station: "KSEA12", wantCode: "INVALID_INPUT"},
+		{name: "not connected", station: "KSEA", stateErr: simconnect.ErrNotConnected, wantCode: "SIMULATOR_NOT_CONNECTED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := callTool(t, &mockStateGetter{err: tt.stateErr}, "get_weather_report", map[string]any{"station": tt.station},
+				internalmcp.WithWeatherReader(&fakeWeather{}))
+			require.True(t, res.IsError)
+			assert.Equal(t, tt.wantCode, parseJSON(t, res)["code"])
+		})
+	}
+}
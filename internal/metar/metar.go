@@ -0,0 +1,441 @@
+// Package metar decodes METAR weather observations and formats them, so
+// simulator weather can be briefed in the usual aviation shorthand.
+package metar
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalid is returned by Parse for text that is not a METAR.
+var ErrInvalid = errors.New("metar: invalid report")
+
+// Unit conversions used by METAR groups.
+const (
+	MetersPerStatuteMile = 1609.344
+	HPaPerInHg           = 33.8639
+	knotsPerMPS          = 1.943844
+	knotsPerKMH          = 0.539957
+)
+
+// Report is a decoded METAR. Groups that were not reported are nil or empty.
+type Report struct {
+	Raw     string
+	Station string
+	Day     int // day of month of the observation, 0 when not reported
+	Hour    int // UTC
+	Minute  int
+	Auto    bool // fully automated observation
+	Wind    *Wind
+	Vis     *Visibility
+	CAVOK   bool
+	Weather []string // present weather groups, e.g. -RA or BR
+	Clouds  []Cloud
+	Clear   bool // SKC, CLR, NSC or NCD: no cloud reported
+	Temp    *int // °C
+	Dewp    *int // °C
+	Alt     *Altimeter
+	Remarks string
+	// Unparsed holds groups that were not recognized, such as runway visual
+	// range and trend forecasts.
+	Unparsed []string
+}
+
+// Wind is a METAR wind group, converted to knots.
+type Wind struct {
+	Direction    int  // degrees true; 0 when calm or variable
+	Variable     bool // VRB
+	VariableFrom int  // limits of a dddVddd group, 0 when not reported
+	VariableTo   int
+	Speed        int // knots
+	Gust         int // knots, 0 when not reported
+}
+
+// Calm reports whether the wind is 00000KT.
+func (w Wind) Calm() bool {
+	return w.Speed == 0 && !w.Variable
+}
+
+// Visibility is the prevailing visibility.
+type Visibility struct {
+	Meters  float64
+	AtLeast bool // 9999, P6SM or CAVOK: the value is a lower bound
+	Below   bool // M1/4SM: the visibility is less than the value
+}
+
+// StatuteMiles returns the visibility in statute miles.
+func (v Visibility) StatuteMiles() float64 {
+	return v.Meters / MetersPerStatuteMile
+}
+
+// Cloud is a cloud layer or vertical visibility group.
+type Cloud struct {
+	Cover string // FEW, SCT, BKN, OVC or VV
+	Base  *int   // feet above ground, nil when not reported (///)
+	Type  string // CB or TCU, if reported
+}
+
+// Altimeter is the altimeter setting, reported in either hPa (Q) or inHg (A).
+type Altimeter struct {
+	HPa  float64
+	InHg float64
+}
+
+var (
+	stationRe = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+	timeRe    = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})Z$`)
+	windRe    = regexp.MustCompile(`^(\d{3}|VRB)(\d{2,3})(?:G(\d{2,3}))?(KT|MPS|KMH)$`)
+	varyRe    = regexp.MustCompile(`^(\d{3})V(\d{3})$`)
+	metersRe  = regexp.MustCompile(`^(\d{4})(?:NDV|[NSEW]{0,2})$`)
+	smRe      = regexp.MustCompile(`^([PM])?(?:(\d{1,2})|(\d)/(\d{1,2}))SM$`)
+	wholeRe   = regexp.MustCompile(`^\d$`)
+	weatherRe = regexp.MustCompile(`^(?:[+-]|VC)?(?:MI|PR|BC|DR|BL|SH|TS|FZ)?(?:DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS)*$`)
+	cloudRe   = regexp.MustCompile(`^(FEW|SCT|BKN|OVC|VV)(\d{3}|///)(CB|TCU)?$`)
+	tempRe    = regexp.MustCompile(`^(M?\d{2})/(M?\d{2})?$`)
+	altRe     = regexp.MustCompile(`^([AQ])(\d{4})$`)
+)
+
+// Parse decodes a METAR. A leading METAR or SPECI keyword is skipped, and
+// the FSX extensions that follow & in a group are ignored. Groups that are
+// not recognized are kept in Unparsed rather than failing the report.
+func Parse(raw string) (Report, error) {
+	r := Report{Raw: strings.TrimSpace(raw)}
+	var groups []string
+	for _, g := range strings.Fields(strings.ToUpper(r.Raw)) {
+		if i := strings.IndexByte(g, '&'); i >= 0 {
+			g = g[:i]
+		}
+		if g != "" {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) > 0 && (groups[0] == "METAR" || groups[0] == "SPECI") {
+		groups = groups[1:]
+	}
+	if len(groups) == 0 || !stationRe.MatchString(groups[0]) {
+		return Report{}, fmt.Errorf("%w: %q has no station", ErrInvalid, raw)
+	}
+	r.Station = groups[0]
+
+	for i := 1; i < len(groups); i++ {
+		g := groups[i]
+		if g == "RMK" {
+			r.Remarks = strings.Join(groups[i+1:], " ")
+			break
+		}
+		if g == "NOSIG" || g == "BECMG" || g == "TEMPO" {
+			// A trend forecast runs to the remarks; keep it undecoded.
+			end := slices.Index(groups[i:], "RMK")
+			if end < 0 {
+				r.Unparsed = append(r.Unparsed, groups[i:]...)
+				break
+			}
+			r.Unparsed = append(r.Unparsed, groups[i:i+end]...)
+			i += end - 1
+			continue
+		}
+		switch {
+		case timeRe.MatchString(g) && r.Day == 0:
+			m := timeRe.FindStringSubmatch(g)
+			r.Day, _ = strconv.Atoi(m[1])
+			r.Hour, _ = strconv.Atoi(m[2])
+			r.Minute, _ = strconv.Atoi(m[3])
+		case g == "AUTO":
+			r.Auto = true
+		case g == "COR" || g == "NIL":
+		case windRe.MatchString(g) && r.Wind == nil:
+			r.Wind = parseWind(windRe.FindStringSubmatch(g))
+		case varyRe.MatchString(g) && r.Wind != nil:
+			m := varyRe.FindStringSubmatch(g)
+			r.Wind.VariableFrom, _ = strconv.Atoi(m[1])
+			r.Wind.VariableTo, _ = strconv.Atoi(m[2])
+		case g == "CAVOK":
+			r.CAVOK = true
+			r.Vis = &Visibility{Meters: 10000, AtLeast: true}
+		case metersRe.MatchString(g) && r.Vis == nil:
+			m, _ := strconv.Atoi(g[:4])
+			r.Vis = &Visibility{Meters: float64(m), AtLeast: m == 9999}
+		case wholeRe.MatchString(g) && i+1 < len(groups) && smRe.MatchString(groups[i+1]) && r.Vis == nil:
+			// "1 1/2SM": a whole number followed by a fraction.
+			whole, _ := strconv.Atoi(g)
+			i++
+			v := parseStatuteMiles(smRe.FindStringSubmatch(groups[i]))
+			v.Meters += float64(whole) * MetersPerStatuteMile
+			r.Vis = &v
+		case smRe.MatchString(g) && r.Vis == nil:
+			v := parseStatuteMiles(smRe.FindStringSubmatch(g))
+			r.Vis = &v
+		case g == "SKC" || g == "CLR" || g == "NSC" || g == "NCD":
+			r.Clear = true
+		case cloudRe.MatchString(g):
+			m := cloudRe.FindStringSubmatch(g)
+			c := Cloud{Cover: m[1], Type: m[3]}
+			if h, err := strconv.Atoi(m[2]); err == nil {
+				base := h * 100
+				c.Base = &base
+			}
+			r.Clouds = append(r.Clouds, c)
+		case tempRe.MatchString(g) && r.Temp == nil:
+			m := tempRe.FindStringSubmatch(g)
+			r.Temp = parseTemp(m[1])
+			r.Dewp = parseTemp(m[2])
+		case altRe.MatchString(g) && r.Alt == nil:
+			m := altRe.FindStringSubmatch(g)
+			n, _ := strconv.Atoi(m[2])
+			if m[1] == "A" {
+				r.Alt = &Altimeter{InHg: float64(n) / 100, HPa: math.Round(float64(n)/100*HPaPerInHg*10) / 10}
+			} else {
+				r.Alt = &Altimeter{HPa: float64(n), InHg: math.Round(float64(n)/HPaPerInHg*100) / 100}
+			}
+		case g != "" && weatherRe.MatchString(g) && g != "+" && g != "-" && g != "VC":
+			r.Weather = append(r.Weather, g)
+		default:
+			r.Unparsed = append(r.Unparsed, g)
+		}
+	}
+	return r, nil
+}
+
+func parseWind(m []string) *Wind {
+	w := &Wind{Variable: m[1] == "VRB"}
+	if !w.Variable {
+		w.Direction, _ = strconv.Atoi(m[1])
+	}
+	speed, _ := strconv.Atoi(m[2])
+	gust, _ := strconv.Atoi(m[3])
+	factor := 1.0
+	switch m[4] {
+	case "MPS":
+		factor = knotsPerMPS
+	case "KMH":
+		factor = knotsPerKMH
+	}
+	w.Speed = int(math.Round(float64(speed) * factor))
+	w.Gust = int(math.Round(float64(gust) * factor))
+	return w
+}
+
+func parseStatuteMiles(m []string) Visibility {
+	var miles float64
+	if m[2] != "" {
+		n, _ := strconv.Atoi(m[2])
+		miles = float64(n)
+	} else {
+		num, _ := strconv.Atoi(m[3])
+		den, _ := strconv.Atoi(m[4])
+		if den != 0 {
+			miles = float64(num) / float64(den)
+		}
+	}
+	return Visibility{Meters: miles * MetersPerStatuteMile, AtLeast: m[1] == "P", Below: m[1] == "M"}
+}
+
+func parseTemp(s string) *int {
+	if s == "" {
+		return nil
+	}
+	n, _ := strconv.Atoi(strings.TrimPrefix(s, "M"))
+	if strings.HasPrefix(s, "M") {
+		n = -n
+	}
+	return &n
+}
+
+// Ceiling returns the height of the lowest broken, overcast or vertical
+// visibility layer in feet, and false when there is none.
+func (r Report) Ceiling() (int, bool) {
+	ceiling, ok := 0, false
+	for _, c := range r.Clouds {
+		if c.Base == nil || (c.Cover != "BKN" && c.Cover != "OVC" && c.Cover != "VV") {
+			continue
+		}
+		if !ok || *c.Base < ceiling {
+			ceiling, ok = *c.Base, true
+		}
+	}
+	return ceiling, ok
+}
+
+// FlightCategory returns the FAA flight category the ceiling and visibility
+// fall in: VFR, MVFR, IFR or LIFR. It returns "" when neither is reported.
+func (r Report) FlightCategory() string {
+	ceiling, hasCeiling := r.Ceiling()
+	if r.Vis == nil && !hasCeiling && !r.Clear && !r.CAVOK && len(r.Clouds) == 0 {
+		return ""
+	}
+	if !hasCeiling {
+		ceiling = math.MaxInt
+	}
+	miles := math.Inf(1)
+	if r.Vis != nil {
+		miles = r.Vis.StatuteMiles()
+	}
+	switch {
+	case ceiling < 500 || miles < 1:
+		return "LIFR"
+	case ceiling < 1000 || miles < 3:
+		return "IFR"
+	case ceiling <= 3000 || miles <= 5:
+		return "MVFR"
+	}
+	return "VFR"
+}
+
+// String formats the decoded groups of r as a METAR in US style, with
+// visibility in statute miles and the altimeter in inHg.
+func (r Report) String() string {
+	parts := []string{r.Station}
+	if r.Day > 0 {
+		parts = append(parts, fmt.Sprintf("%02d%02d%02dZ", r.Day, r.Hour, r.Minute))
+	}
+	if r.Auto {
+		parts = append(parts, "AUTO")
+	}
+	if w := r.Wind; w != nil {
+		dir := fmt.Sprintf("%03d", w.Direction)
+		if w.Variable {
+			dir = "VRB"
+		}
+		wind := fmt.Sprintf("%s%02d", dir, w.Speed)
+		if w.Gust > 0 {
+			wind += fmt.Sprintf("G%02d", w.Gust)
+		}
+		parts = append(parts, wind+"KT")
+		if w.VariableFrom != w.VariableTo {
+			parts = append(parts, fmt.Sprintf("%03dV%03d", w.VariableFrom, w.VariableTo))
+		}
+	}
+	if r.Vis != nil {
+		parts = append(parts, formatStatuteMiles(*r.Vis))
+	}
+	parts = append(parts, r.Weather...)
+	if r.Clear {
+		parts = append(parts, "CLR")
+	}
+	for _, c := range r.Clouds {
+		base := "///"
+		if c.Base != nil {
+			base = fmt.Sprintf("%03d", *c.Base/100)
+		}
+		parts = append(parts, c.Cover+base+c.Type)
+	}
+	if r.Temp != nil {
+		group := formatTemp(*r.Temp) + "/"
+		if r.Dewp != nil {
+			group += formatTemp(*r.Dewp)
+		}
+		parts = append(parts, group)
+	}
+	if r.Alt != nil {
+		parts = append(parts, fmt.Sprintf("A%04d", int(math.Round(r.Alt.InHg*100))))
+	}
+	if r.Remarks != "" {
+		parts = append(parts, "RMK", r.Remarks)
+	}
+	return strings.Join(parts, " ")
+}
+
+// smSteps are the statute-mile visibilities reported below 3 miles. Reports
+// round down to the next step.
+var smSteps = []struct {
+	miles float64
+	text  string
+}{
+	{2.5, "2 1/2"}, {2, "2"}, {1.75, "1 3/4"}, {1.5, "1 1/2"}, {1.25, "1 1/4"},
+	{1, "1"}, {0.75, "3/4"}, {0.5, "1/2"}, {0.25, "1/4"},
+}
+
+func formatStatuteMiles(v Visibility) string {
+	if v.Below {
+		s := formatStatuteMiles(Visibility{Meters: v.Meters})
+		if !strings.HasPrefix(s, "M") {
+			s = "M" + s
+		}
+		return s
+	}
+	miles := v.StatuteMiles() + 1e-6
+	switch {
+	case miles >= 10 || (v.AtLeast && miles >= 6):
+		return "10SM"
+	case miles >= 3:
+		return fmt.Sprintf("%dSM", int(miles))
+	}
+	for _, s := range smSteps {
+		if miles >= s.miles {
+			return s.text + "SM"
+		}
+	}
+	return "M1/4SM"
+}
+
+func formatTemp(c int) string {
+	if c < 0 {
+		return fmt.Sprintf("M%02d", -c)
+	}
+	return fmt.Sprintf("%02d", c)
+}
+
+var (
+	weatherDescriptors = map[string]string{
+		"MI": "shallow", "PR": "partial", "BC": "patches of", "DR": "low drifting",
+		"BL": "blowing", "SH": "showers of", "TS": "thunderstorm with", "FZ": "freezing",
+	}
+	weatherPhenomena = map[string]string{
+		"DZ": "drizzle", "RA": "rain", "SN": "snow", "SG": "snow grains", "IC": "ice crystals",
+		"PL": "ice pellets", "GR": "hail", "GS": "small hail", "UP": "unknown precipitation",
+		"BR": "mist", "FG": "fog", "FU": "smoke", "VA": "volcanic ash", "DU": "dust",
+		"SA": "sand", "HZ": "haze", "PY": "spray", "PO": "dust whirls", "SQ": "squalls",
+		"FC": "funnel cloud", "SS": "sandstorm", "DS": "duststorm",
+	}
+)
+
+// DescribeWeather spells out a present weather group, e.g. -SHRA as
+// "light showers of rain". Groups it cannot decode are returned unchanged.
+func DescribeWeather(code string) string {
+	rest := code
+	var words []string
+	vicinity := false
+	switch {
+	case strings.HasPrefix(rest, "-"):
+		words, rest = append(words, "light"), rest[1:]
+	case strings.HasPrefix(rest, "+"):
+		words, rest = append(words, "heavy"), rest[1:]
+	case strings.HasPrefix(rest, "VC"):
+		vicinity, rest = true, rest[2:]
+	}
+	descriptor := ""
+	if len(rest) >= 2 {
+		if d, ok := weatherDescriptors[rest[:2]]; ok {
+			descriptor, rest = d, rest[2:]
+		}
+	}
+	var phenomena []string
+	for ; len(rest) >= 2; rest = rest[2:] {
+		p, ok := weatherPhenomena[rest[:2]]
+		if !ok {
+			return code
+		}
+		phenomena = append(phenomena, p)
+	}
+	if rest != "" || (descriptor == "" && len(phenomena) == 0) {
+		return code
+	}
+	if descriptor != "" {
+		if len(phenomena) == 0 {
+			descriptor = strings.TrimSuffix(strings.TrimSuffix(descriptor, " of"), " with")
+		}
+		words = append(words, descriptor)
+	}
+	if len(phenomena) > 0 {
+		words = append(words, strings.Join(phenomena, " and "))
+	}
+	if vicinity {
+		words = append(words, "in the vicinity")
+	}
+	return strings.Join(words, " ")
+}
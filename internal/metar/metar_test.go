@@ -0,0 +1,198 @@
+package metar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func intp(n int) *int { return &n }
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want Report
+	}{
+		{
+			name: "US report",
+			raw:  "METAR KSEA 141453Z 18012G20KT 160V220 1 1/2SM -RA BR FEW008 BKN015 OVC030 14/M02 A3001 RMK AO2 SLP165",
+			want: Report{
+				Station: "KSEA", Day: 14, Hour: 14, Minute: 53,
+				Wind:    &Wind{Direction: 180, VariableFrom: 160, VariableTo: 220, Speed: 12, Gust: 20},
+				Vis:     &Visibility{Meters: 1.5 * MetersPerStatuteMile},
+				Weather: []string{"-RA", "BR"},
+				Clouds: []Cloud{
+					{Cover: "FEW", Base: intp(800)},
+					{Cover: "BKN", Base: intp(1500)},
+					{Cover: "OVC", Base: intp(3000)},
+				},
+				Temp: intp(14), Dewp: intp(-2),
+				Alt:     &Altimeter{InHg: 30.01, HPa: 1016.3},
+				Remarks: "AO2 SLP165",
+			},
+		},
+		{
+			name: "ICAO report with trend",
+			raw:  "EGLL 141450Z AUTO VRB03MPS 9999 SCT040CB 09/ Q1017 NOSIG",
+			want: Report{
+				Station: "EGLL", Day: 14, Hour: 14, Minute: 50, Auto: true,
+				Wind:     &Wind{Variable: true, Speed: 6},
+				Vis:      &Visibility{Meters: 9999, AtLeast: true},
+				Clouds:   []Cloud{{Cover: "SCT", Base: intp(4000), Type: "CB"}},
+				Temp:     intp(9),
+				Alt:      &Altimeter{HPa: 1017, InHg: 30.03},
+				Unparsed: []string{"NOSIG"},
+			},
+		},
+		{
+			name: "CAVOK",
+			raw:  "LFPG 141430Z 00000KT CAVOK M05/M08 Q1030",
+			want: Report{
+				Station: "LFPG", Day: 14, Hour: 14, Minute: 30,
+				Wind:  &Wind{},
+				Vis:   &Visibility{Meters: 10000, AtLeast: true},
+				CAVOK: true,
+				Temp:  intp(-5), Dewp: intp(-8),
+				Alt: &Altimeter{HPa: 1030, InHg: 30.42},
+			},
+		},
+		{
+			name: "FSX extensions and vertical visibility",
+			raw:  "KBFI 141453Z 27005KT&D980NG 1/4SM&B-488&D3048 FG VV002&ST000FNVN000N 05/05&A0 A2992 R16/0600FT",
+			want: Report{
+				Station: "KBFI", Day: 14, Hour: 14, Minute: 53,
+				Wind:    &Wind{Direction: 270, Speed: 5},
+				Vis:     &Visibility{Meters: 0.25 * MetersPerStatuteMile},
+				Weather: []string{"FG"},
+				Clouds:  []Cloud{{Cover: "VV", Base: intp(200)}},
+				Temp:    intp(5), Dewp: intp(5),
+				Alt:      &Altimeter{InHg: 29.92, HPa: 1013.2},
+				Unparsed: []string{"R16/0600FT"},
+			},
+		},
+		{
+			name: "clear skies and greater-than visibility",
+			raw:  "KPHX 141451Z 09004KT P6SM CLR 30/02 A2990",
+			want: Report{
+				Station: "KPHX", Day: 14, Hour: 14, Minute: 51,
+				Wind:  &Wind{Direction: 90, Speed: 4},
+				Vis:   &Visibility{Meters: 6 * MetersPerStatuteMile, AtLeast: true},
+				Clear: true,
+				Temp:  intp(30), Dewp: intp(2),
+				Alt: &Altimeter{InHg: 29.90, HPa: 1012.5},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.raw)
+			require.NoError(t, err)
+			tt.want.Raw = tt.raw
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, raw := range []string{"", "METAR", "12345 141453Z"} {
+		_, err := Parse(raw)
+		assert.ErrorIs(t, err, ErrInvalid, raw)
+	}
+}
+
+func TestFlightCategory(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"KSEA 10SM FEW040 BKN250", "VFR"},
+		{"KSEA 10SM BKN030", "MVFR"},
+		{"KSEA 4SM OVC040", "MVFR"},
+		{"KSEA 2SM OVC040", "IFR"},
+		{"KSEA 10SM OVC008", "IFR"},
+		{"KSEA 1/2SM FG VV002", "LIFR"},
+		{"EGLL CAVOK", "VFR"},
+		{"KSEA 18005KT", ""},
+	}
+	for _, tt := range tests {
+		r, err := Parse(tt.raw)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, r.FlightCategory(), tt.raw)
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	for _, raw := range []string{
+		"KSEA 141453Z 18012G20KT 160V220 1 1/2SM -RA BR FEW008 BKN015 OVC030 14/M02 A3001 RMK AO2 SLP165",
+		"KPHX 141451Z 09004KT 10SM CLR 30/02 A2990",
+		"KBFI 020305Z AUTO 00000KT M1/4SM FG VV/// 05/ A2992",
+	} {
+		r, err := Parse(raw)
+		require.NoError(t, err)
+		assert.Equal(t, raw, r.String())
+	}
+}
+
+func TestFromEnvironment(t *testing.T) {
+	tests := []struct {
+		name string
+		env  types.Environment
+		want string
+	}{
+		{
+			name: "rain",
+			env: types.Environment{
+				WindVelocity: 12.4, WindDirection: 184, Temperature: 14.4, Visibility: 8000,
+				PrecipState: 4, ZuluTime: 53580, ZuluDayOfMonth: 14, SeaLevelPressure: 1016.2,
+			},
+			want: "ZZZZ 141453Z AUTO 18012KT 4SM RA 14/ A3001 RMK SIM ACFT POSN",
+		},
+		{
+			name: "calm and fog",
+			env:  types.Environment{Temperature: -2.6, Visibility: 400, PrecipState: 2, ZuluDayOfMonth: 3, ZuluTime: 300},
+			want: "ZZZZ 030005Z AUTO 00000KT M1/4SM FG M03/ RMK SIM ACFT POSN",
+		},
+		{
+			name: "north wind, no date, mist",
+			env:  types.Environment{WindVelocity: 5, WindDirection: 356, Temperature: 20, Visibility: 3000, SeaLevelPressure: 1013.25},
+			want: "ZZZZ AUTO 36005KT 1 3/4SM BR 20/ A2992 RMK SIM ACFT POSN",
+		},
+		{
+			name: "unlimited visibility and snow",
+			env:  types.Environment{WindVelocity: 20, WindDirection: 4, Temperature: -10, Visibility: 60000, PrecipState: 8},
+			want: "ZZZZ AUTO 36020KT 10SM SN M10/ RMK SIM ACFT POSN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := FromEnvironment("", tt.env)
+			assert.Equal(t, tt.want, r.Raw)
+
+			parsed, err := Parse(r.Raw)
+			require.NoError(t, err)
+			assert.Equal(t, r.Wind, parsed.Wind)
+			assert.Equal(t, r.Temp, parsed.Temp)
+		})
+	}
+}
+
+func TestDescribeWeather(t *testing.T) {
+	tests := map[string]string{
+		"-RA":    "light rain",
+		"+SHRA":  "heavy showers of rain",
+		"TSRAGR": "thunderstorm with rain and hail",
+		"TS":     "thunderstorm",
+		"VCSH":   "showers in the vicinity",
+		"FZFG":   "freezing fog",
+		"BR":     "mist",
+		"XX":     "XX",
+		"-":      "-",
+	}
+	for code, want := range tests {
+		assert.Equal(t, want, DescribeWeather(code), code)
+	}
+}
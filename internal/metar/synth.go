@@ -0,0 +1,72 @@
+package metar
+
+import (
+	"math"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// UnknownStation is the ICAO placeholder used for observations that do not
+// belong to a station.
+const UnknownStation = "ZZZZ"
+
+// SynthesizedRemark marks reports built by FromEnvironment.
+const SynthesizedRemark = "SIM ACFT POSN"
+
+// Precipitation bits of AMBIENT PRECIP STATE.
+const (
+	precipRain = 4
+	precipSnow = 8
+)
+
+// FromEnvironment builds a report from the weather at the aircraft. The
+// simulator gives no cloud cover or dewpoint, so the report has no sky
+// condition and a missing dewpoint. Mist or fog is inferred from low
+// visibility without precipitation.
+func FromEnvironment(station string, env types.Environment) Report {
+	if station == "" {
+		station = UnknownStation
+	}
+	r := Report{Station: station, Auto: true, Remarks: SynthesizedRemark}
+
+	if day := int(env.ZuluDayOfMonth); day >= 1 && day <= 31 {
+		secs := int(env.ZuluTime) % 86400
+		r.Day, r.Hour, r.Minute = day, secs/3600, secs%3600/60
+	}
+
+	wind := &Wind{Speed: int(math.Round(env.WindVelocity))}
+	if wind.Speed > 0 {
+		wind.Direction = int(math.Round(math.Mod(env.WindDirection, 360)/10)) * 10
+		if wind.Direction <= 0 {
+			wind.Direction += 360
+		}
+	}
+	r.Wind = wind
+
+	r.Vis = &Visibility{Meters: math.Max(0, env.Visibility)}
+
+	precip := int(env.PrecipState)
+	switch {
+	case precip&precipSnow != 0:
+		r.Weather = append(r.Weather, "SN")
+	case precip&precipRain != 0:
+		r.Weather = append(r.Weather, "RA")
+	case env.Visibility < 1000:
+		r.Weather = append(r.Weather, "FG")
+	case env.Visibility < 5000:
+		r.Weather = append(r.Weather, "BR")
+	}
+
+	temp := int(math.Round(env.Temperature))
+	r.Temp = &temp
+
+	if env.SeaLevelPressure > 0 {
+		r.Alt = &Altimeter{
+			HPa:  math.Round(env.SeaLevelPressure*10) / 10,
+			InHg: math.Round(env.SeaLevelPressure/HPaPerInHg*100) / 100,
+		}
+	}
+
+	r.Raw = r.String()
+	return r
+}
@@ -0,0 +1,504 @@
+// Package pln reads and writes MSFS .PLN flight plan files, the AceXML
+// documents the simulator's world map and the GPS load and save.
+package pln
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/eytandecker/flightsim-mcp/internal/geo"
+)
+
+var (
+	// ErrInvalid is returned by Parse for data that is not a .PLN flight plan.
+	ErrInvalid = errors.New("pln: invalid flight plan file")
+	// ErrInvalidPlan is returned by Validate for a plan the simulator cannot fly.
+	ErrInvalidPlan = errors.New("pln: invalid flight plan")
+)
+
+// Flight rules (FPType).
+const (
+	IFR = "IFR"
+	VFR = "VFR"
+)
+
+// Waypoint types (ATCWaypointType).
+const (
+	TypeAirport      = "Airport"
+	TypeIntersection = "Intersection"
+	TypeVOR          = "VOR"
+	TypeNDB          = "NDB"
+	TypeUser         = "User"
+)
+
+// MaxCruisingAltitudeFt is the highest cruising altitude Validate accepts.
+const MaxCruisingAltitudeFt = 60000
+
+// FlightPlan is a decoded .PLN flight plan. Elements the package does not
+// model, such as speed and altitude constraints, are dropped.
+type FlightPlan struct {
+	Title              string
+	Description        string
+	Type               string // IFR or VFR
+	RouteType          string // HighAlt, LowAlt, VOR or Direct
+	CruisingAltitudeFt float64
+	Departure          Airport
+	Destination        Airport
+	// DeparturePosition is the runway, e.g. 16L, or parking spot the flight
+	// starts from.
+	DeparturePosition string
+	// Waypoints run from the departure airport to the destination airport.
+	Waypoints []Waypoint
+}
+
+// Airport is the departure or destination of a plan.
+type Airport struct {
+	ID       string
+	Name     string
+	Position Position
+}
+
+// Position is a point in a .PLN file.
+type Position struct {
+	Lat, Lon   float64 // degrees
+	AltitudeFt float64 // MSL
+}
+
+// Waypoint is an ATCWaypoint of a plan.
+type Waypoint struct {
+	ID       string
+	Type     string // one of the Type constants
+	Position Position
+	// Airway is the airway flown on the leg that ends at this waypoint.
+	Airway string
+	Region string // ICAO region, e.g. K1
+	// Airport is the airport a terminal waypoint belongs to.
+	Airport string
+
+	// Procedures, set on the departure and destination waypoints.
+	SID              string // DepartureFP
+	STAR             string // ArrivalFP
+	Approach         string // ApproachTypeFP, e.g. ILS or RNAV
+	RunwayNumber     string // RunwayNumberFP, e.g. 16
+	RunwayDesignator string // RunwayDesignatorFP, e.g. LEFT
+}
+
+// Runway returns the runway of a departure or destination waypoint, e.g.
+// 16L, or "".
+func (wp *Waypoint) Runway() string {
+	if wp.RunwayNumber == "" {
+		return ""
+	}
+	switch wp.RunwayDesignator {
+	case "LEFT":
+		return wp.RunwayNumber + "L"
+	case "RIGHT":
+		return wp.RunwayNumber + "R"
+	case "CENTER":
+		return wp.RunwayNumber + "C"
+	}
+	return wp.RunwayNumber
+}
+
+// SID returns the standard instrument departure of the plan, or "".
+func (fp *FlightPlan) SID() string {
+	for i := range fp.Waypoints {
+		if fp.Waypoints[i].SID != "" {
+			return fp.Waypoints[i].SID
+		}
+	}
+	return ""
+}
+
+// STAR returns the standard terminal arrival of the plan, or "".
+func (fp *FlightPlan) STAR() string {
+	for i := range fp.Waypoints {
+		if fp.Waypoints[i].STAR != "" {
+			return fp.Waypoints[i].STAR
+		}
+	}
+	return ""
+}
+
+// Approach returns the approach type of the plan, or "".
+func (fp *FlightPlan) Approach() string {
+	for i := range fp.Waypoints {
+		if fp.Waypoints[i].Approach != "" {
+			return fp.Waypoints[i].Approach
+		}
+	}
+	return ""
+}
+
+// DistanceNM returns the length of the route through all waypoints.
+func (fp *FlightPlan) DistanceNM() float64 {
+	var total float64
+	for i := 1; i < len(fp.Waypoints); i++ {
+		a, b := fp.Waypoints[i-1].Position, fp.Waypoints[i].Position
+		total += geo.DistanceNM(a.Lat, a.Lon, b.Lat, b.Lon)
+	}
+	return total
+}
+
+// Route returns the plan in ICAO route notation, e.g.
+// "KSEA SUMMA2 SUMMA J1 BTG KPDX": consecutive waypoints on one airway are
+// collapsed into the airway and its exit fix, and direct legs are implied.
+func (fp *FlightPlan) Route() string {
+	var parts []string
+	wps := fp.Waypoints
+	for i := range wps {
+		wp := &wps[i]
+		last := i == len(wps)-1
+		if last && i > 0 {
+			if star := fp.STAR(); star != "" {
+				parts = append(parts, star)
+			}
+		}
+		if i > 0 && wp.Airway != "" {
+			if !last && wps[i+1].Airway == wp.Airway {
+				continue
+			}
+			parts = append(parts, wp.Airway)
+		}
+		parts = append(parts, wp.ID)
+		if i == 0 {
+			if sid := fp.SID(); sid != "" {
+				parts = append(parts, sid)
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Problems lists the reasons the simulator could not fly the plan; it is
+// empty for a valid plan.
+func (fp *FlightPlan) Problems() []string {
+	var problems []string
+	if fp.Type != IFR && fp.Type != VFR {
+		problems = append(problems, fmt.Sprintf("flight rules %q must be IFR or VFR", fp.Type))
+	}
+	if fp.CruisingAltitudeFt < 0 || fp.CruisingAltitudeFt > MaxCruisingAltitudeFt {
+		problems = append(problems, fmt.Sprintf("cruising altitude %.0f ft must be 0-%d", fp.CruisingAltitudeFt, MaxCruisingAltitudeFt))
+	}
+	if fp.Departure.ID == "" {
+		problems = append(problems, "departure airport is missing")
+	}
+	if fp.Destination.ID == "" {
+		problems = append(problems, "destination airport is missing")
+	}
+	if len(fp.Waypoints) < 2 {
+		return append(problems, fmt.Sprintf("plan has %d waypoints, need at least the departure and destination", len(fp.Waypoints)))
+	}
+	if first := fp.Waypoints[0]; fp.Departure.ID != "" && first.ID != fp.Departure.ID {
+		problems = append(problems, fmt.Sprintf("first waypoint %s is not the departure airport %s", first.ID, fp.Departure.ID))
+	}
+	if last := fp.Waypoints[len(fp.Waypoints)-1]; fp.Destination.ID != "" && last.ID != fp.Destination.ID {
+		problems = append(problems, fmt.Sprintf("last waypoint %s is not the destination airport %s", last.ID, fp.Destination.ID))
+	}
+	for i := range fp.Waypoints {
+		wp := &fp.Waypoints[i]
+		switch {
+		case wp.ID == "":
+			problems = append(problems, fmt.Sprintf("waypoint %d has no ident", i+1))
+		case !validType(wp.Type):
+			problems = append(problems, fmt.Sprintf("waypoint %s has unknown type %q", wp.ID, wp.Type))
+		}
+		if !validPosition(wp.Position) {
+			problems = append(problems, fmt.Sprintf("waypoint %s has no valid position", wp.ID))
+		}
+	}
+	return problems
+}
+
+// Validate returns an error matching ErrInvalidPlan that lists Problems, or
+// nil for a valid plan.
+func (fp *FlightPlan) Validate() error {
+	if problems := fp.Problems(); len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidPlan, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validType(t string) bool {
+	switch t {
+	case TypeAirport, TypeIntersection, TypeVOR, TypeNDB, TypeUser:
+		return true
+	}
+	return false
+}
+
+// validPosition rejects out-of-range coordinates and the null island an
+// omitted WorldPosition decodes to.
+func validPosition(p Position) bool {
+	return math.Abs(p.Lat) <= 90 && math.Abs(p.Lon) <= 180 && (p.Lat != 0 || p.Lon != 0)
+}
+
+// --- XML ---
+
+type document struct {
+	XMLName xml.Name `xml:"SimBase.Document"`
+	Plan    *plan    `xml:"FlightPlan.FlightPlan"`
+}
+
+type plan struct {
+	Title             string     `xml:"Title"`
+	FPType            string     `xml:"FPType"`
+	RouteType         string     `xml:"RouteType"`
+	CruisingAlt       string     `xml:"CruisingAlt"`
+	DepartureID       string     `xml:"DepartureID"`
+	DepartureLLA      string     `xml:"DepartureLLA"`
+	DestinationID     string     `xml:"DestinationID"`
+	DestinationLLA    string     `xml:"DestinationLLA"`
+	Descr             string     `xml:"Descr"`
+	DeparturePosition string     `xml:"DeparturePosition"`
+	DepartureName     string     `xml:"DepartureName"`
+	DestinationName   string     `xml:"DestinationName"`
+	Waypoints         []waypoint `xml:"ATCWaypoint"`
+}
+
+type waypoint struct {
+	ID                 string `xml:"id,attr"`
+	Type               string `xml:"ATCWaypointType"`
+	WorldPosition      string `xml:"WorldPosition"`
+	Airway             string `xml:"ATCAirway"`
+	DepartureFP        string `xml:"DepartureFP"`
+	ArrivalFP          string `xml:"ArrivalFP"`
+	ApproachTypeFP     string `xml:"ApproachTypeFP"`
+	RunwayNumberFP     string `xml:"RunwayNumberFP"`
+	RunwayDesignatorFP string `xml:"RunwayDesignatorFP"`
+	ICAO               struct {
+		Region  string `xml:"ICAORegion"`
+		Ident   string `xml:"ICAOIdent"`
+		Airport string `xml:"ICAOAirport"`
+	} `xml:"ICAO"`
+}
+
+// utf8BOM is written by some flight planners and rejected by encoding/xml.
+var utf8BOM = []byte{0xef, 0xbb, 0xbf}
+
+// Parse decodes a .PLN file. It checks only that the file is well formed;
+// use Validate to check that the plan can be flown.
+func Parse(data []byte) (FlightPlan, error) {
+	var doc document
+	if err := xml.Unmarshal(bytes.TrimPrefix(data, utf8BOM), &doc); err != nil {
+		return FlightPlan{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+	if doc.Plan == nil {
+		return FlightPlan{}, fmt.Errorf("%w: no FlightPlan.FlightPlan element", ErrInvalid)
+	}
+	p := doc.Plan
+
+	fp := FlightPlan{
+		Title:             strings.TrimSpace(p.Title),
+		Description:       strings.TrimSpace(p.Descr),
+		Type:              strings.ToUpper(strings.TrimSpace(p.FPType)),
+		RouteType:         strings.TrimSpace(p.RouteType),
+		Departure:         Airport{ID: strings.TrimSpace(p.DepartureID), Name: strings.TrimSpace(p.DepartureName)},
+		Destination:       Airport{ID: strings.TrimSpace(p.DestinationID), Name: strings.TrimSpace(p.DestinationName)},
+		DeparturePosition: strings.TrimSpace(p.DeparturePosition),
+	}
+	var err error
+	if s := strings.TrimSpace(p.CruisingAlt); s != "" {
+		if fp.CruisingAltitudeFt, err = strconv.ParseFloat(s, 64); err != nil {
+			return FlightPlan{}, fmt.Errorf("%w: CruisingAlt %q is not a number", ErrInvalid, s)
+		}
+	}
+	if fp.Departure.Position, err = parseOptionalLLA(p.DepartureLLA); err != nil {
+		return FlightPlan{}, fmt.Errorf("%w: DepartureLLA: %w", ErrInvalid, err)
+	}
+	if fp.Destination.Position, err = parseOptionalLLA(p.DestinationLLA); err != nil {
+		return FlightPlan{}, fmt.Errorf("%w: DestinationLLA: %w", ErrInvalid, err)
+	}
+
+	for i := range p.Waypoints {
+		w := &p.Waypoints[i]
+		wp := Waypoint{
+			ID:               strings.TrimSpace(w.ID),
+			Type:             strings.TrimSpace(w.Type),
+			Airway:           strings.TrimSpace(w.Airway),
+			Region:           strings.TrimSpace(w.ICAO.Region),
+			Airport:          strings.TrimSpace(w.ICAO.Airport),
+			SID:              strings.TrimSpace(w.DepartureFP),
+			STAR:             strings.TrimSpace(w.ArrivalFP),
+			Approach:         strings.TrimSpace(w.ApproachTypeFP),
+			RunwayNumber:     strings.TrimSpace(w.RunwayNumberFP),
+			RunwayDesignator: strings.TrimSpace(w.RunwayDesignatorFP),
+		}
+		if wp.ID == "" {
+			wp.ID = strings.TrimSpace(w.ICAO.Ident)
+		}
+		if wp.Position, err = parseOptionalLLA(w.WorldPosition); err != nil {
+			return FlightPlan{}, fmt.Errorf("%w: waypoint %d (%s) WorldPosition: %w", ErrInvalid, i+1, wp.ID, err)
+		}
+		fp.Waypoints = append(fp.Waypoints, wp)
+	}
+	return fp, nil
+}
+
+// Marshal encodes a plan as a .PLN file in the layout the simulator writes.
+func Marshal(fp *FlightPlan) []byte {
+	var w planWriter
+	w.raw(`<?xml version="1.0" encoding="UTF-8"?>`)
+	w.raw(`<SimBase.Document Type="AceXML" version="1,0">`)
+	w.depth++
+	w.element("Descr", "AceXML Document")
+	w.raw("<FlightPlan.FlightPlan>")
+	w.depth++
+	w.element("Title", fp.Title)
+	w.element("FPType", fp.Type)
+	w.optional("RouteType", fp.RouteType)
+	w.element("CruisingAlt", strconv.FormatFloat(fp.CruisingAltitudeFt, 'f', -1, 64))
+	w.element("DepartureID", fp.Departure.ID)
+	w.element("DepartureLLA", FormatLLA(fp.Departure.Position))
+	w.element("DestinationID", fp.Destination.ID)
+	w.element("DestinationLLA", FormatLLA(fp.Destination.Position))
+	w.element("Descr", fp.Description)
+	w.optional("DeparturePosition", fp.DeparturePosition)
+	w.optional("DepartureName", fp.Departure.Name)
+	w.optional("DestinationName", fp.Destination.Name)
+	for i := range fp.Waypoints {
+		wp := &fp.Waypoints[i]
+		w.raw(`<ATCWaypoint id="` + attrEscaper.Replace(wp.ID) + `">`)
+		w.depth++
+		w.element("ATCWaypointType", wp.Type)
+		w.element("WorldPosition", FormatLLA(wp.Position))
+		w.optional("ATCAirway", wp.Airway)
+		w.optional("DepartureFP", wp.SID)
+		w.optional("ArrivalFP", wp.STAR)
+		w.optional("ApproachTypeFP", wp.Approach)
+		w.optional("RunwayNumberFP", wp.RunwayNumber)
+		w.optional("RunwayDesignatorFP", wp.RunwayDesignator)
+		w.raw("<ICAO>")
+		w.depth++
+		w.optional("ICAORegion", wp.Region)
+		w.element("ICAOIdent", wp.ID)
+		w.optional("ICAOAirport", wp.Airport)
+		w.depth--
+		w.raw("</ICAO>")
+		w.depth--
+		w.raw("</ATCWaypoint>")
+	}
+	w.depth--
+	w.raw("</FlightPlan.FlightPlan>")
+	w.depth--
+	w.raw("</SimBase.Document>")
+	return w.buf.Bytes()
+}
+
+// planWriter writes indented XML by hand: encoding/xml escapes the quotes
+// in WorldPosition values, which the simulator's own files never do.
+type planWriter struct {
+	buf   bytes.Buffer
+	depth int
+}
+
+func (w *planWriter) raw(line string) {
+	w.buf.WriteString(strings.Repeat("    ", w.depth))
+	w.buf.WriteString(line)
+	w.buf.WriteString("\n")
+}
+
+func (w *planWriter) element(name, value string) {
+	w.raw("<" + name + ">" + textEscaper.Replace(value) + "</" + name + ">")
+}
+
+func (w *planWriter) optional(name, value string) {
+	if value != "" {
+		w.element(name, value)
+	}
+}
+
+// Element content needs no quotes escaped, so WorldPosition is written as
+// the simulator writes it; attribute values do.
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// --- Coordinates ---
+
+var (
+	latRe = regexp.MustCompile(`^([NS])\s*(\d{1,2})°\s*(\d{1,2}(?:\.\d+)?)'\s*(?:(\d{1,2}(?:\.\d+)?)")?$`)
+	lonRe = regexp.MustCompile(`^([EW])\s*(\d{1,3})°\s*(\d{1,2}(?:\.\d+)?)'\s*(?:(\d{1,2}(?:\.\d+)?)")?$`)
+)
+
+// ParseLLA decodes a .PLN position such as
+// N47° 26' 56.09",W122° 18' 34.56",+000433.00. The altitude is optional.
+func ParseLLA(s string) (Position, error) {
+	parts := strings.Split(strings.TrimSpace(s), ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Position{}, fmt.Errorf("position %q is not latitude,longitude[,altitude]", s)
+	}
+	lat, err := parseDMS(latRe, parts[0], 90)
+	if err != nil {
+		return Position{}, fmt.Errorf("latitude %q: %w", parts[0], err)
+	}
+	lon, err := parseDMS(lonRe, parts[1], 180)
+	if err != nil {
+		return Position{}, fmt.Errorf("longitude %q: %w", parts[1], err)
+	}
+	pos := Position{Lat: lat, Lon: lon}
+	if len(parts) == 3 {
+		if pos.AltitudeFt, err = strconv.ParseFloat(strings.TrimSpace(parts[2]), 64); err != nil {
+			return Position{}, fmt.Errorf("altitude %q is not a number", parts[2])
+		}
+	}
+	return pos, nil
+}
+
+func parseOptionalLLA(s string) (Position, error) {
+	if strings.TrimSpace(s) == "" {
+		return Position{}, nil
+	}
+	return ParseLLA(s)
+}
+
+// parseDMS decodes a hemisphere, degrees, minutes and optional seconds.
+func parseDMS(re *regexp.Regexp, s string, limit float64) (float64, error) {
+	m := re.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, errors.New("not in H dd° mm' ss.ss\" form")
+	}
+	deg, _ := strconv.ParseFloat(m[2], 64)
+	mins, _ := strconv.ParseFloat(m[3], 64)
+	var secs float64
+	if m[4] != "" {
+		secs, _ = strconv.ParseFloat(m[4], 64)
+	}
+	if mins >= 60 || secs >= 60 {
+		return 0, errors.New("minutes and seconds must be below 60")
+	}
+	v := deg + mins/60 + secs/3600
+	if v > limit {
+		return 0, fmt.Errorf("must be at most %.0f°", limit)
+	}
+	if m[1] == "S" || m[1] == "W" {
+		v = -v
+	}
+	return v, nil
+}
+
+// FormatLLA encodes a position the way the simulator writes it, e.g.
+// N47° 26' 56.09",W122° 18' 34.56",+000433.00.
+func FormatLLA(p Position) string {
+	return formatDMS(p.Lat, 'N', 'S') + "," + formatDMS(p.Lon, 'E', 'W') + "," + fmt.Sprintf("%+010.2f", p.AltitudeFt)
+}
+
+func formatDMS(v float64, pos, neg byte) string {
+	hemi := pos
+	if v < 0 {
+		hemi = neg
+	}
+	// Round to hundredths of a second first so 59.999" carries into the minutes.
+	hundredths := int64(math.Round(math.Abs(v) * 360000))
+	deg := hundredths / 360000
+	mins := hundredths / 6000 % 60
+	secs := float64(hundredths%6000) / 100
+	return fmt.Sprintf("%c%d° %d' %.2f\"", hemi, deg, mins, secs)
+}
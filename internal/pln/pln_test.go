@@ -0,0 +1,280 @@
+package pln
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// samplePLN is a plan as the MSFS world map saves it, with a SID, an airway
+// segment, a STAR and an approach.
+const samplePLN = "\xef\xbb\xbf" + `<?xml version="1.0" encoding="UTF-8"?>
+<SimBase.Document Type="AceXML" version="1,0">
+    <Descr>AceXML Document</Descr>
+    <FlightPlan.FlightPlan>
+        <Title>KSEA to KPDX</Title>
+        <FPType>IFR</FPType>
+        <RouteType>HighAlt</RouteType>
+        <CruisingAlt>24000</CruisingAlt>
+        <DepartureID>KSEA</DepartureID>
+        <DepartureLLA>N47° 26' 56.09",W122° 18' 34.56",+000433.00</DepartureLLA>
+        <DestinationID>KPDX</DestinationID>
+        <DestinationLLA>N45° 35' 19.32",W122° 35' 51.00",+000031.00</DestinationLLA>
+        <Descr>KSEA, KPDX</Descr>
+        <DeparturePosition>16L</DeparturePosition>
+        <DepartureName>Seattle-Tacoma Intl</DepartureName>
+        <DestinationName>Portland Intl</DestinationName>
+        <AppVersion>
+            <AppVersionMajor>11</AppVersionMajor>
+            <AppVersionBuild>282174</AppVersionBuild>
+        </AppVersion>
+        <ATCWaypoint id="KSEA">
+            <ATCWaypointType>Airport</ATCWaypointType>
+            <WorldPosition>N47° 26' 56.09",W122° 18' 34.56",+000433.00</WorldPosition>
+            <DepartureFP>SUMMA2</DepartureFP>
+            <RunwayNumberFP>16</RunwayNumberFP>
+            <RunwayDesignatorFP>LEFT</RunwayDesignatorFP>
+            <ICAO>
+                <ICAOIdent>KSEA</ICAOIdent>
+            </ICAO>
+        </ATCWaypoint>
+        <ATCWaypoint id="SUMMA">
+            <ATCWaypointType>Intersection</ATCWaypointType>
+            <WorldPosition>N46° 37' 0.00",W122° 12' 0.00",+000000.00</WorldPosition>
+            <SpeedMaxFP>-1</SpeedMaxFP>
+            <ICAO>
+                <ICAORegion>K1</ICAORegion>
+                <ICAOIdent>SUMMA</ICAOIdent>
+            </ICAO>
+        </ATCWaypoint>
+        <ATCWaypoint id="OLM">
+            <ATCWaypointType>VOR</ATCWaypointType>
+            <WorldPosition>N46° 58' 18.00",W122° 54' 7.00",+000000.00</WorldPosition>
+            <ICAO>
+                <ICAORegion>K1</ICAORegion>
+                <ICAOIdent>OLM</ICAOIdent>
+            </ICAO>
+        </ATCWaypoint>
+        <ATCWaypoint id="BTG">
+            <ATCWaypointType>VOR</ATCWaypointType>
+            <WorldPosition>N45° 44' 52.00",W122° 35' 28.00",+000000.00</WorldPosition>
+            <ATCAirway>V165</ATCAirway>
+            <ICAO>
+                <ICAORegion>K1</ICAORegion>
+                <ICAOIdent>BTG</ICAOIdent>
+            </ICAO>
+        </ATCWaypoint>
+        <ATCWaypoint id="KPDX">
+            <ATCWaypointType>Airport</ATCWaypointType>
+            <WorldPosition>N45° 35' 19.32",W122° 35' 51.00",+000031.00</WorldPosition>
+            <ArrivalFP>HHOOD4</ArrivalFP>
+            <ApproachTypeFP>ILS</ApproachTypeFP>
+            <RunwayNumberFP>10</RunwayNumberFP>
+            <RunwayDesignatorFP>RIGHT</RunwayDesignatorFP>
+            <ICAO>
+                <ICAOIdent>KPDX</ICAOIdent>
+            </ICAO>
+        </ATCWaypoint>
+    </FlightPlan.FlightPlan>
+</SimBase.Document>
+`
+
+func TestParse(t *testing.T) {
+	fp, err := Parse([]byte(samplePLN))
+	require.NoError(t, err)
+
+	assert.Equal(t, "KSEA to KPDX", fp.Title)
+	assert.Equal(t, "KSEA, KPDX", fp.Description)
+	assert.Equal(t, IFR, fp.Type)
+	assert.Equal(t, "HighAlt", fp.RouteType)
+	assert.Equal(t, 24000.0, fp.CruisingAltitudeFt)
+	assert.Equal(t, "KSEA", fp.Departure.ID)
+	assert.Equal(t, "Seattle-Tacoma Intl", fp.Departure.Name)
+	assert.InDelta(t, 47.44891, fp.Departure.Position.Lat, 1e-5)
+	assert.InDelta(t, -122.30960, fp.Departure.Position.Lon, 1e-5)
+	assert.Equal(t, 433.0, fp.Departure.Position.AltitudeFt)
+	assert.Equal(t, "KPDX", fp.Destination.ID)
+	assert.Equal(t, "Portland Intl", fp.Destination.Name)
+	assert.Equal(t, "16L", fp.DeparturePosition)
+
+	require.Len(t, fp.Waypoints, 5)
+	assert.Equal(t, Waypoint{
+		ID: "KSEA", Type: TypeAirport, Position: fp.Departure.Position,
+		SID: "SUMMA2", RunwayNumber: "16", RunwayDesignator: "LEFT",
+	}, fp.Waypoints[0])
+	assert.Equal(t, "K1", fp.Waypoints[1].Region)
+	assert.Equal(t, "V165", fp.Waypoints[3].Airway)
+	assert.Equal(t, "HHOOD4", fp.Waypoints[4].STAR)
+	assert.Equal(t, "16L", fp.Waypoints[0].Runway())
+	assert.Equal(t, "10R", fp.Waypoints[4].Runway())
+	assert.Equal(t, "", fp.Waypoints[1].Runway())
+
+	assert.Equal(t, "SUMMA2", fp.SID())
+	assert.Equal(t, "HHOOD4", fp.STAR())
+	assert.Equal(t, "ILS", fp.Approach())
+	assert.Equal(t, "KSEA SUMMA2 SUMMA OLM V165 BTG HHOOD4 KPDX", fp.Route())
+	assert.InDelta(t, 170, fp.DistanceNM(), 1)
+	assert.NoError(t, fp.Validate())
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not XML", "KSEA KPDX", "EOF"},
+		{"other document", `<SimBase.Document><Flight/></SimBase.Document>`, "no FlightPlan.FlightPlan element"},
+		{"wrong root", `<FlightPlan.FlightPlan/>`, "expected element type <SimBase.Document>"},
+		{
+			"bad cruising altitude",
+			`<SimBase.Document><FlightPlan.FlightPlan><CruisingAlt>FL240</CruisingAlt></FlightPlan.FlightPlan></SimBase.Document>`,
+			`CruisingAlt "FL240" is not a number`,
+		},
+		{
+			"bad waypoint position",
+			`<SimBase.Document><FlightPlan.FlightPlan><ATCWaypoint id="X"><WorldPosition>47.4,-122.3</WorldPosition></ATCWaypoint></FlightPlan.FlightPlan></SimBase.Document>`,
+			"waypoint 1 (X) WorldPosition",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			require.ErrorIs(t, err, ErrInvalid)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	fp, err := Parse([]byte(samplePLN))
+	require.NoError(t, err)
+
+	data := Marshal(&fp)
+	assert.Contains(t, string(data), `<WorldPosition>N47° 26' 56.09",W122° 18' 34.56",+000433.00</WorldPosition>`)
+	assert.Contains(t, string(data), `<ATCWaypoint id="SUMMA">`)
+	assert.Contains(t, string(data), `<DepartureFP>SUMMA2</DepartureFP>`)
+
+	again, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, fp, again)
+}
+
+func TestMarshalEscapes(t *testing.T) {
+	fp := FlightPlan{
+		Title:     `Tom's "A&B" <plan>`,
+		Waypoints: []Waypoint{{ID: `W"1`, Type: TypeUser, Position: Position{Lat: 1, Lon: 2}}},
+	}
+	data := Marshal(&fp)
+	assert.Contains(t, string(data), `<Title>Tom's "A&amp;B" &lt;plan&gt;</Title>`)
+	assert.Contains(t, string(data), `<ATCWaypoint id="W&quot;1">`)
+
+	again, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, fp.Title, again.Title)
+	assert.Equal(t, fp.Waypoints[0].ID, again.Waypoints[0].ID)
+}
+
+func TestProblems(t *testing.T) {
+	valid := func() FlightPlan {
+		fp, err := Parse([]byte(samplePLN))
+		require.NoError(t, err)
+		return fp
+	}
+
+	tests := []struct {
+		name   string
+		modify func(fp *FlightPlan)
+		want   []string
+	}{
+		{"valid", func(*FlightPlan) {}, nil},
+		{"flight rules", func(fp *FlightPlan) { fp.Type = "SVFR" }, []string{`flight rules "SVFR" must be IFR or VFR`}},
+		{"cruising altitude", func(fp *FlightPlan) { fp.CruisingAltitudeFt = 99000 }, []string{"cruising altitude 99000 ft must be 0-60000"}},
+		{
+			"no departure",
+			func(fp *FlightPlan) { fp.Departure.ID = "" },
+			[]string{"departure airport is missing"},
+		},
+		{
+			"too few waypoints",
+			func(fp *FlightPlan) { fp.Waypoints = fp.Waypoints[:1] },
+			[]string{"plan has 1 waypoints, need at least the departure and destination"},
+		},
+		{
+			"route does not end at destination",
+			func(fp *FlightPlan) { fp.Waypoints = fp.Waypoints[:4] },
+			[]string{"last waypoint BTG is not the destination airport KPDX"},
+		},
+		{
+			"bad waypoint",
+			func(fp *FlightPlan) {
+				fp.Waypoints[1].Type = "Fix"
+				fp.Waypoints[2].Position = Position{}
+			},
+			[]string{`waypoint SUMMA has unknown type "Fix"`, "waypoint OLM has no valid position"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := valid()
+			tt.modify(&fp)
+			assert.Equal(t, tt.want, fp.Problems())
+			if tt.want == nil {
+				assert.NoError(t, fp.Validate())
+			} else {
+				assert.ErrorIs(t, fp.Validate(), ErrInvalidPlan)
+			}
+		})
+	}
+}
+
+func TestParseLLA(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Position
+		wantErr string
+	}{
+		{"MSFS", `N47° 26' 56.09",W122° 18' 34.56",+000433.00`, Position{47.448914, -122.309600, 433}, ""},
+		{"southern and eastern", `S33° 56' 46.00",E151° 10' 38.00",+000021.00`, Position{-33.946111, 151.177222, 21}, ""},
+		{"no altitude", `N0° 30' 0.00",E0° 0' 0.00"`, Position{0.5, 0, 0}, ""},
+		{"decimal minutes", `N47° 26.5',W122° 18.25'`, Position{47.441667, -122.304167, 0}, ""},
+		{"decimal degrees", "47.4,-122.3", Position{}, "not in H dd° mm' ss.ss\" form"},
+		{"minutes out of range", `N47° 61' 0.00",W122° 0' 0.00"`, Position{}, "below 60"},
+		{"latitude out of range", `N91° 0' 0.00",W122° 0' 0.00"`, Position{}, "at most 90°"},
+		{"bad altitude", `N47° 0' 0.00",W122° 0' 0.00",high`, Position{}, `altitude "high" is not a number`},
+		{"one part", `N47° 0' 0.00"`, Position{}, "is not latitude,longitude[,altitude]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLLA(tt.in)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want.Lat, got.Lat, 1e-6)
+			assert.InDelta(t, tt.want.Lon, got.Lon, 1e-6)
+			assert.Equal(t, tt.want.AltitudeFt, got.AltitudeFt)
+		})
+	}
+}
+
+func TestFormatLLA(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Position
+		want string
+	}{
+		{"MSFS", Position{47.448914, -122.3096, 433}, `N47° 26' 56.09",W122° 18' 34.56",+000433.00`},
+		{"southern and eastern", Position{-33.946111, 151.177222, -12.5}, `S33° 56' 46.00",E151° 10' 38.00",-000012.50`},
+		{"seconds carry into minutes", Position{10.99999999, 0, 0}, `N11° 0' 0.00",E0° 0' 0.00",+000000.00`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatLLA(tt.in))
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package simconnect
+
+// DecodeBCD16 converts a 16-bit BCD value, such as TRANSPONDER CODE read in
+// Bco16 units, to the decimal number its four digits spell: 0x7000 is 7000.
+// Bco16 packs one octal squawk digit per nibble, so it decodes the same way.
+func DecodeBCD16(bcd uint16) int {
+	n := 0
+	for shift := 12; shift >= 0; shift -= 4 {
+		n = n*10 + int(bcd>>shift&0xf)
+	}
+	return n
+}
+
+// EncodeBCD16 converts a decimal number of up to four digits to 16-bit BCD,
+// as XPNDR_SET expects: 7000 becomes 0x7000. Digits above 9999 are dropped.
+func EncodeBCD16(n int) uint16 {
+	var bcd uint16
+	for shift := 0; shift <= 12; shift += 4 {
+		bcd |= uint16(n%10) << shift // #nosec G115 -- a single decimal digit
+		n /= 10
+	}
+	return bcd
+}
@@ -0,0 +1,32 @@
+package simconnect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeBCD16(t *testing.T) {
+	tests := []struct {
+		bcd  uint16
+		want int
+	}{
+		{0x0000, 0},
+		{0x1200, 1200},
+		{0x7000, 7000},
+		{0x7777, 7777},
+		{0x0021, 21},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, DecodeBCD16(tt.bcd), "%#04x", tt.bcd)
+	}
+}
+
+func TestEncodeBCD16(t *testing.T) {
+	for _, n := range []int{0, 21, 1200, 7000, 7777, 9999} {
+		bcd := EncodeBCD16(n)
+		assert.Equal(t, n, DecodeBCD16(bcd), "%d", n)
+	}
+	assert.Equal(t, uint16(0x7000), EncodeBCD16(7000))
+	assert.Equal(t, uint16(0x0021), EncodeBCD16(21))
+}
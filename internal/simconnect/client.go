@@ -3,6 +3,7 @@ package simconnect
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -37,11 +38,76 @@ type Client struct {
 	state  atomic.Int32
 	mu     sync.Mutex
 	nextID atomic.Uint32
+
+	events       *EventRegistry
+	mappedEvents map[string]bool // per-connection; guarded by mu
+	groupReady   bool            // per-connection; guarded by mu
+
+	simvars   *SimVarRegistry
+	writeDefs map[uint32]bool // per-connection write definitions; guarded by mu
+
+	facilityDefs    map[uint32]uint32 // per-connection: DefIDFacility* → registered definition ID, 0 if abandoned; guarded by mu
+	facilityRetries uint32            // per-connection count of definitions registered again; guarded by mu
+
+	sent sendLog
+
+	pendingMu     sync.Mutex
+	pending       map[uint32]chan<- *Exception // sendID → exception waiter; guarded by pendingMu
+	recent        []*Exception                 // most recent exceptions, oldest first; guarded by pendingMu
+	replies       map[uint32]chan<- []byte     // requestID → ReadSimVars or weather observation waiter; guarded by pendingMu
+	objectReplies map[uint32]*objectCollector  // requestID → ReadSimObjects collector; guarded by pendingMu
+	querySeq      atomic.Uint32
+
+	facilityReplies map[uint32]*facilityCollector // requestID → facility request collector; guarded by pendingMu
+	facilitySeq     atomic.Uint32
+	weatherSeq      atomic.Uint32
+
+	statusMu      sync.Mutex
+	attempts      int64     // guarded by statusMu
+	lastConnected time.Time // guarded by statusMu
+	lastErr       error     // guarded by statusMu
+	open          *OpenInfo // guarded by statusMu
+
+	lastRecv atomic.Int64 // UnixNano of the last message read
+
+	listenersMu sync.Mutex
+	listeners   []StateChangeFunc
 }
 
+// StateChangeFunc is called with the previous and new state whenever the
+// client's connection state changes. It runs synchronously on the goroutine
+// that changed the state, possibly while the client holds its send lock, so it
+// must not block or call Client methods that send messages.
+type StateChangeFunc func(from, to ConnectionState)
+
+// recentExceptionsSize is how many exceptions Diagnostics reports.
+const recentExceptionsSize = 20
+
+// Diagnostics summarizes SimConnect exceptions seen by the client.
+type Diagnostics struct {
+	RejectedSimVars  []RejectedSimVar // SimVars rejected on the current connection
+	RecentExceptions []*Exception     // most recent exceptions, newest first
+}
+
+// writeConfirmWindow is how long WriteSimVar waits for an exception before
+// treating a write as accepted. SimConnect never acknowledges successful writes.
+const writeConfirmWindow = 250 * time.Millisecond
+
 // NewClient creates a new SimConnect client.
 func NewClient(cfg Config) *Client {
-	c := &Client{config: cfg}
+	c := &Client{
+		config:        cfg,
+		events:        NewEventRegistry(),
+		mappedEvents:  make(map[string]bool),
+		simvars:       NewSimVarRegistry(),
+		writeDefs:     make(map[uint32]bool),
+		pending:       make(map[uint32]chan<- *Exception),
+		replies:       make(map[uint32]chan<- []byte),
+		objectReplies: make(map[uint32]*objectCollector),
+
+		facilityDefs:    make(map[uint32]uint32),
+		facilityReplies: make(map[uint32]*facilityCollector),
+	}
 	c.state.Store(int32(StateDisconnected))
 	return c
 }
@@ -51,15 +117,46 @@ func (c *Client) State() ConnectionState {
 	return ConnectionState(c.state.Load())
 }
 
+// OnStateChange registers fn to be called on every connection state change.
+func (c *Client) OnStateChange(fn StateChangeFunc) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+	c.listeners = append(c.listeners, fn)
+}
+
+// setState stores the connection state and notifies listeners if it changed.
+func (c *Client) setState(to ConnectionState) {
+	from := ConnectionState(c.state.Swap(int32(to)))
+	if from == to {
+		return
+	}
+	c.listenersMu.Lock()
+	listeners := c.listeners
+	c.listenersMu.Unlock()
+	for _, fn := range listeners {
+		fn(from, to)
+	}
+}
+
+// LastReceived returns when the client last read a message, or when the
+// current connection was opened if nothing has been read since.
+func (c *Client) LastReceived() time.Time {
+	return time.Unix(0, c.lastRecv.Load())
+}
+
 // Connect establishes a TCP connection and sends the OPEN message.
 func (c *Client) Connect(ctx context.Context) error {
 	addr := fmt.Sprintf("%s:%d", c.config.Host, c.config.Port)
 	dialer := net.Dialer{Timeout: c.config.Timeout}
 	conn, err := dialer.DialContext(ctx, "tcp", addr)
 	if err != nil {
-		return fmt.Errorf("simconnect dial: %w", err)
+		err = fmt.Errorf("simconnect dial: %w", err)
+		c.recordConnect(err)
+		return err
 	}
-	return c.connectWithConn(ctx, conn)
+	err = c.connectWithConn(ctx, conn)
+	c.recordConnect(err)
+	return err
 }
 
 // connectWithConn performs the connection handshake on an existing net.Conn.
@@ -72,8 +169,17 @@ func (c *Client) connectWithConn(ctx context.Context, conn net.Conn) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.state.Store(int32(StateConnecting))
+	if c.State() != StateReconnecting {
+		c.setState(StateConnecting)
+	}
 	c.conn = conn
+	c.lastRecv.Store(time.Now().UnixNano())
+	c.mappedEvents = make(map[string]bool)
+	c.groupReady = false
+	c.writeDefs = make(map[uint32]bool)
+	c.facilityDefs = make(map[uint32]uint32)
+	c.facilityRetries = 0
+	c.simvars.ClearRejected()
 
 	// Build KittyHawk OPEN payload (280 bytes):
 	//   256-byte zero-padded app name
@@ -100,11 +206,11 @@ func (c *Client) connectWithConn(ctx context.Context, conn net.Conn) error {
 	payload = binary.LittleEndian.AppendUint32(payload, KHBuildMinor)
 
 	if err := c.sendMessageLocked(SendOpen, payload); err != nil {
-		c.state.Store(int32(StateDisconnected))
+		c.setState(StateDisconnected)
 		return fmt.Errorf("simconnect open: %w", err)
 	}
 
-	c.state.Store(int32(StateConnected))
+	c.setState(StateConnected)
 	return nil
 }
 
@@ -122,7 +228,7 @@ func (c *Client) Close() error {
 
 	err := c.conn.Close()
 	c.conn = nil
-	c.state.Store(int32(StateDisconnected))
+	c.setState(StateDisconnected)
 	return err
 }
 
@@ -136,37 +242,176 @@ func (c *Client) sendMessage(msgType uint32, payload []byte) error {
 
 // sendMessageLocked sends a message; caller must hold c.mu.
 func (c *Client) sendMessageLocked(msgType uint32, payload []byte) error {
+	_, err := c.sendTrackedLocked(msgType, payload, nil)
+	return err
+}
+
+// sendTrackedLocked sends a message and returns its send ID. When waiter is
+// non-nil it is registered, before the write, to receive any exception that
+// references the send ID; the caller must untrack it. Caller must hold c.mu.
+func (c *Client) sendTrackedLocked(msgType uint32, payload []byte, waiter chan<- *Exception) (uint32, error) {
 	if c.conn == nil {
-		return ErrNotConnected
+		return 0, ErrNotConnected
 	}
 
 	id := c.nextID.Add(1)
+	c.sent.record(describeSend(id, msgType, payload))
+	if waiter != nil {
+		c.pendingMu.Lock()
+		c.pending[id] = waiter
+		c.pendingMu.Unlock()
+	}
 	header := EncodeSendHeader(msgType, id, len(payload))
 
+	// A half-open connection must not block the sender, which holds c.mu, forever.
+	if c.config.Timeout > 0 {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(c.config.Timeout))
+	}
 	if _, err := c.conn.Write(header); err != nil {
-		return fmt.Errorf("write header: %w", err)
+		return id, fmt.Errorf("write header: %w", err)
 	}
 	if len(payload) > 0 {
 		if _, err := c.conn.Write(payload); err != nil {
-			return fmt.Errorf("write payload: %w", err)
+			return id, fmt.Errorf("write payload: %w", err)
 		}
 	}
-	return nil
+	return id, nil
+}
+
+// untrackExceptions removes exception waiters registered by sendTrackedLocked.
+func (c *Client) untrackExceptions(sendIDs ...uint32) {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	for _, id := range sendIDs {
+		delete(c.pending, id)
+	}
+}
+
+// handleException correlates an exception with the message that caused it,
+// flags rejected SimVars in the registry, records it for Diagnostics and hands
+// it to any waiter tracking its send ID. SimVars rejected from a temporary
+// query definition are not flagged: the definition is cleared after use and
+// the read reports the rejection to its caller.
+func (c *Client) handleException(data []byte) {
+	exc, err := ParseException(data)
+	if err != nil {
+		return
+	}
+	exc.Message, _ = c.sent.lookup(exc.SendID)
+	exc.Received = time.Now()
+
+	if exc.Message.Type == SendAddToDataDef && exc.Message.SimVar != "" && !isQueryDefinition(exc.Message.DefID) {
+		c.simvars.MarkRejected(exc.Message.SimVar, exc.Message.DefID, exc)
+	}
+
+	c.pendingMu.Lock()
+	c.recent = append(c.recent, exc)
+	if len(c.recent) > recentExceptionsSize {
+		c.recent = c.recent[len(c.recent)-recentExceptionsSize:]
+	}
+	waiter, ok := c.pending[exc.SendID]
+	c.pendingMu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case waiter <- exc:
+	default:
+	}
+}
+
+// deliverReply hands a SimObjectData payload to the ReadSimVars call waiting
+// on its request ID, if any.
+func (c *Client) deliverReply(data []byte) {
+	if len(data) < simObjectDataHeaderSize {
+		return
+	}
+	c.pendingMu.Lock()
+	reply, ok := c.replies[binary.LittleEndian.Uint32(data[0:4])]
+	c.pendingMu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case reply <- data[simObjectDataHeaderSize:]:
+	default:
+	}
+}
+
+// LookupSend returns the outgoing message with the given send ID, if it is
+// still remembered.
+func (c *Client) LookupSend(sendID uint32) (SentMessage, bool) {
+	return c.sent.lookup(sendID)
+}
+
+// SimVars returns the client's SimVar registry, including SimVars the
+// simulator rejected on the current connection.
+func (c *Client) SimVars() *SimVarRegistry {
+	return c.simvars
+}
+
+// Diagnostics returns the SimVars rejected on the current connection and the
+// most recent exceptions.
+func (c *Client) Diagnostics() Diagnostics {
+	c.pendingMu.Lock()
+	recent := make([]*Exception, 0, len(c.recent))
+	for i := len(c.recent) - 1; i >= 0; i-- {
+		recent = append(recent, c.recent[i])
+	}
+	c.pendingMu.Unlock()
+
+	return Diagnostics{
+		RejectedSimVars:  c.simvars.Rejected(),
+		RecentExceptions: recent,
+	}
 }
 
 // ReadNext reads the next complete framed message from the SimConnect connection.
+// Exceptions are correlated with the offending message and routed to any
+// caller waiting on its send ID, replies to ReadSimVars, ReadSimObjects,
+// facility and weather requests are handed to the waiting caller, and the OPEN
+// acknowledgement is stored for Status, before the message is returned.
 func (c *Client) ReadNext() (RecvHeader, []byte, error) {
-	return c.readMessage()
+	h, data, err := c.readMessage()
+	if err != nil {
+		if !errors.Is(err, ErrNotConnected) && !errors.Is(err, net.ErrClosed) {
+			c.recordError(err)
+		}
+		return h, data, err
+	}
+	switch h.Type {
+	case RecvSimObjectData:
+		c.deliverReply(data)
+	case RecvSimObjectDataByType:
+		c.deliverObjectReply(data)
+	case RecvWeatherObservation:
+		c.deliverWeatherObservation(data)
+	case RecvAirportList, RecvVORList, RecvNDBList, RecvWaypointList:
+		c.deliverFacilityList(h.Type, data)
+	case RecvFacilityData:
+		c.deliverFacilityData(data)
+	case RecvFacilityDataEnd:
+		c.deliverFacilityDataEnd(data)
+	case RecvException:
+		c.handleException(data)
+	case RecvOpen:
+		c.handleOpen(data)
+	}
+	return h, data, nil
 }
 
-// readMessage reads a complete framed message from the connection.
+// readMessage reads a complete framed message from the connection. It takes
+// c.mu only to load the connection, so Close can interrupt a blocked read.
 func (c *Client) readMessage() (RecvHeader, []byte, error) {
-	if c.conn == nil {
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
 		return RecvHeader{}, nil, ErrNotConnected
 	}
 
 	headerBuf := make([]byte, RecvHeaderSize)
-	if _, err := io.ReadFull(c.conn, headerBuf); err != nil {
+	if _, err := io.ReadFull(conn, headerBuf); err != nil {
 		return RecvHeader{}, nil, fmt.Errorf("read header: %w", err)
 	}
 
@@ -177,24 +422,31 @@ func (c *Client) readMessage() (RecvHeader, []byte, error) {
 
 	payloadSize := h.Size - RecvHeaderSize
 	if payloadSize == 0 {
+		c.lastRecv.Store(time.Now().UnixNano())
 		return h, nil, nil
 	}
 
 	payload := make([]byte, payloadSize)
-	if _, err := io.ReadFull(c.conn, payload); err != nil {
+	if _, err := io.ReadFull(conn, payload); err != nil {
 		return RecvHeader{}, nil, fmt.Errorf("read payload: %w", err)
 	}
+	c.lastRecv.Store(time.Now().UnixNano())
 	return h, payload, nil
 }
 
 // AddToDataDefinition sends an ADD_TO_DATA_DEFINITION message to register
 // a SimVar with the given definition ID.
 func (c *Client) AddToDataDefinition(defID uint32, simvar SimVarDef) error {
+	return c.sendMessage(SendAddToDataDef, encodeAddToDataDefinition(defID, simvar))
+}
+
+// encodeAddToDataDefinition builds an ADD_TO_DATA_DEFINITION payload.
+func encodeAddToDataDefinition(defID uint32, simvar SimVarDef) []byte {
 	// KittyHawk payload layout (528 bytes):
 	//   int32:      defID
 	//   char[256]:  datum name (zero-padded)
-	//   char[256]:  units name (zero-padded)
-	//   int32:      dataType (4 for FLOAT64)
+	//   char[256]:  units name (zero-padded; empty for strings and structs)
+	//   int32:      dataType (SIMCONNECT_DATATYPE)
 	//   float32:    epsilon (0.0)
 	//   int32:      datumId (0xffffffff = UNUSED)
 	payload := make([]byte, 0, 528)
@@ -206,31 +458,277 @@ func (c *Client) AddToDataDefinition(defID uint32, simvar SimVarDef) error {
 	payload = append(payload, datumName...)
 
 	unitsName := make([]byte, 256)
-	copy(unitsName, simvar.Unit)
+	if !simvar.DataType.IsString() && !simvar.DataType.IsStruct() {
+		copy(unitsName, simvar.Unit)
+	}
 	payload = append(payload, unitsName...)
 
 	payload = binary.LittleEndian.AppendUint32(payload, uint32(simvar.DataType)) // #nosec G115 -- DataType is a small enum value
 	payload = binary.LittleEndian.AppendUint32(payload, math.Float32bits(0.0))   // epsilon
-	payload = binary.LittleEndian.AppendUint32(payload, 0xffffffff)              // datumId UNUSED
+	return binary.LittleEndian.AppendUint32(payload, 0xffffffff)                 // datumId UNUSED
+}
 
-	return c.sendMessage(SendAddToDataDef, payload)
+// RequestOptions controls the period and filtering of a data request.
+type RequestOptions struct {
+	Period   Period
+	Flags    uint32 // DataRequestFlag* bits
+	Origin   uint32 // periods to wait before the first send
+	Interval uint32 // periods to skip between sends
+	Limit    uint32 // sends before the request stops; 0 = unlimited
 }
 
-// RequestData sends a REQUEST_DATA message to start receiving data for
+// RequestData sends a REQUEST_DATA message to receive data once for
 // the given definition ID, object ID, and request ID.
 func (c *Client) RequestData(defID, objectID, requestID uint32) error {
-	// KittyHawk payload layout (32 bytes):
-	//   int32: requestID, defID, objectID
-	//   int32: period(1=PERIOD_ONCE), flags(0), origin(0), interval(0), limit(0)
+	return c.RequestDataWithOptions(defID, objectID, requestID, RequestOptions{Period: PeriodOnce})
+}
+
+// RequestDataWithOptions sends a REQUEST_DATA message with an explicit period,
+// flags, origin, interval and limit. Periodic requests keep streaming
+// SimObjectData until cancelled with CancelDataRequest or the connection closes.
+func (c *Client) RequestDataWithOptions(defID, objectID, requestID uint32, opts RequestOptions) error {
+	return c.sendMessage(SendRequestData, encodeRequestData(defID, objectID, requestID, opts))
+}
+
+// CancelDataRequest stops a periodic request by re-issuing it with PERIOD_NEVER.
+func (c *Client) CancelDataRequest(defID, objectID, requestID uint32) error {
+	return c.RequestDataWithOptions(defID, objectID, requestID, RequestOptions{Period: PeriodNever})
+}
+
+// encodeRequestData builds a REQUEST_DATA payload (32 bytes):
+//
+//	int32: requestID, defID, objectID
+//	int32: period, flags, origin, interval, limit
+func encodeRequestData(defID, objectID, requestID uint32, opts RequestOptions) []byte {
 	payload := make([]byte, 0, 32)
 	payload = binary.LittleEndian.AppendUint32(payload, requestID)
 	payload = binary.LittleEndian.AppendUint32(payload, defID)
 	payload = binary.LittleEndian.AppendUint32(payload, objectID)
-	payload = binary.LittleEndian.AppendUint32(payload, 1) // PERIOD_ONCE
-	payload = binary.LittleEndian.AppendUint32(payload, 0) // flags
-	payload = binary.LittleEndian.AppendUint32(payload, 0) // origin
-	payload = binary.LittleEndian.AppendUint32(payload, 0) // interval
-	payload = binary.LittleEndian.AppendUint32(payload, 0) // limit
+	payload = binary.LittleEndian.AppendUint32(payload, uint32(opts.Period))
+	payload = binary.LittleEndian.AppendUint32(payload, opts.Flags)
+	payload = binary.LittleEndian.AppendUint32(payload, opts.Origin)
+	payload = binary.LittleEndian.AppendUint32(payload, opts.Interval)
+	return binary.LittleEndian.AppendUint32(payload, opts.Limit)
+}
+
+// RequestSystemState sends a REQUEST_SYSTEM_STATE message for the named state
+// (e.g. "Sim", "AircraftLoaded"). The reply arrives as RecvSystemState.
+func (c *Client) RequestSystemState(requestID uint32, state string) error {
+	payload := binary.LittleEndian.AppendUint32(make([]byte, 0, 264), requestID)
+	name := make([]byte, 260)
+	copy(name, state)
+	return c.sendMessage(SendRequestSystemState, append(payload, name...))
+}
+
+// SetDataOnSimObject sends a SET_DATA_ON_SIMOBJECT message that writes data,
+// laid out according to defID, to the given object.
+func (c *Client) SetDataOnSimObject(defID, objectID uint32, data []byte) error {
+	return c.sendMessage(SendSetDataOnSimObject, encodeSetDataOnSimObject(defID, objectID, data))
+}
+
+// WriteSimVar sets a writable SimVar on the user aircraft. The name must be in
+// both the SimVarRegistry and the writable allowlist. Each writable SimVar has
+// its own data definition, registered on first use for each connection.
+//
+// SimConnect only reports failed writes, so WriteSimVar waits briefly for an
+// exception referencing the definition or the write and returns it as an
+// *Exception. Exceptions are only seen while another goroutine is
+// reading messages, normally the Poller.
+func (c *Client) WriteSimVar(ctx context.Context, name string, value float64) error {
+	if err := c.simvars.ValidateWritable(name); err != nil {
+		return err
+	}
+	defID, simvar := writeDefinition(name)
+	data := binary.LittleEndian.AppendUint64(nil, math.Float64bits(value))
+	return c.setData(ctx, defID, simvar, data)
+}
+
+// SetInitPosition places the user aircraft at pos through the write-only
+// "Initial Position" SimVar, as the simulator's slew or a flight load would:
+// the aircraft is moved instantly, with its attitude and airspeed reset.
+// Errors are reported like WriteSimVar's.
+func (c *Client) SetInitPosition(ctx context.Context, pos InitPosition) error {
+	data, err := binary.Append(nil, binary.LittleEndian, pos)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", InitialPosition.Name, err)
+	}
+	return c.setData(ctx, DefIDInitPosition, InitialPosition, data)
+}
+
+// setData sets data, laid out per simvar, on the user aircraft through the
+// single-var definition defID, which is registered on first use for each
+// connection. It waits writeConfirmWindow for an exception, since
+// SimConnect only reports failures.
+func (c *Client) setData(ctx context.Context, defID uint32, simvar SimVarDef, data []byte) error {
+	name := simvar.Name
+	waiter := make(chan *Exception, 2)
+	var sendIDs []uint32
+	defer func() { c.untrackExceptions(sendIDs...) }()
+
+	c.mu.Lock()
+	var defSendID uint32
+	if !c.writeDefs[defID] {
+		id, err := c.sendTrackedLocked(SendAddToDataDef, encodeAddToDataDefinition(defID, simvar), waiter)
+		sendIDs = append(sendIDs, id)
+		if err != nil {
+			c.mu.Unlock()
+			return fmt.Errorf("define %s: %w", name, err)
+		}
+		c.writeDefs[defID] = true
+		defSendID = id
+	}
+	id, err := c.sendTrackedLocked(SendSetDataOnSimObject, encodeSetDataOnSimObject(defID, ObjectIDUser, data), waiter)
+	sendIDs = append(sendIDs, id)
+	c.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	timer := time.NewTimer(writeConfirmWindow)
+	defer timer.Stop()
+
+	select {
+	case exc := <-waiter:
+		if defSendID != 0 && exc.SendID == defSendID {
+			// The definition was rejected; register it again on the next write.
+			c.mu.Lock()
+			delete(c.writeDefs, defID)
+			c.mu.Unlock()
+			return fmt.Errorf("define %s: %w", name, exc)
+		}
+		return fmt.Errorf("write %s: %w", name, exc)
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// writeDefinition returns the data definition ID and SimVarDef used to write
+// the named SimVar. The name must already be validated as writable.
+func writeDefinition(name string) (uint32, SimVarDef) {
+	for i, sv := range WritableSimVars {
+		if sv.Name == name {
+			return DefIDWriteBase + uint32(i), sv // #nosec G115 -- allowlist is small
+		}
+	}
+	return 0, SimVarDef{}
+}
+
+// encodeSetDataOnSimObject builds a SET_DATA_ON_SIMOBJECT payload
+// (20 bytes + data):
+//
+//	int32: defID, objectID, flags (0), arrayCount (1), unitSize
+//	data:  raw values laid out per the definition
+func encodeSetDataOnSimObject(defID, objectID uint32, data []byte) []byte {
+	payload := make([]byte, 0, 20+len(data))
+	payload = binary.LittleEndian.AppendUint32(payload, defID)
+	payload = binary.LittleEndian.AppendUint32(payload, objectID)
+	payload = binary.LittleEndian.AppendUint32(payload, 0)                 // flags
+	payload = binary.LittleEndian.AppendUint32(payload, 1)                 // arrayCount
+	payload = binary.LittleEndian.AppendUint32(payload, uint32(len(data))) // #nosec G115 -- data is a few bytes
+	return append(payload, data...)
+}
+
+// MapClientEventToSimEvent sends a MAP_CLIENT_EVENT_TO_SIM_EVENT message that
+// associates a client-defined event ID with a named sim event.
+func (c *Client) MapClientEventToSimEvent(eventID uint32, eventName string) error {
+	return c.sendMessage(SendMapClientEvent, encodeMapClientEvent(eventID, eventName))
+}
+
+// TransmitClientEvent sends a TRANSMIT_CLIENT_EVENT message that fires a
+// previously mapped client event at the given object.
+func (c *Client) TransmitClientEvent(objectID, eventID, data, groupID, flags uint32) error {
+	return c.sendMessage(SendTransmitClientEvent, encodeTransmitClientEvent(objectID, eventID, data, groupID, flags))
+}
+
+// AddClientEventToNotificationGroup sends an ADD_CLIENT_EVENT_TO_NOTIFICATION_GROUP
+// message that adds a mapped client event to a notification group.
+func (c *Client) AddClientEventToNotificationGroup(groupID, eventID uint32, maskable bool) error {
+	return c.sendMessage(SendAddClientEventToGroup, encodeAddClientEventToGroup(groupID, eventID, maskable))
+}
+
+// SetNotificationGroupPriority sends a SET_NOTIFICATION_GROUP_PRIORITY message.
+func (c *Client) SetNotificationGroupPriority(groupID, priority uint32) error {
+	return c.sendMessage(SendSetGroupPriority, encodeSetGroupPriority(groupID, priority))
+}
+
+// TransmitEvent fires an allowlisted sim event at the user aircraft.
+// The event is mapped and added to the default notification group on first
+// use for each connection, so callers only deal in sim event names.
+func (c *Client) TransmitEvent(eventName string, data uint32) error {
+	eventID, ok := c.events.ID(eventName)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidEvent, eventName)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.mappedEvents[eventName] {
+		if err := c.sendMessageLocked(SendMapClientEvent, encodeMapClientEvent(eventID, eventName)); err != nil {
+			return fmt.Errorf("map event %s: %w", eventName, err)
+		}
+		if err := c.sendMessageLocked(SendAddClientEventToGroup, encodeAddClientEventToGroup(GroupIDDefault, eventID, false)); err != nil {
+			return fmt.Errorf("add event %s to group: %w", eventName, err)
+		}
+		c.mappedEvents[eventName] = true
+	}
+	if !c.groupReady {
+		if err := c.sendMessageLocked(SendSetGroupPriority, encodeSetGroupPriority(GroupIDDefault, GroupPriorityHighest)); err != nil {
+			return fmt.Errorf("set group priority: %w", err)
+		}
+		c.groupReady = true
+	}
+
+	payload := encodeTransmitClientEvent(ObjectIDUser, eventID, data, GroupIDDefault, EventFlagDefault)
+	return c.sendMessageLocked(SendTransmitClientEvent, payload)
+}
+
+// encodeMapClientEvent builds a MAP_CLIENT_EVENT_TO_SIM_EVENT payload (260 bytes):
+//
+//	int32:     eventID
+//	char[256]: event name (zero-padded)
+func encodeMapClientEvent(eventID uint32, eventName string) []byte {
+	payload := make([]byte, 0, 260)
+	payload = binary.LittleEndian.AppendUint32(payload, eventID)
+	name := make([]byte, 256)
+	copy(name, eventName)
+	return append(payload, name...)
+}
+
+// encodeTransmitClientEvent builds a TRANSMIT_CLIENT_EVENT payload (20 bytes):
+//
+//	int32: objectID, eventID, data, groupID, flags
+func encodeTransmitClientEvent(objectID, eventID, data, groupID, flags uint32) []byte {
+	payload := make([]byte, 0, 20)
+	payload = binary.LittleEndian.AppendUint32(payload, objectID)
+	payload = binary.LittleEndian.AppendUint32(payload, eventID)
+	payload = binary.LittleEndian.AppendUint32(payload, data)
+	payload = binary.LittleEndian.AppendUint32(payload, groupID)
+	return binary.LittleEndian.AppendUint32(payload, flags)
+}
+
+// encodeAddClientEventToGroup builds an ADD_CLIENT_EVENT_TO_NOTIFICATION_GROUP
+// payload (12 bytes):
+//
+//	int32: groupID, eventID, maskable (BOOL)
+func encodeAddClientEventToGroup(groupID, eventID uint32, maskable bool) []byte {
+	payload := make([]byte, 0, 12)
+	payload = binary.LittleEndian.AppendUint32(payload, groupID)
+	payload = binary.LittleEndian.AppendUint32(payload, eventID)
+	var m uint32
+	if maskable {
+		m = 1
+	}
+	return binary.LittleEndian.AppendUint32(payload, m)
+}
 
-	return c.sendMessage(SendRequestData, payload)
+// encodeSetGroupPriority builds a SET_NOTIFICATION_GROUP_PRIORITY payload (8 bytes):
+//
+//	int32: groupID, priority
+func encodeSetGroupPriority(groupID, priority uint32) []byte {
+	payload := make([]byte, 0, 8)
+	payload = binary.LittleEndian.AppendUint32(payload, groupID)
+	return binary.LittleEndian.AppendUint32(payload, priority)
 }
@@ -0,0 +1,47 @@
+package simconnect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+)
+
+func TestClientStatusRecordsOpenAck(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+
+	require.Eventually(t, func() bool { return c.Status().Open != nil }, time.Second, 5*time.Millisecond)
+	st := c.Status()
+	assert.Equal(t, simconnect.StateConnected, st.State)
+	assert.Equal(t, int64(1), st.ConnectAttempts)
+	assert.False(t, st.LastConnectedAt.IsZero())
+	assert.NoError(t, st.LastError)
+	assert.Equal(t, simconnecttest.DefaultAppName, st.Open.AppName)
+	assert.Equal(t, uint32(simconnecttest.DefaultAppVersionMajor), st.Open.AppVersion.Major)
+	assert.Equal(t, uint32(simconnecttest.DefaultSimConnectMajor), st.Open.SimConnectVersion.Major)
+}
+
+func TestClientStatusRecordsConnectFailure(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	cfg := srv.Config()
+	srv.Close()
+
+	c := simconnect.NewClient(cfg)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.Error(t, c.Connect(ctx))
+	require.Error(t, c.Connect(ctx))
+
+	st := c.Status()
+	assert.Equal(t, simconnect.StateDisconnected, st.State)
+	assert.Equal(t, int64(2), st.ConnectAttempts)
+	assert.True(t, st.LastConnectedAt.IsZero())
+	assert.ErrorContains(t, st.LastError, "simconnect dial")
+	assert.Nil(t, st.Open)
+}
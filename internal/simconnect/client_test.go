@@ -383,3 +383,196 @@ func TestRequestData(t *testing.T) {
 		t.Fatal("timeout")
 	}
 }
+
+func TestRequestDataWithOptions(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	_, serverConn := connectAndDrainOpen(t, c)
+	msgs := captureMessages(serverConn, 1)
+
+	opts := RequestOptions{
+		Period:   PeriodSimFrame,
+		Flags:    DataRequestFlagChanged | DataRequestFlagTagged,
+		Origin:   2,
+		Interval: 15,
+		Limit:    100,
+	}
+	require.NoError(t, c.RequestDataWithOptions(DefIDPosition, ObjectIDUser, ReqIDPosition, opts))
+
+	msg := nextCaptured(t, msgs)
+	assert.Equal(t, SendRequestData|SendTypeMask, msg.h.Type)
+	require.Len(t, msg.payload, 32)
+	want := []uint32{ReqIDPosition, DefIDPosition, ObjectIDUser, uint32(PeriodSimFrame), 0x03, 2, 15, 100}
+	for i, w := range want {
+		assert.Equal(t, w, binary.LittleEndian.Uint32(msg.payload[i*4:i*4+4]), "field %d", i)
+	}
+}
+
+func TestCancelDataRequest(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	_, serverConn := connectAndDrainOpen(t, c)
+	msgs := captureMessages(serverConn, 1)
+
+	require.NoError(t, c.CancelDataRequest(DefIDEngine, ObjectIDUser, ReqIDEngine))
+
+	msg := nextCaptured(t, msgs)
+	assert.Equal(t, ReqIDEngine, binary.LittleEndian.Uint32(msg.payload[0:4]))
+	assert.Equal(t, uint32(PeriodNever), binary.LittleEndian.Uint32(msg.payload[12:16]))
+}
+
+type capturedMessage struct {
+	h       SendHeader
+	payload []byte
+}
+
+// captureMessages drains n outgoing messages from the server side of a net.Pipe.
+func captureMessages(serverConn net.Conn, n int) <-chan capturedMessage {
+	out := make(chan capturedMessage, n)
+	go func() {
+		for i := 0; i < n; i++ {
+			h, p, err := drainOneMessage(serverConn)
+			if err != nil {
+				return
+			}
+			out <- capturedMessage{h, p}
+		}
+	}()
+	return out
+}
+
+func nextCaptured(t *testing.T, ch <-chan capturedMessage) capturedMessage {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for message")
+		return capturedMessage{}
+	}
+}
+
+func TestMapClientEventToSimEvent(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	_, serverConn := connectAndDrainOpen(t, c)
+	msgs := captureMessages(serverConn, 1)
+
+	require.NoError(t, c.MapClientEventToSimEvent(1001, EventAPMaster))
+
+	msg := nextCaptured(t, msgs)
+	assert.Equal(t, SendMapClientEvent|SendTypeMask, msg.h.Type)
+	require.Len(t, msg.payload, 260)
+	assert.Equal(t, uint32(1001), binary.LittleEndian.Uint32(msg.payload[0:4]))
+	assert.Equal(t, "AP_MASTER", string(msg.payload[4:4+len("AP_MASTER")]))
+	assert.Equal(t, byte(0), msg.payload[4+len("AP_MASTER")])
+}
+
+func TestTransmitClientEvent(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	_, serverConn := connectAndDrainOpen(t, c)
+	msgs := captureMessages(serverConn, 1)
+
+	require.NoError(t, c.TransmitClientEvent(ObjectIDUser, 1001, 270, GroupIDDefault, EventFlagGroupIDIsPriority))
+
+	msg := nextCaptured(t, msgs)
+	assert.Equal(t, SendTransmitClientEvent|SendTypeMask, msg.h.Type)
+	require.Len(t, msg.payload, 20)
+	assert.Equal(t, ObjectIDUser, binary.LittleEndian.Uint32(msg.payload[0:4]))
+	assert.Equal(t, uint32(1001), binary.LittleEndian.Uint32(msg.payload[4:8]))
+	assert.Equal(t, uint32(270), binary.LittleEndian.Uint32(msg.payload[8:12]))
+	assert.Equal(t, GroupIDDefault, binary.LittleEndian.Uint32(msg.payload[12:16]))
+	assert.Equal(t, EventFlagGroupIDIsPriority, binary.LittleEndian.Uint32(msg.payload[16:20]))
+}
+
+func TestAddClientEventToNotificationGroup(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	_, serverConn := connectAndDrainOpen(t, c)
+	msgs := captureMessages(serverConn, 1)
+
+	require.NoError(t, c.AddClientEventToNotificationGroup(2, 1001, true))
+
+	msg := nextCaptured(t, msgs)
+	assert.Equal(t, SendAddClientEventToGroup|SendTypeMask, msg.h.Type)
+	require.Len(t, msg.payload, 12)
+	assert.Equal(t, uint32(2), binary.LittleEndian.Uint32(msg.payload[0:4]))
+	assert.Equal(t, uint32(1001), binary.LittleEndian.Uint32(msg.payload[4:8]))
+	assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(msg.payload[8:12]))
+}
+
+func TestSetNotificationGroupPriority(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	_, serverConn := connectAndDrainOpen(t, c)
+	msgs := captureMessages(serverConn, 1)
+
+	require.NoError(t, c.SetNotificationGroupPriority(2, GroupPriorityStandard))
+
+	msg := nextCaptured(t, msgs)
+	assert.Equal(t, SendSetGroupPriority|SendTypeMask, msg.h.Type)
+	require.Len(t, msg.payload, 8)
+	assert.Equal(t, uint32(2), binary.LittleEndian.Uint32(msg.payload[0:4]))
+	assert.Equal(t, GroupPriorityStandard, binary.LittleEndian.Uint32(msg.payload[4:8]))
+}
+
+func TestTransmitEventMapsOncePerConnection(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	_, serverConn := connectAndDrainOpen(t, c)
+	// First call: map + add to group + set priority + transmit. Second: transmit only.
+	msgs := captureMessages(serverConn, 5)
+
+	require.NoError(t, c.TransmitEvent(EventHeadingBugSet, 90))
+	require.NoError(t, c.TransmitEvent(EventHeadingBugSet, 180))
+
+	wantTypes := []uint32{
+		SendMapClientEvent, SendAddClientEventToGroup, SendSetGroupPriority,
+		SendTransmitClientEvent, SendTransmitClientEvent,
+	}
+	eventID, ok := c.events.ID(EventHeadingBugSet)
+	require.True(t, ok)
+	for i, want := range wantTypes {
+		msg := nextCaptured(t, msgs)
+		assert.Equal(t, want|SendTypeMask, msg.h.Type, "message %d", i)
+		if want == SendTransmitClientEvent {
+			assert.Equal(t, eventID, binary.LittleEndian.Uint32(msg.payload[4:8]))
+		}
+	}
+}
+
+func TestTransmitEventRejectsUnknownEvent(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	err := c.TransmitEvent("SIM_RATE_INCR_TO_MAX", 0)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+}
+
+func TestTransmitEventWhenNotConnected(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	err := c.TransmitEvent(EventAPMaster, 0)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
+}
+
+func TestSetDataOnSimObject(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	_, serverConn := connectAndDrainOpen(t, c)
+	msgs := captureMessages(serverConn, 1)
+
+	data := binary.LittleEndian.AppendUint64(nil, math.Float64bits(29.92))
+	require.NoError(t, c.SetDataOnSimObject(DefIDWriteBase, ObjectIDUser, data))
+
+	msg := nextCaptured(t, msgs)
+	assert.Equal(t, SendSetDataOnSimObject|SendTypeMask, msg.h.Type)
+	require.Len(t, msg.payload, 28)
+	assert.Equal(t, DefIDWriteBase, binary.LittleEndian.Uint32(msg.payload[0:4]))
+	assert.Equal(t, ObjectIDUser, binary.LittleEndian.Uint32(msg.payload[4:8]))
+	assert.Equal(t, uint32(0), binary.LittleEndian.Uint32(msg.payload[8:12]), "flags")
+	assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(msg.payload[12:16]), "arrayCount")
+	assert.Equal(t, uint32(8), binary.LittleEndian.Uint32(msg.payload[16:20]), "unitSize")
+	assert.InDelta(t, 29.92, math.Float64frombits(binary.LittleEndian.Uint64(msg.payload[20:28])), 1e-9)
+}
+
+func TestWriteSimVarValidatesAllowlist(t *testing.T) {
+	c := NewClient(defaultTestConfig())
+	ctx := context.Background()
+
+	assert.ErrorIs(t, c.WriteSimVar(ctx, "PLANE LATITUDE", 0), ErrSimVarNotWritable)
+	assert.ErrorIs(t, c.WriteSimVar(ctx, "NOT_A_REAL_VAR", 0), ErrInvalidSimVar)
+	assert.ErrorIs(t, c.WriteSimVar(ctx, "KOHLSMAN SETTING HG", 29.92), ErrNotConnected)
+}
@@ -0,0 +1,135 @@
+package simconnect_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+)
+
+// connectReading connects a client to srv and drains its messages in the
+// background, as the Poller does, so exceptions reach WriteSimVar.
+func connectReading(t *testing.T, srv *simconnecttest.Server) *simconnect.Client {
+	t.Helper()
+	c := simconnect.NewClient(srv.Config())
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, c.Connect(ctx))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := c.ReadNext(); err != nil {
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		srv.Close()
+		<-done
+	})
+	return c
+}
+
+func TestWriteSimVar(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+	ctx := context.Background()
+
+	require.NoError(t, c.WriteSimVar(ctx, simconnect.KohlsmanSettingHg.Name, 29.92))
+	require.NoError(t, c.WriteSimVar(ctx, simconnect.KohlsmanSettingHg.Name, 30.01))
+
+	writes := srv.Writes()
+	require.Len(t, writes, 2)
+	for i, want := range []float64{29.92, 30.01} {
+		assert.Equal(t, simconnect.ObjectIDUser, writes[i].ObjectID)
+		assert.Equal(t, []string{"KOHLSMAN SETTING HG"}, writes[i].SimVars)
+		require.Len(t, writes[i].Data, 8)
+		assert.InDelta(t, want, math.Float64frombits(binary.LittleEndian.Uint64(writes[i].Data)), 1e-9)
+	}
+	assert.Len(t, srv.Messages(simconnect.SendAddToDataDef), 1, "definition should be registered once per connection")
+}
+
+func TestWriteSimVarSurfacesWriteException(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.FailMessages(simconnect.SendSetDataOnSimObject, simconnect.ExceptionDataError)
+	c := connectReading(t, srv)
+
+	err := c.WriteSimVar(context.Background(), simconnect.ComStandbyFrequency1.Name, 121.5)
+	require.Error(t, err)
+
+	var exc *simconnect.Exception
+	require.True(t, errors.As(err, &exc))
+	assert.ErrorIs(t, err, simconnect.ErrException)
+	assert.Equal(t, simconnect.ExceptionDataError, exc.Code)
+
+	sets := srv.Messages(simconnect.SendSetDataOnSimObject)
+	require.Len(t, sets, 1)
+	assert.Equal(t, sets[0].ID, exc.SendID)
+}
+
+func TestWriteSimVarSurfacesDefinitionException(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.RejectSimVar("FUEL TANK CENTER QUANTITY", simconnect.ExceptionNameUnrecognized)
+	c := connectReading(t, srv)
+	ctx := context.Background()
+
+	err := c.WriteSimVar(ctx, simconnect.FuelTankCenterQuantity.Name, 10)
+	var exc *simconnect.Exception
+	require.True(t, errors.As(err, &exc))
+	assert.Equal(t, simconnect.ExceptionNameUnrecognized, exc.Code)
+	assert.Equal(t, srv.Messages(simconnect.SendAddToDataDef)[0].ID, exc.SendID)
+
+	// A rejected definition is registered again on the next attempt.
+	_ = c.WriteSimVar(ctx, simconnect.FuelTankCenterQuantity.Name, 10)
+	assert.Len(t, srv.Messages(simconnect.SendAddToDataDef), 2)
+}
+
+func TestSetInitPosition(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+	ctx := context.Background()
+
+	pos := simconnect.InitPosition{
+		Latitude: 47.28, Longitude: -122.31, Altitude: 3000, Heading: 163,
+		Airspeed: 120,
+	}
+	require.NoError(t, c.SetInitPosition(ctx, pos))
+	pos.OnGround, pos.Airspeed = 1, simconnect.InitPositionAirspeedKeep
+	require.NoError(t, c.SetInitPosition(ctx, pos))
+
+	defs := srv.Messages(simconnect.SendAddToDataDef)
+	require.Len(t, defs, 1, "definition should be registered once per connection")
+	assert.Equal(t, simconnect.DefIDInitPosition, binary.LittleEndian.Uint32(defs[0].Payload[0:4]))
+	assert.Equal(t, simconnect.DataTypeInitPosition, simconnect.DataType(binary.LittleEndian.Uint32(defs[0].Payload[516:520])))
+
+	writes := srv.Writes()
+	require.Len(t, writes, 2)
+	assert.Equal(t, simconnect.ObjectIDUser, writes[1].ObjectID)
+	assert.Equal(t, []string{"Initial Position"}, writes[1].SimVars)
+	require.Len(t, writes[1].Data, 56)
+	var got simconnect.InitPosition
+	require.NoError(t, binary.Read(bytes.NewReader(writes[1].Data), binary.LittleEndian, &got))
+	assert.Equal(t, pos, got)
+	assert.Equal(t, uint32(120), binary.LittleEndian.Uint32(writes[0].Data[52:56]))
+}
+
+func TestSetInitPositionSurfacesException(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.FailMessages(simconnect.SendSetDataOnSimObject, simconnect.ExceptionDataError)
+	c := connectReading(t, srv)
+
+	err := c.SetInitPosition(context.Background(), simconnect.InitPosition{Latitude: 91})
+	require.ErrorIs(t, err, simconnect.ErrException)
+	assert.Contains(t, err.Error(), "write Initial Position")
+}
@@ -0,0 +1,213 @@
+package simconnect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// LatLonAlt is a SIMCONNECT_DATA_LATLONALT value, e.g. STRUCT LATLONALT.
+type LatLonAlt struct {
+	Latitude  float64 // degrees
+	Longitude float64 // degrees
+	Altitude  float64 // meters
+}
+
+// XYZ is a SIMCONNECT_DATA_XYZ value, e.g. STRUCT WORLDVELOCITY.
+type XYZ struct {
+	X, Y, Z float64
+}
+
+// PBH is an attitude triple. SimConnect has no PBH data type: attitude
+// structs arrive as XYZ with pitch, bank and heading in X, Y and Z.
+type PBH struct {
+	Pitch   float64
+	Bank    float64
+	Heading float64
+}
+
+// PBH reads v as pitch (X), bank (Y) and heading (Z).
+func (v XYZ) PBH() PBH {
+	return PBH{Pitch: v.X, Bank: v.Y, Heading: v.Z}
+}
+
+// Waypoint is a SIMCONNECT_DATA_WAYPOINT value.
+type Waypoint struct {
+	Latitude        float64 // degrees
+	Longitude       float64 // degrees
+	Altitude        float64 // feet
+	Flags           uint32  // SIMCONNECT_WAYPOINT_* flags
+	SpeedKnots      float64
+	ThrottlePercent float64
+}
+
+// InitPosition is a SIMCONNECT_DATA_INITPOSITION value, used to place an
+// aircraft.
+type InitPosition struct {
+	Latitude  float64 // degrees
+	Longitude float64 // degrees
+	Altitude  float64 // feet
+	Pitch     float64 // degrees
+	Bank      float64 // degrees
+	Heading   float64 // degrees
+	OnGround  uint32  // 1 to place the aircraft on the ground
+	Airspeed  uint32  // knots, or one of the INITPOSITION_AIRSPEED_* values
+}
+
+// Special InitPosition.Airspeed values.
+const (
+	InitPositionAirspeedCruise uint32 = 0xFFFFFFFF // INITPOSITION_AIRSPEED_CRUISE (-1): the aircraft's cruise speed
+	InitPositionAirspeedKeep   uint32 = 0xFFFFFFFE // INITPOSITION_AIRSPEED_KEEP (-2): the current airspeed
+)
+
+// MarkerState is a SIMCONNECT_DATA_MARKERSTATE value.
+type MarkerState struct {
+	Name  string
+	State uint32
+}
+
+// Size returns the number of bytes a value of the type occupies in a data
+// block. It is 0 for DataTypeStringV, whose length is only known once the
+// value is decoded, and for unknown types.
+func (dt DataType) Size() int {
+	switch dt {
+	case DataTypeInt32, DataTypeFloat32:
+		return 4
+	case DataTypeInt64, DataTypeFloat64, DataTypeString8:
+		return 8
+	case DataTypeString32:
+		return 32
+	case DataTypeString64:
+		return 64
+	case DataTypeString128:
+		return 128
+	case DataTypeString256:
+		return 256
+	case DataTypeString260:
+		return 260
+	case DataTypeInitPosition:
+		return 56
+	case DataTypeMarkerState:
+		return 68
+	case DataTypeWaypoint:
+		return 44
+	case DataTypeLatLonAlt, DataTypeXYZ:
+		return 24
+	default:
+		return 0
+	}
+}
+
+// IsString reports whether the type is one of the string types.
+func (dt DataType) IsString() bool {
+	return dt >= DataTypeString8 && dt <= DataTypeStringV
+}
+
+// IsStruct reports whether the type is one of the SIMCONNECT_DATA_* structs.
+func (dt DataType) IsStruct() bool {
+	return dt >= DataTypeInitPosition && dt <= DataTypeXYZ
+}
+
+// String returns the SIMCONNECT_DATATYPE name without its prefix.
+func (dt DataType) String() string {
+	if name, ok := dataTypeNames[dt]; ok {
+		return name
+	}
+	return fmt.Sprintf("DataType(%d)", int(dt))
+}
+
+var dataTypeNames = map[DataType]string{
+	DataTypeInt32:        "INT32",
+	DataTypeInt64:        "INT64",
+	DataTypeFloat32:      "FLOAT32",
+	DataTypeFloat64:      "FLOAT64",
+	DataTypeString8:      "STRING8",
+	DataTypeString32:     "STRING32",
+	DataTypeString64:     "STRING64",
+	DataTypeString128:    "STRING128",
+	DataTypeString256:    "STRING256",
+	DataTypeString260:    "STRING260",
+	DataTypeStringV:      "STRINGV",
+	DataTypeInitPosition: "INITPOSITION",
+	DataTypeMarkerState:  "MARKERSTATE",
+	DataTypeWaypoint:     "WAYPOINT",
+	DataTypeLatLonAlt:    "LATLONALT",
+	DataTypeXYZ:          "XYZ",
+}
+
+// decodeValue decodes the value at the start of data and returns it with the
+// number of bytes it occupied.
+func decodeValue(data []byte, dt DataType) (any, int, error) {
+	if dt == DataTypeStringV {
+		// Variable-length strings are NUL-terminated with no padding.
+		n := bytes.IndexByte(data, 0)
+		if n < 0 {
+			return nil, 0, fmt.Errorf("STRINGV is not NUL-terminated within %d bytes", len(data))
+		}
+		return string(data[:n]), n + 1, nil
+	}
+	size := dt.Size()
+	if size == 0 {
+		return nil, 0, fmt.Errorf("unsupported data type: %d", dt)
+	}
+	if len(data) < size {
+		return nil, 0, fmt.Errorf("%s requires %d bytes, got %d", dt, size, len(data))
+	}
+	data = data[:size]
+
+	switch dt {
+	case DataTypeInt32:
+		return int32(binary.LittleEndian.Uint32(data)), size, nil // #nosec G115 -- intentional reinterpretation of binary-encoded signed int32
+	case DataTypeInt64:
+		return int64(binary.LittleEndian.Uint64(data)), size, nil // #nosec G115 -- intentional reinterpretation of binary-encoded signed int64
+	case DataTypeFloat32:
+		return math.Float32frombits(binary.LittleEndian.Uint32(data)), size, nil
+	case DataTypeFloat64:
+		return math.Float64frombits(binary.LittleEndian.Uint64(data)), size, nil
+	case DataTypeMarkerState:
+		return MarkerState{
+			Name:  cString(data[:64]),
+			State: binary.LittleEndian.Uint32(data[64:68]),
+		}, size, nil
+	case DataTypeInitPosition:
+		var v InitPosition
+		err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &v)
+		return v, size, err
+	case DataTypeWaypoint:
+		var v Waypoint
+		err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &v)
+		return v, size, err
+	case DataTypeLatLonAlt:
+		var v LatLonAlt
+		err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &v)
+		return v, size, err
+	case DataTypeXYZ:
+		var v XYZ
+		err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &v)
+		return v, size, err
+	default: // fixed-length strings
+		return cString(data), size, nil
+	}
+}
+
+// ParseSimVarValues walks a data block laid out as defs, in order, and
+// decodes one value per definition. Variable-length strings make the offset
+// of every later value depend on the ones before it, so the block can only be
+// read front to back.
+func ParseSimVarValues(data []byte, defs []SimVarDef) ([]any, error) {
+	vals := make([]any, len(defs))
+	offset := 0
+	for i, def := range defs {
+		v, n, err := decodeValue(data[offset:], def.DataType)
+		if err != nil {
+			return nil, fmt.Errorf("%s at offset %d: %w", def.Name, offset, err)
+		}
+		vals[i] = v
+		offset += n
+	}
+	if offset != len(data) {
+		return nil, fmt.Errorf("data block has %d bytes, definitions use %d", len(data), offset)
+	}
+	return vals, nil
+}
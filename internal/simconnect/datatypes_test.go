@@ -0,0 +1,151 @@
+package simconnect
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func le(vals ...any) []byte {
+	var buf bytes.Buffer
+	for _, v := range vals {
+		if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+			panic(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func fixedString(s string, size int) []byte {
+	b := make([]byte, size)
+	copy(b, s)
+	return b
+}
+
+func TestParseSimVarValueTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		dt   DataType
+		want any
+	}{
+		{"int64", le(int64(-5_000_000_000)), DataTypeInt64, int64(-5_000_000_000)},
+		{"float32", le(float32(29.92)), DataTypeFloat32, float32(29.92)},
+		{"string8", fixedString("KSEA", 8), DataTypeString8, "KSEA"},
+		{"string32", fixedString("N172SP", 32), DataTypeString32, "N172SP"},
+		{"string256", fixedString("Cessna Skyhawk G1000 Asobo", 256), DataTypeString256, "Cessna Skyhawk G1000 Asobo"},
+		{"string260", fixedString("C:\\path", 260), DataTypeString260, "C:\\path"},
+		{"stringv", []byte("C172\x00"), DataTypeStringV, "C172"},
+		{
+			"latlonalt", le(47.45, -122.31, 130.0), DataTypeLatLonAlt,
+			LatLonAlt{Latitude: 47.45, Longitude: -122.31, Altitude: 130},
+		},
+		{"xyz", le(1.0, 2.0, 3.0), DataTypeXYZ, XYZ{X: 1, Y: 2, Z: 3}},
+		{
+			"waypoint", le(47.45, -122.31, 3000.0, uint32(0x4), 120.0, 75.0), DataTypeWaypoint,
+			Waypoint{Latitude: 47.45, Longitude: -122.31, Altitude: 3000, Flags: 0x4, SpeedKnots: 120, ThrottlePercent: 75},
+		},
+		{
+			"initposition", le(47.45, -122.31, 433.0, 0.0, 0.0, 163.0, uint32(1), uint32(0)), DataTypeInitPosition,
+			InitPosition{Latitude: 47.45, Longitude: -122.31, Altitude: 433, Heading: 163, OnGround: 1},
+		},
+		{
+			"markerstate", append(fixedString("Cockpit", 64), le(uint32(1))...), DataTypeMarkerState,
+			MarkerState{Name: "Cockpit", State: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Len(t, tt.data, max(tt.dt.Size(), len(tt.data)), "fixed-size test data matches the type size")
+			got, err := ParseSimVarValue(tt.data, tt.dt)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSimVarValueErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		dt   DataType
+	}{
+		{"unknown type", make([]byte, 8), DataType(99)},
+		{"short string", make([]byte, 31), DataTypeString32},
+		{"short struct", make([]byte, 16), DataTypeLatLonAlt},
+		{"unterminated stringv", []byte("C172"), DataTypeStringV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSimVarValue(tt.data, tt.dt)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseSimVarValuesWalksVariableLengthData(t *testing.T) {
+	defs := []SimVarDef{
+		{Name: "ATC MODEL", DataType: DataTypeStringV},
+		{Name: "PLANE ALTITUDE", Unit: "feet", DataType: DataTypeFloat64, Size: 8},
+		{Name: "ATC ID", DataType: DataTypeStringV},
+		{Name: "ATC FLIGHT NUMBER", DataType: DataTypeString8, Size: 8},
+	}
+	var data []byte
+	data = append(data, "TT:ATCCOM.AC_MODEL C172.0.text\x00"...)
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(4500))
+	data = append(data, "N172SP\x00"...)
+	data = append(data, fixedString("123", 8)...)
+
+	vals, err := ParseSimVarValues(data, defs)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"TT:ATCCOM.AC_MODEL C172.0.text", 4500.0, "N172SP", "123"}, vals)
+
+	_, err = ParseSimVarValues(data[:len(data)-1], defs)
+	assert.Error(t, err, "truncated block")
+	_, err = ParseSimVarValues(append(data, 0), defs)
+	assert.Error(t, err, "trailing bytes")
+}
+
+func TestDataTypeSize(t *testing.T) {
+	tests := []struct {
+		dt   DataType
+		want int
+	}{
+		{DataTypeInt32, 4}, {DataTypeInt64, 8}, {DataTypeFloat32, 4}, {DataTypeFloat64, 8},
+		{DataTypeString8, 8}, {DataTypeString32, 32}, {DataTypeString64, 64},
+		{DataTypeString128, 128}, {DataTypeString256, 256}, {DataTypeString260, 260},
+		{DataTypeStringV, 0}, {DataTypeInitPosition, 56}, {DataTypeMarkerState, 68},
+		{DataTypeWaypoint, 44}, {DataTypeLatLonAlt, 24}, {DataTypeXYZ, 24},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dt.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.dt.Size())
+		})
+	}
+}
+
+func TestQuerySimVarSizesMatchDataTypes(t *testing.T) {
+	for _, v := range QuerySimVars {
+		assert.Equal(t, v.DataType.Size(), v.Size, v.Name)
+		if v.DataType.IsString() || v.DataType.IsStruct() {
+			assert.Empty(t, v.Unit, v.Name)
+		}
+	}
+}
+
+func TestEncodeAddToDataDefinitionOmitsUnitForStrings(t *testing.T) {
+	p := encodeAddToDataDefinition(DefIDQueryBase, SimVarDef{Name: "TITLE", Unit: "number", DataType: DataTypeString256, Size: 256})
+	require.Len(t, p, 528)
+	assert.Equal(t, "TITLE", cString(p[4:260]))
+	assert.Equal(t, make([]byte, 256), p[260:516], "strings are registered without a unit")
+	assert.Equal(t, uint32(DataTypeString256), binary.LittleEndian.Uint32(p[516:520]))
+}
+
+func TestXYZAsPBH(t *testing.T) {
+	assert.Equal(t, PBH{Pitch: -2, Bank: 10, Heading: 270}, XYZ{X: -2, Y: 10, Z: 270}.PBH())
+}
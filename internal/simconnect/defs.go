@@ -1,5 +1,11 @@
 package simconnect
 
+import (
+	"fmt"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
 // Ordered SimVar slices for each data group.
 // The order determines the byte layout in SimObjectData responses.
 var (
@@ -15,16 +21,12 @@ var (
 		TurnCoordinatorBall, PlanePitch, PlaneBank,
 	}
 
-	EngineSimVars = []SimVarDef{
-		NumberOfEngines, ThrottlePosition1, ThrottlePosition2,
-		EngRPM1, EngRPM2, TurbEngN1_1, TurbEngN1_2, TurbEngN2_1, TurbEngN2_2,
-		FuelFlow1, FuelFlow2, EGT1, EGT2, OilTemp1, OilTemp2,
-		OilPressure1, OilPressure2, FuelTotalQuantity, FuelLeftQuantity, FuelRightQuantity,
-	}
+	EngineSimVars = engineGroupSimVars()
 
 	EnvironmentSimVars = []SimVarDef{
 		AmbientWindVelocity, AmbientWindDirection, AmbientTemperature,
 		AmbientPressure, AmbientVisibility, AmbientPrecipState, LocalTime, ZuluTime,
+		SeaLevelPressure, ZuluDayOfMonth,
 	}
 
 	AutopilotSimVars = []SimVarDef{
@@ -32,18 +34,180 @@ var (
 		APAltitudeLock, APVerticalHold, APAirspeedHold, APFlightDirector,
 		APHeadingLockDir, APAltitudeLockVar, APVerticalHoldVar, APAirspeedHoldVar,
 	}
+
+	AircraftSimVars = []SimVarDef{
+		Title, ATCID, ATCAirline, ATCFlightNumber, ATCModel, Category, EngineType, NumberOfEngines,
+		MaxGrossWeight, DesignSpeedVS0, DesignSpeedVS1, DesignSpeedVC, AirspeedBarberPole,
+	}
+
+	FlightControlsSimVars = []SimVarDef{
+		ElevatorPosition, AileronPosition, RudderPosition,
+		ElevatorTrimPosition, AileronTrimPct, RudderTrimPct,
+		FlapsHandleIndex, FlapsHandlePercent, SpoilersHandlePosition, SpoilersArmed,
+		GearHandlePosition, GearCenterPosition, GearLeftPosition, GearRightPosition,
+		BrakeParkingPosition,
+	}
+
+	SystemsSimVars = []SimVarDef{
+		FuelTotalQuantity, FuelTotalQuantityWeight,
+		FuelTankLeftMainQuantity, FuelTankRightMainQuantity, FuelTankCenterQuantity,
+		ElectricalMasterBattery, ElectricalMainBusVoltage, ElectricalBatteryVoltage, ElectricalBatteryLoad,
+		ElectricalGenAltBusVoltage1, ElectricalGenAltBusVoltage2,
+		HydraulicPressure1, HydraulicPressure2, HydraulicPressure3,
+		PressurizationCabinAltitude, PressurizationCabinAltitudeRate,
+		PressurizationPressureDifferential, PressurizationDumpSwitch,
+	}
+
+	NavigationSimVars = navigationGroupSimVars()
+
+	// TrafficSimVars is read from AI and multiplayer objects by QueryTraffic
+	// through a temporary definition; no data group polls it.
+	TrafficSimVars = []SimVarDef{
+		IsUserSim, ATCID, ATCAirline, ATCFlightNumber, ATCModel,
+		PlaneLatitude, PlaneLongitude, PlaneAltitude, PlaneHeadingTrue,
+		GroundVelocity, VerticalSpeed, SimOnGround,
+	}
+
+	// FlightPlanSimVars is read from the user aircraft by ReadFlightPlan
+	// through a temporary definition; no data group polls it.
+	FlightPlanSimVars = []SimVarDef{
+		GPSIsActiveFlightPlan, GPSFlightPlanWPCount, GPSFlightPlanWPIndex,
+		GPSWPPrevID, GPSWPPrevLat, GPSWPPrevLon,
+		GPSWPNextID, GPSWPNextLat, GPSWPNextLon, GPSWPNextAlt,
+		GPSWPDistance, GPSWPETE, GPSWPETA, GPSETE, GPSETA, GPSWPCrossTrk,
+	}
+
+	// WritableSimVars is the allowlist for SetDataOnSimObject. Each entry gets
+	// its own single-var definition, DefIDWriteBase plus its index.
+	WritableSimVars = []SimVarDef{
+		KohlsmanSettingHg,
+		FuelTankLeftMainQuantity, FuelTankRightMainQuantity, FuelTankCenterQuantity,
+		ComActiveFrequency1, ComStandbyFrequency1, ComActiveFrequency2, ComStandbyFrequency2,
+	}
+
+	// InitialPosition is the write-only SimVar SetInitPosition uses to place
+	// the user aircraft. It is not in the SimVarRegistry, so it cannot be
+	// read or written through the allowlist.
+	InitialPosition = SimVarDef{Name: "Initial Position", DataType: DataTypeInitPosition, Size: 56}
 )
 
 const (
-	DefIDPosition    uint32 = 1
-	ReqIDPosition    uint32 = 1
-	DefIDInstruments uint32 = 2
-	ReqIDInstruments uint32 = 2
-	DefIDEngine      uint32 = 3
-	ReqIDEngine      uint32 = 3
-	DefIDEnvironment uint32 = 4
-	ReqIDEnvironment uint32 = 4
-	DefIDAutopilot   uint32 = 5
-	ReqIDAutopilot   uint32 = 5
-	ObjectIDUser     uint32 = 0 // SIMCONNECT_OBJECT_ID_USER
+	DefIDPosition        uint32 = 1
+	ReqIDPosition        uint32 = 1
+	DefIDInstruments     uint32 = 2
+	ReqIDInstruments     uint32 = 2
+	DefIDEngine          uint32 = 3
+	ReqIDEngine          uint32 = 3
+	DefIDEnvironment     uint32 = 4
+	ReqIDEnvironment     uint32 = 4
+	DefIDAutopilot       uint32 = 5
+	ReqIDAutopilot       uint32 = 5
+	DefIDAircraft        uint32 = 6
+	ReqIDAircraft        uint32 = 6
+	ReqIDAircraftRefresh uint32 = 7 // one-off re-read of the aircraft group when a new aircraft loads
+	DefIDFlightControls  uint32 = 8
+	ReqIDFlightControls  uint32 = 8
+	DefIDSystems         uint32 = 9
+	ReqIDSystems         uint32 = 9
+	DefIDNavigation      uint32 = 10
+	ReqIDNavigation      uint32 = 10
+	DefIDWriteBase       uint32 = 100
+	DefIDQueryBase       uint32 = 200 // temporary definitions used by ReadSimVars
+	ReqIDQueryBase       uint32 = 200
+	DefIDFacilityAirport uint32 = 300 // facility definitions, registered on first use for each connection
+	DefIDFacilityILS     uint32 = 301
+	DefIDFacilityRetry   uint32 = 310  // facility definitions registered again after a failed registration
+	ReqIDFacilityBase    uint32 = 300  // facility list and data requests
+	ReqIDWeatherBase     uint32 = 400  // weather observation requests
+	DefIDInitPosition    uint32 = 500  // SetInitPosition's definition, registered on first use for each connection
+	ReqIDHeartbeat       uint32 = 1000 // RequestSystemState used by the connection heartbeat
+	ReqIDSimState        uint32 = 1001 // RequestSystemState("Sim") sent when the Poller subscribes to system events
+	ObjectIDUser         uint32 = 0    // SIMCONNECT_OBJECT_ID_USER
 )
+
+// perEngineSimVars lists the indexed SimVar families read for each engine, in
+// payload order. Their units come from indexedSimVars.
+var perEngineSimVars = []string{
+	"GENERAL ENG THROTTLE LEVER POSITION", "GENERAL ENG RPM", "TURB ENG N1", "TURB ENG N2",
+	"ENG FUEL FLOW GPH", "ENG EXHAUST GAS TEMPERATURE", "TURB ENG ITT", "ENG TORQUE",
+	"GENERAL ENG OIL TEMPERATURE", "GENERAL ENG OIL PRESSURE",
+}
+
+// engineGroupSimVars lays out the engine group: the engine count, every
+// perEngineSimVars family for engines 1 to types.MaxEngines, then the fuel
+// quantities. All engines are registered because the count is only known once
+// data arrives; ParseEnginePayload drops the engines the aircraft lacks.
+func engineGroupSimVars() []SimVarDef {
+	vars := make([]SimVarDef, 0, 4+types.MaxEngines*len(perEngineSimVars))
+	vars = append(vars, NumberOfEngines)
+	for n := 1; n <= types.MaxEngines; n++ {
+		for _, base := range perEngineSimVars {
+			vars = append(vars, indexedDef(base, n))
+		}
+	}
+	return append(vars, FuelTotalQuantity, FuelLeftQuantity, FuelRightQuantity)
+}
+
+// NavRadios is how many NAV receivers the navigation group reads.
+const NavRadios = 2
+
+// perNavRadioSimVars lists the indexed SimVar families read for each NAV
+// receiver, in payload order. Their units come from indexedSimVars.
+var perNavRadioSimVars = []string{
+	"NAV ACTIVE FREQUENCY", "NAV STANDBY FREQUENCY", "NAV OBS", "NAV RADIAL",
+	"NAV CDI", "NAV GSI", "NAV TOFROM", "NAV DME",
+	"NAV HAS NAV", "NAV HAS LOCALIZER", "NAV HAS GLIDE SLOPE",
+}
+
+// navigationGroupSimVars lays out the navigation group: every
+// perNavRadioSimVars family for NAV1 and NAV2, then ADF1, COM1 and COM2, the
+// transponder and the GPS guidance to the next waypoint.
+func navigationGroupSimVars() []SimVarDef {
+	vars := make([]SimVarDef, 0, NavRadios*len(perNavRadioSimVars)+12)
+	for n := 1; n <= NavRadios; n++ {
+		for _, base := range perNavRadioSimVars {
+			vars = append(vars, indexedDef(base, n))
+		}
+	}
+	return append(vars,
+		indexedDef("ADF ACTIVE FREQUENCY", 1), indexedDef("ADF RADIAL", 1),
+		ComActiveFrequency1, ComStandbyFrequency1, ComActiveFrequency2, ComStandbyFrequency2,
+		indexedDef("TRANSPONDER CODE", 1),
+		GPSCourseToSteer, GPSWPDesiredTrack, GPSWPDistance, GPSWPETE, GPSWPNextID,
+	)
+}
+
+// definitionNames names the data definitions for diagnostics.
+var definitionNames = map[uint32]string{
+	DefIDPosition:       "position",
+	DefIDInstruments:    "instruments",
+	DefIDEngine:         "engine",
+	DefIDEnvironment:    "environment",
+	DefIDAutopilot:      "autopilot",
+	DefIDAircraft:       "aircraft",
+	DefIDFlightControls: "flight_controls",
+	DefIDSystems:        "systems",
+	DefIDNavigation:     "navigation",
+
+	DefIDFacilityAirport: "airport_facility",
+	DefIDFacilityILS:     "ils_facility",
+	DefIDInitPosition:    "init_position",
+}
+
+// DefinitionName returns a short name for a data definition ID, such as
+// "position" or "write" for the per-SimVar write definitions.
+func DefinitionName(defID uint32) string {
+	if name, ok := definitionNames[defID]; ok {
+		return name
+	}
+	if isQueryDefinition(defID) {
+		return "query"
+	}
+	if defID >= DefIDFacilityRetry && defID < DefIDFacilityRetry+querySlots {
+		return "facility"
+	}
+	if defID >= DefIDWriteBase {
+		return "write"
+	}
+	return fmt.Sprintf("definition_%d", defID)
+}
@@ -6,5 +6,28 @@ var (
 	ErrNotConnected      = errors.New("simconnect: not connected")
 	ErrTimeout           = errors.New("simconnect: connection timeout")
 	ErrInvalidSimVar     = errors.New("simconnect: invalid simvar")
+	ErrInvalidEvent      = errors.New("simconnect: invalid sim event")
+	ErrSimVarNotWritable = errors.New("simconnect: simvar is not writable")
+	ErrException         = errors.New("simconnect: exception")
 	ErrConnectionRefused = errors.New("simconnect: connection refused")
+	ErrHeartbeatTimeout  = errors.New("simconnect: heartbeat timeout")
+	ErrInvalidUnit       = errors.New("simconnect: invalid unit")
+	ErrFacilityNotFound  = errors.New("simconnect: facility not found")
+	ErrNoObservation     = errors.New("simconnect: no weather observation")
+	ErrInvalidPath       = errors.New("simconnect: invalid file name")
+)
+
+// Named errors for common SimConnect exception codes. An *Exception matches
+// the one for its code with errors.Is.
+var (
+	ErrSimConnectError  = errors.New("simconnect: ERROR")
+	ErrSizeMismatch     = errors.New("simconnect: SIZE_MISMATCH")
+	ErrUnrecognizedID   = errors.New("simconnect: UNRECOGNIZED_ID")
+	ErrUnopened         = errors.New("simconnect: UNOPENED")
+	ErrVersionMismatch  = errors.New("simconnect: VERSION_MISMATCH")
+	ErrNameUnrecognized = errors.New("simconnect: NAME_UNRECOGNIZED")
+	ErrInvalidDataType  = errors.New("simconnect: INVALID_DATA_TYPE")
+	ErrInvalidDataSize  = errors.New("simconnect: INVALID_DATA_SIZE")
+	ErrDataError        = errors.New("simconnect: DATA_ERROR")
+	ErrLoadFlightPlan   = errors.New("simconnect: LOAD_FLIGHTPLAN_FAILED")
 )
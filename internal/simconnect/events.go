@@ -0,0 +1,116 @@
+package simconnect
+
+import "fmt"
+
+// Sim event names the client is allowed to transmit (Event IDs in the MSFS SDK).
+const (
+	EventAutopilotOn        = "AUTOPILOT_ON"
+	EventAutopilotOff       = "AUTOPILOT_OFF"
+	EventAPMaster           = "AP_MASTER"
+	EventAPHeadingHoldOn    = "AP_HDG_HOLD_ON"
+	EventAPHeadingHoldOff   = "AP_HDG_HOLD_OFF"
+	EventAPNav1HoldOn       = "AP_NAV1_HOLD_ON"
+	EventAPNav1HoldOff      = "AP_NAV1_HOLD_OFF"
+	EventAPApproachHoldOn   = "AP_APR_HOLD_ON"
+	EventAPApproachHoldOff  = "AP_APR_HOLD_OFF"
+	EventAPAltitudeHoldOn   = "AP_ALT_HOLD_ON"
+	EventAPAltitudeHoldOff  = "AP_ALT_HOLD_OFF"
+	EventAPVerticalSpeedOn  = "AP_VS_ON"
+	EventAPVerticalSpeedOff = "AP_VS_OFF"
+	EventAPAirspeedOn       = "AP_AIRSPEED_ON"
+	EventAPAirspeedOff      = "AP_AIRSPEED_OFF"
+	EventHeadingBugSet      = "HEADING_BUG_SET"
+	EventAPAltitudeVarSet   = "AP_ALT_VAR_SET_ENGLISH"
+	EventAPVSVarSet         = "AP_VS_VAR_SET_ENGLISH"
+	EventAPSpeedVarSet      = "AP_SPD_VAR_SET"
+	EventToggleFD           = "TOGGLE_FLIGHT_DIRECTOR"
+	EventGearToggle         = "GEAR_TOGGLE"
+	EventGearUp             = "GEAR_UP"
+	EventGearDown           = "GEAR_DOWN"
+	EventParkingBrakes      = "PARKING_BRAKES"
+	EventFlapsUp            = "FLAPS_UP"
+	EventFlapsDown          = "FLAPS_DOWN"
+	EventFlapsIncr          = "FLAPS_INCR"
+	EventFlapsDecr          = "FLAPS_DECR"
+	EventCom1ActiveSetHz    = "COM_RADIO_SET_HZ"
+	EventCom1StandbySetHz   = "COM_STBY_RADIO_SET_HZ"
+	EventCom1Swap           = "COM_STBY_RADIO_SWAP"
+	EventCom2ActiveSetHz    = "COM2_RADIO_SET_HZ"
+	EventCom2StandbySetHz   = "COM2_STBY_RADIO_SET_HZ"
+	EventCom2Swap           = "COM2_RADIO_SWAP"
+	EventNav1ActiveSetHz    = "NAV1_RADIO_SET_HZ"
+	EventNav1StandbySetHz   = "NAV1_STBY_SET_HZ"
+	EventNav1Swap           = "NAV1_RADIO_SWAP"
+	EventNav2ActiveSetHz    = "NAV2_RADIO_SET_HZ"
+	EventNav2StandbySetHz   = "NAV2_STBY_SET_HZ"
+	EventNav2Swap           = "NAV2_RADIO_SWAP"
+	EventTransponderSet     = "XPNDR_SET"
+)
+
+// Event flags for TransmitClientEvent (SIMCONNECT_EVENT_FLAG_*).
+const (
+	EventFlagDefault           uint32 = 0x00
+	EventFlagGroupIDIsPriority uint32 = 0x10
+)
+
+// Notification group priorities (SIMCONNECT_GROUP_PRIORITY_*).
+const (
+	GroupPriorityHighest         uint32 = 1
+	GroupPriorityHighestMaskable uint32 = 10000000
+	GroupPriorityStandard        uint32 = 1900000000
+	GroupPriorityDefault         uint32 = 2000000000
+	GroupPriorityLowest          uint32 = 4000000000
+)
+
+// GroupIDDefault is the notification group all allowlisted events are added to.
+const GroupIDDefault uint32 = 1
+
+// eventIDBase is the first client event ID assigned by the EventRegistry.
+const eventIDBase uint32 = 1000
+
+// EventRegistry holds the allowlist of sim events and assigns each a stable
+// client event ID.
+type EventRegistry struct {
+	ids map[string]uint32
+}
+
+// NewEventRegistry creates a registry with all allowlisted sim events.
+func NewEventRegistry() *EventRegistry {
+	r := &EventRegistry{ids: make(map[string]uint32)}
+	for i, name := range []string{
+		// Autopilot modes
+		EventAutopilotOn, EventAutopilotOff, EventAPMaster,
+		EventAPHeadingHoldOn, EventAPHeadingHoldOff, EventAPNav1HoldOn, EventAPNav1HoldOff,
+		EventAPApproachHoldOn, EventAPApproachHoldOff, EventAPAltitudeHoldOn, EventAPAltitudeHoldOff,
+		EventAPVerticalSpeedOn, EventAPVerticalSpeedOff, EventAPAirspeedOn, EventAPAirspeedOff,
+		// Autopilot targets
+		EventHeadingBugSet, EventAPAltitudeVarSet, EventAPVSVarSet, EventAPSpeedVarSet,
+		EventToggleFD,
+		// Gear, brakes and flaps
+		EventGearToggle, EventGearUp, EventGearDown, EventParkingBrakes,
+		EventFlapsUp, EventFlapsDown, EventFlapsIncr, EventFlapsDecr,
+		// Radios and transponder
+		EventCom1ActiveSetHz, EventCom1StandbySetHz, EventCom1Swap,
+		EventCom2ActiveSetHz, EventCom2StandbySetHz, EventCom2Swap,
+		EventNav1ActiveSetHz, EventNav1StandbySetHz, EventNav1Swap,
+		EventNav2ActiveSetHz, EventNav2StandbySetHz, EventNav2Swap,
+		EventTransponderSet,
+	} {
+		r.ids[name] = eventIDBase + uint32(i) // #nosec G115 -- allowlist is small
+	}
+	return r
+}
+
+// ID returns the client event ID for the given sim event name, if allowlisted.
+func (r *EventRegistry) ID(name string) (uint32, bool) {
+	id, ok := r.ids[name]
+	return id, ok
+}
+
+// Validate checks if a sim event name is in the allowlist.
+func (r *EventRegistry) Validate(name string) error {
+	if _, ok := r.ids[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidEvent, name)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package simconnect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventRegistryValidate(t *testing.T) {
+	r := NewEventRegistry()
+
+	tests := []struct {
+		name    string
+		event   string
+		wantErr bool
+	}{
+		{name: "autopilot master", event: EventAPMaster},
+		{name: "heading bug", event: EventHeadingBugSet},
+		{name: "gear toggle", event: EventGearToggle},
+		{name: "com standby", event: EventCom1StandbySetHz},
+		{name: "transponder", event: EventTransponderSet},
+		{name: "unknown event", event: "SIM_RATE_INCR", wantErr: true},
+		{name: "empty name", event: "", wantErr: true},
+		{name: "case sensitive", event: "ap_master", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Validate(tt.event)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidEvent)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestEventRegistryIDsAreUniqueAndStable(t *testing.T) {
+	r1 := NewEventRegistry()
+	r2 := NewEventRegistry()
+
+	seen := make(map[uint32]string)
+	for name, id := range r1.ids {
+		other, dup := seen[id]
+		require.False(t, dup, "%s and %s share event ID %d", name, other, id)
+		seen[id] = name
+
+		id2, ok := r2.ID(name)
+		require.True(t, ok)
+		assert.Equal(t, id, id2)
+	}
+}
@@ -0,0 +1,118 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// exceptionPayloadSize is the size of a SIMCONNECT_RECV_EXCEPTION payload.
+const exceptionPayloadSize = 12
+
+// exceptionNames maps SIMCONNECT_EXCEPTION values to their SDK names.
+var exceptionNames = map[uint32]string{
+	0:  "NONE",
+	1:  "ERROR",
+	2:  "SIZE_MISMATCH",
+	3:  "UNRECOGNIZED_ID",
+	4:  "UNOPENED",
+	5:  "VERSION_MISMATCH",
+	6:  "TOO_MANY_GROUPS",
+	7:  "NAME_UNRECOGNIZED",
+	8:  "TOO_MANY_EVENT_NAMES",
+	9:  "EVENT_ID_DUPLICATE",
+	10: "TOO_MANY_MAPS",
+	11: "TOO_MANY_OBJECTS",
+	12: "TOO_MANY_REQUESTS",
+	13: "WEATHER_INVALID_PORT",
+	14: "WEATHER_INVALID_METAR",
+	15: "WEATHER_UNABLE_TO_GET_OBSERVATION",
+	16: "WEATHER_UNABLE_TO_CREATE_STATION",
+	17: "WEATHER_UNABLE_TO_REMOVE_STATION",
+	18: "INVALID_DATA_TYPE",
+	19: "INVALID_DATA_SIZE",
+	20: "DATA_ERROR",
+	21: "INVALID_ARRAY",
+	22: "CREATE_OBJECT_FAILED",
+	23: "LOAD_FLIGHTPLAN_FAILED",
+	24: "OPERATION_INVALID_FOR_OBJECT_TYPE",
+	25: "ILLEGAL_OPERATION",
+	26: "ALREADY_SUBSCRIBED",
+	27: "INVALID_ENUM",
+	28: "DEFINITION_ERROR",
+	29: "DUPLICATE_ID",
+	30: "DATUM_ID",
+	31: "OUT_OF_BOUNDS",
+	32: "ALREADY_CREATED",
+	33: "OBJECT_OUTSIDE_REALITY_BUBBLE",
+	34: "OBJECT_CONTAINER",
+	35: "OBJECT_AI",
+	36: "OBJECT_ATC",
+	37: "OBJECT_SCHEDULE",
+}
+
+// exceptionErrors maps the exception codes callers commonly handle to named errors.
+var exceptionErrors = map[uint32]error{
+	ExceptionError:            ErrSimConnectError,
+	ExceptionSizeMismatch:     ErrSizeMismatch,
+	ExceptionUnrecognizedID:   ErrUnrecognizedID,
+	ExceptionUnopened:         ErrUnopened,
+	ExceptionVersionMismatch:  ErrVersionMismatch,
+	ExceptionNameUnrecognized: ErrNameUnrecognized,
+	ExceptionInvalidDataType:  ErrInvalidDataType,
+	ExceptionInvalidDataSize:  ErrInvalidDataSize,
+	ExceptionDataError:        ErrDataError,
+	ExceptionLoadFlightPlan:   ErrLoadFlightPlan,
+}
+
+// ExceptionName returns the SDK name for a SIMCONNECT_EXCEPTION code.
+func ExceptionName(code uint32) string {
+	if name, ok := exceptionNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN_%d", code)
+}
+
+// Exception is a SimConnect exception correlated with the message that
+// caused it. It matches ErrException and, for common codes, a named error
+// such as ErrNameUnrecognized with errors.Is.
+type Exception struct {
+	Code     uint32      // SIMCONNECT_EXCEPTION value
+	SendID   uint32      // send ID of the offending client message
+	Index    uint32      // index of the offending parameter, if known
+	Message  SentMessage // the offending message; zero if it was not recorded
+	Received time.Time
+}
+
+// Name returns the SDK name of the exception code.
+func (e *Exception) Name() string {
+	return ExceptionName(e.Code)
+}
+
+func (e *Exception) Error() string {
+	if e.Message.Type == 0 {
+		return fmt.Sprintf("simconnect: exception %s (code=%d) sendID=%d index=%d", e.Name(), e.Code, e.SendID, e.Index)
+	}
+	return fmt.Sprintf("simconnect: exception %s (code=%d) for %s sendID=%d index=%d",
+		e.Name(), e.Code, e.Message, e.SendID, e.Index)
+}
+
+// Unwrap lets errors.Is match ErrException and the code's named error.
+func (e *Exception) Unwrap() []error {
+	if named, ok := exceptionErrors[e.Code]; ok {
+		return []error{ErrException, named}
+	}
+	return []error{ErrException}
+}
+
+// ParseException decodes a SIMCONNECT_RECV_EXCEPTION payload.
+func ParseException(data []byte) (*Exception, error) {
+	if len(data) < exceptionPayloadSize {
+		return nil, fmt.Errorf("exception payload too short: got %d bytes, need %d", len(data), exceptionPayloadSize)
+	}
+	return &Exception{
+		Code:   binary.LittleEndian.Uint32(data[0:4]),
+		SendID: binary.LittleEndian.Uint32(data[4:8]),
+		Index:  binary.LittleEndian.Uint32(data[8:12]),
+	}, nil
+}
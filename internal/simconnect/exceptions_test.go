@@ -0,0 +1,68 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseException(t *testing.T) {
+	data := binary.LittleEndian.AppendUint32(nil, ExceptionNameUnrecognized)
+	data = binary.LittleEndian.AppendUint32(data, 42)
+	data = binary.LittleEndian.AppendUint32(data, 3)
+
+	exc, err := ParseException(data)
+	require.NoError(t, err)
+	assert.Equal(t, &Exception{Code: ExceptionNameUnrecognized, SendID: 42, Index: 3}, exc)
+	assert.True(t, errors.Is(exc, ErrException))
+	assert.Contains(t, exc.Error(), "sendID=42")
+}
+
+func TestParseExceptionTooShort(t *testing.T) {
+	_, err := ParseException(make([]byte, 8))
+	assert.Error(t, err)
+}
+
+func TestExceptionNamedErrors(t *testing.T) {
+	tests := []struct {
+		code uint32
+		name string
+		want error
+	}{
+		{ExceptionError, "ERROR", ErrSimConnectError},
+		{ExceptionSizeMismatch, "SIZE_MISMATCH", ErrSizeMismatch},
+		{ExceptionUnrecognizedID, "UNRECOGNIZED_ID", ErrUnrecognizedID},
+		{ExceptionNameUnrecognized, "NAME_UNRECOGNIZED", ErrNameUnrecognized},
+		{ExceptionDataError, "DATA_ERROR", ErrDataError},
+		{ExceptionInvalidDataSize, "INVALID_DATA_SIZE", ErrInvalidDataSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exc := &Exception{Code: tt.code}
+			assert.Equal(t, tt.name, exc.Name())
+			assert.ErrorIs(t, exc, tt.want)
+			assert.ErrorIs(t, exc, ErrException)
+		})
+	}
+}
+
+func TestExceptionUnknownCode(t *testing.T) {
+	exc := &Exception{Code: 999}
+	assert.Equal(t, "UNKNOWN_999", exc.Name())
+	assert.ErrorIs(t, exc, ErrException)
+	assert.NotErrorIs(t, exc, ErrDataError)
+}
+
+func TestExceptionErrorDescribesMessage(t *testing.T) {
+	exc := &Exception{
+		Code:    ExceptionNameUnrecognized,
+		SendID:  7,
+		Message: SentMessage{SendID: 7, Type: SendAddToDataDef, DefID: DefIDPosition, SimVar: "PLANE LATITUDE"},
+	}
+	msg := exc.Error()
+	assert.Contains(t, msg, "NAME_UNRECOGNIZED")
+	assert.Contains(t, msg, `ADD_TO_DATA_DEFINITION "PLANE LATITUDE" def=1`)
+}
@@ -0,0 +1,429 @@
+package simconnect
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// FacilityListType selects the facility cache RequestFacilitiesList reports
+// (SIMCONNECT_FACILITY_LIST_TYPE).
+type FacilityListType uint32
+
+const (
+	FacilityListAirport  FacilityListType = 0
+	FacilityListWaypoint FacilityListType = 1
+	FacilityListNDB      FacilityListType = 2
+	FacilityListVOR      FacilityListType = 3
+)
+
+// Facility data types reported in RecvFacilityData (SIMCONNECT_FACILITY_DATA_TYPE).
+const (
+	FacilityDataAirport   uint32 = 0
+	FacilityDataRunway    uint32 = 1
+	FacilityDataFrequency uint32 = 3
+	FacilityDataVOR       uint32 = 19
+)
+
+// facilityListHeaderSize is the size of the SIMCONNECT_RECV_FACILITIES_LIST
+// fields before the entries: requestID, arraySize, entryNumber, outOf.
+const facilityListHeaderSize = 16
+
+// facilityDataHeaderSize is the size of the SIMCONNECT_RECV_FACILITY_DATA
+// fields before the data: userRequestID, uniqueRequestID,
+// parentUniqueRequestID, type, isListItem, itemIndex, listSize.
+const facilityDataHeaderSize = 28
+
+// facilityBlock is one OPEN…CLOSE level of a facility definition: the fields
+// read for that facility type, in order, and the blocks nested inside it.
+type facilityBlock struct {
+	name     string
+	fields   []SimVarDef
+	children []facilityBlock
+}
+
+// fieldNames returns the names AddToFacilityDefinition is called with for b.
+func (b facilityBlock) fieldNames() []string {
+	names := []string{"OPEN " + b.name}
+	for _, f := range b.fields {
+		names = append(names, f.Name)
+	}
+	for _, child := range b.children {
+		names = append(names, child.fieldNames()...)
+	}
+	return append(names, "CLOSE "+b.name)
+}
+
+func facilityField(name string, dt DataType) SimVarDef {
+	return SimVarDef{Name: name, DataType: dt, Size: dt.Size()}
+}
+
+var (
+	airportFacilityFields = []SimVarDef{
+		facilityField("LATITUDE", DataTypeFloat64),
+		facilityField("LONGITUDE", DataTypeFloat64),
+		facilityField("ALTITUDE", DataTypeFloat64),
+		facilityField("MAGVAR", DataTypeFloat32),
+		facilityField("NAME64", DataTypeString64),
+		facilityField("ICAO", DataTypeString8),
+		facilityField("REGION", DataTypeString8),
+	}
+
+	runwayFacilityFields = []SimVarDef{
+		facilityField("LATITUDE", DataTypeFloat64),
+		facilityField("LONGITUDE", DataTypeFloat64),
+		facilityField("ALTITUDE", DataTypeFloat64),
+		facilityField("HEADING", DataTypeFloat32),
+		facilityField("LENGTH", DataTypeFloat32),
+		facilityField("WIDTH", DataTypeFloat32),
+		facilityField("SURFACE", DataTypeInt32),
+		facilityField("PRIMARY_NUMBER", DataTypeInt32),
+		facilityField("PRIMARY_DESIGNATOR", DataTypeInt32),
+		facilityField("PRIMARY_ILS_ICAO", DataTypeString8),
+		facilityField("PRIMARY_ILS_REGION", DataTypeString8),
+		facilityField("SECONDARY_NUMBER", DataTypeInt32),
+		facilityField("SECONDARY_DESIGNATOR", DataTypeInt32),
+		facilityField("SECONDARY_ILS_ICAO", DataTypeString8),
+		facilityField("SECONDARY_ILS_REGION", DataTypeString8),
+	}
+
+	frequencyFacilityFields = []SimVarDef{
+		facilityField("TYPE", DataTypeInt32),
+		facilityField("FREQUENCY", DataTypeInt32),
+		facilityField("NAME", DataTypeString64),
+	}
+
+	ilsFacilityFields = []SimVarDef{
+		facilityField("FREQUENCY", DataTypeInt32),
+		facilityField("LOCALIZER", DataTypeFloat32),
+		facilityField("GS_ANGLE", DataTypeFloat32),
+		facilityField("HAS_GLIDE_SLOPE", DataTypeInt32),
+		facilityField("NAME", DataTypeString64),
+	}
+
+	// airportFacility is registered as DefIDFacilityAirport and read by AirportInfo.
+	airportFacility = facilityBlock{
+		name:   "AIRPORT",
+		fields: airportFacilityFields,
+		children: []facilityBlock{
+			{name: "RUNWAY", fields: runwayFacilityFields},
+			{name: "FREQUENCY", fields: frequencyFacilityFields},
+		},
+	}
+
+	// ilsFacility is registered as DefIDFacilityILS; localizers are VOR facilities.
+	ilsFacility = facilityBlock{name: "VOR", fields: ilsFacilityFields}
+)
+
+// facilityRecord is one RecvFacilityData message: the data of one OPEN…CLOSE
+// block, laid out as that block's fields.
+type facilityRecord struct {
+	dataType uint32
+	data     []byte
+}
+
+// facilityCollector gathers the replies to one facility list or data request.
+// Its fields are guarded by Client.pendingMu.
+type facilityCollector struct {
+	facilities []types.Facility
+	records    []facilityRecord
+	err        error
+	done       chan struct{}
+}
+
+// RequestFacilitiesList sends a REQUEST_FACILITIES_LIST message for the
+// facilities of listType cached around the user aircraft. The reply arrives
+// as one or more RecvAirportList, RecvVORList, RecvNDBList or
+// RecvWaypointList messages.
+func (c *Client) RequestFacilitiesList(listType FacilityListType, requestID uint32) error {
+	return c.sendMessage(SendRequestFacilitiesList, encodeRequestFacilitiesList(listType, requestID))
+}
+
+// encodeRequestFacilitiesList builds a REQUEST_FACILITIES_LIST payload (8 bytes):
+//
+//	int32: listType, requestID
+func encodeRequestFacilitiesList(listType FacilityListType, requestID uint32) []byte {
+	payload := make([]byte, 0, 8)
+	payload = binary.LittleEndian.AppendUint32(payload, uint32(listType))
+	return binary.LittleEndian.AppendUint32(payload, requestID)
+}
+
+// AddToFacilityDefinition sends an ADD_TO_FACILITY_DEFINITION message that
+// appends a field, or an OPEN/CLOSE marker, to a facility definition.
+func (c *Client) AddToFacilityDefinition(defID uint32, field string) error {
+	return c.sendMessage(SendAddToFacilityDef, encodeAddToFacilityDefinition(defID, field))
+}
+
+// encodeAddToFacilityDefinition builds an ADD_TO_FACILITY_DEFINITION payload (260 bytes):
+//
+//	int32:     defID
+//	char[256]: field name (zero-padded)
+func encodeAddToFacilityDefinition(defID uint32, field string) []byte {
+	payload := make([]byte, 0, 260)
+	payload = binary.LittleEndian.AppendUint32(payload, defID)
+	name := make([]byte, 256)
+	copy(name, field)
+	return append(payload, name...)
+}
+
+// RequestFacilityData sends a REQUEST_FACILITY_DATA message for the facility
+// with the given ICAO ident and region, read through facility definition
+// defID. Airports need no region.
+func (c *Client) RequestFacilityData(defID, requestID uint32, icao, region string) error {
+	return c.sendMessage(SendRequestFacilityData, encodeRequestFacilityData(defID, requestID, icao, region))
+}
+
+// encodeRequestFacilityData builds a REQUEST_FACILITY_DATA payload (28 bytes):
+//
+//	int32:    defID, requestID
+//	char[16]: ICAO ident (zero-padded)
+//	char[4]:  region (zero-padded)
+func encodeRequestFacilityData(defID, requestID uint32, icao, region string) []byte {
+	payload := make([]byte, 0, 28)
+	payload = binary.LittleEndian.AppendUint32(payload, defID)
+	payload = binary.LittleEndian.AppendUint32(payload, requestID)
+	ident := make([]byte, 16)
+	copy(ident, icao)
+	reg := make([]byte, 4)
+	copy(reg, region)
+	payload = append(payload, ident...)
+	return append(payload, reg...)
+}
+
+// nextFacilityRequest returns the request ID for the next facility request.
+func (c *Client) nextFacilityRequest() uint32 {
+	return ReqIDFacilityBase + c.facilitySeq.Add(1)%querySlots
+}
+
+// ListFacilities returns the facilities of listType in the simulator's cache,
+// which covers roughly the reality bubble around the user aircraft. Like
+// ReadSimVars it needs a reader running ReadNext.
+func (c *Client) ListFacilities(ctx context.Context, listType FacilityListType) ([]types.Facility, error) {
+	reqID := c.nextFacilityRequest()
+	col := c.trackFacilityRequest(reqID)
+	defer c.untrackFacilityRequest(reqID)
+
+	excs := make(chan *Exception, 1)
+	c.mu.Lock()
+	sendID, err := c.sendTrackedLocked(SendRequestFacilitiesList, encodeRequestFacilitiesList(listType, reqID), excs)
+	c.mu.Unlock()
+	defer c.untrackExceptions(sendID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.awaitFacilityRequest(ctx, col, excs); err != nil {
+		return nil, err
+	}
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	return col.facilities, col.err
+}
+
+// AirportInfo reads an airport with its runways and frequencies, and the
+// localizer of each runway end that has an ILS. It returns an error matching
+// ErrFacilityNotFound when the simulator has no airport with that ident.
+func (c *Client) AirportInfo(ctx context.Context, icao string) (types.Airport, error) {
+	icao = strings.ToUpper(strings.TrimSpace(icao))
+	records, err := c.readFacility(ctx, DefIDFacilityAirport, airportFacility, icao, "")
+	if err != nil {
+		return types.Airport{}, err
+	}
+	airport, err := decodeAirport(records)
+	if err != nil {
+		return types.Airport{}, fmt.Errorf("airport %s: %w", icao, err)
+	}
+
+	for i := range airport.Runways {
+		rw := &airport.Runways[i]
+		for _, ils := range []*types.ILS{&rw.PrimaryILS, &rw.SecondaryILS} {
+			if ils.Ident == "" {
+				continue
+			}
+			// A localizer that cannot be read leaves its frequency unset
+			// rather than failing the whole airport.
+			records, err := c.readFacility(ctx, DefIDFacilityILS, ilsFacility, ils.Ident, ils.Region)
+			if ctx.Err() != nil {
+				return types.Airport{}, ctx.Err()
+			}
+			if err == nil {
+				_ = decodeILS(records, ils)
+			}
+		}
+	}
+	return airport, nil
+}
+
+// readFacility requests one facility through the facility definition defID,
+// registering block as that definition on first use for each connection, and
+// returns the data records up to RecvFacilityDataEnd. SimConnect cannot
+// clear a facility definition, so one whose registration fails part way is
+// abandoned and the next call registers block under a fresh ID from
+// DefIDFacilityRetry, rather than adding the first fields twice.
+func (c *Client) readFacility(ctx context.Context, defID uint32, block facilityBlock, icao, region string) ([]facilityRecord, error) {
+	reqID := c.nextFacilityRequest()
+	col := c.trackFacilityRequest(reqID)
+	defer c.untrackFacilityRequest(reqID)
+
+	names := block.fieldNames()
+	excs := make(chan *Exception, len(names)+1)
+	sendIDs := make([]uint32, 0, len(names)+1)
+	defer func() { c.untrackExceptions(sendIDs...) }()
+
+	var requestSendID, registered uint32
+	c.mu.Lock()
+	err := func() error {
+		facilityDefID, ok := c.facilityDefs[defID]
+		if !ok || facilityDefID == 0 {
+			facilityDefID = defID
+			if ok {
+				if c.facilityRetries == querySlots {
+					return fmt.Errorf("simconnect: %s definition failed %d times on this connection", DefinitionName(defID), querySlots)
+				}
+				facilityDefID = DefIDFacilityRetry + c.facilityRetries
+				c.facilityRetries++
+			}
+			c.facilityDefs[defID] = facilityDefID
+			registered = facilityDefID
+			for _, name := range names {
+				id, err := c.sendTrackedLocked(SendAddToFacilityDef, encodeAddToFacilityDefinition(facilityDefID, name), excs)
+				sendIDs = append(sendIDs, id)
+				if err != nil {
+					c.abandonFacilityDefLocked(defID, facilityDefID)
+					return err
+				}
+			}
+		}
+		id, err := c.sendTrackedLocked(SendRequestFacilityData, encodeRequestFacilityData(facilityDefID, reqID, icao, region), excs)
+		sendIDs = append(sendIDs, id)
+		requestSendID = id
+		return err
+	}()
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.awaitFacilityRequest(ctx, col, excs); err != nil {
+		var exc *Exception
+		if errors.As(err, &exc) && exc.SendID == requestSendID {
+			return nil, fmt.Errorf("%w: %s: %w", ErrFacilityNotFound, icao, err)
+		}
+		if exc != nil && registered != 0 {
+			// A field of the definition was rejected.
+			c.mu.Lock()
+			c.abandonFacilityDefLocked(defID, registered)
+			c.mu.Unlock()
+		}
+		return nil, err
+	}
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	return col.records, nil
+}
+
+// abandonFacilityDefLocked marks the definition facilityDefID, registered
+// for defID, as incomplete so that the next use registers defID again under
+// a fresh ID. Caller must hold c.mu.
+func (c *Client) abandonFacilityDefLocked(defID, facilityDefID uint32) {
+	if c.facilityDefs[defID] == facilityDefID {
+		c.facilityDefs[defID] = 0
+	}
+}
+
+func (c *Client) trackFacilityRequest(reqID uint32) *facilityCollector {
+	col := &facilityCollector{done: make(chan struct{})}
+	c.pendingMu.Lock()
+	c.facilityReplies[reqID] = col
+	c.pendingMu.Unlock()
+	return col
+}
+
+func (c *Client) untrackFacilityRequest(reqID uint32) {
+	c.pendingMu.Lock()
+	delete(c.facilityReplies, reqID)
+	c.pendingMu.Unlock()
+}
+
+// awaitFacilityRequest waits until col is complete, an exception arrives for
+// one of its messages, or ctx is done.
+func (c *Client) awaitFacilityRequest(ctx context.Context, col *facilityCollector, excs <-chan *Exception) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case exc := <-excs:
+		return exc
+	case <-col.done:
+		select {
+		case exc := <-excs:
+			return exc
+		default:
+			return nil
+		}
+	}
+}
+
+// deliverFacilityList adds a facility list page to the ListFacilities call
+// waiting on its request ID, if any, and completes the call on the last page.
+// Pages are numbered from 0; an empty cache is reported as a single empty page.
+func (c *Client) deliverFacilityList(recvType uint32, data []byte) {
+	if len(data) < facilityListHeaderSize {
+		return
+	}
+	reqID := binary.LittleEndian.Uint32(data[0:4])
+	entry := binary.LittleEndian.Uint32(data[8:12])
+	outOf := binary.LittleEndian.Uint32(data[12:16])
+
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	col, ok := c.facilityReplies[reqID]
+	if !ok {
+		return
+	}
+	facilities, err := decodeFacilityList(recvType, data)
+	if err != nil && col.err == nil {
+		col.err = err
+	}
+	col.facilities = append(col.facilities, facilities...)
+	if entry+1 >= outOf {
+		close(col.done)
+		delete(c.facilityReplies, reqID)
+	}
+}
+
+// deliverFacilityData adds a RecvFacilityData record to the request waiting
+// on its user request ID, if any.
+func (c *Client) deliverFacilityData(data []byte) {
+	if len(data) < facilityDataHeaderSize {
+		return
+	}
+	reqID := binary.LittleEndian.Uint32(data[0:4])
+
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	if col, ok := c.facilityReplies[reqID]; ok {
+		col.records = append(col.records, facilityRecord{
+			dataType: binary.LittleEndian.Uint32(data[12:16]),
+			data:     data[facilityDataHeaderSize:],
+		})
+	}
+}
+
+// deliverFacilityDataEnd completes the facility data request it names.
+func (c *Client) deliverFacilityDataEnd(data []byte) {
+	if len(data) < 4 {
+		return
+	}
+	reqID := binary.LittleEndian.Uint32(data[0:4])
+
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	if col, ok := c.facilityReplies[reqID]; ok {
+		close(col.done)
+		delete(c.facilityReplies, reqID)
+	}
+}
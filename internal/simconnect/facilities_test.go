@@ -0,0 +1,188 @@
+package simconnect_test
+
+import (
+	"context"
+	"encoding/binary"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// facilityBlock encodes alternating data type and value pairs as the data of
+// one facility record.
+func facilityBlock(pairs ...any) []byte {
+	var buf []byte
+	for i := 0; i < len(pairs); i += 2 {
+		buf = append(buf, simconnecttest.EncodeValue(pairs[i].(simconnect.DataType), pairs[i+1])...)
+	}
+	return buf
+}
+
+const (
+	f64 = simconnect.DataTypeFloat64
+	f32 = simconnect.DataTypeFloat32
+	i32 = simconnect.DataTypeInt32
+	s8  = simconnect.DataTypeString8
+	s64 = simconnect.DataTypeString64
+)
+
+// seattleRecords is KSEA with runway 16L/34R (ILS on 16L) and a tower frequency.
+var seattleRecords = []simconnecttest.FacilityRecord{
+	{Type: simconnect.FacilityDataAirport, Data: facilityBlock(
+		f64, 47.449, f64, -122.309, f64, 131.7, f32, -15.0, s64, "Seattle-Tacoma Intl", s8, "KSEA", s8, "K1")},
+	{Type: simconnect.FacilityDataRunway, Data: facilityBlock(
+		f64, 47.46, f64, -122.31, f64, 130.0, f32, 180.0, f32, 3627.0, f32, 46.0, i32, 4,
+		i32, 16, i32, 1, s8, "ISNQ", s8, "K1",
+		i32, 34, i32, 2, s8, "", s8, "")},
+	{Type: simconnect.FacilityDataFrequency, Data: facilityBlock(i32, 6, i32, 119900000, s64, "SEATTLE TOWER")},
+}
+
+func TestAirportInfo(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.SetFacilityData("KSEA", seattleRecords...)
+	srv.SetFacilityData("ISNQ", simconnecttest.FacilityRecord{
+		Type: simconnect.FacilityDataVOR,
+		Data: facilityBlock(i32, 110300000, f32, 179.5, f32, 3.0, i32, 1, s64, "ILS RWY 16L"),
+	})
+	c := connectReading(t, srv)
+
+	airport, err := c.AirportInfo(context.Background(), "ksea")
+	require.NoError(t, err)
+	assert.Equal(t, types.Airport{
+		ICAO: "KSEA", Region: "K1", Name: "Seattle-Tacoma Intl",
+		Latitude: 47.449, Longitude: -122.309, Altitude: 131.7, MagVar: -15,
+		Runways: []types.Runway{{
+			Latitude: 47.46, Longitude: -122.31, Altitude: 130,
+			Heading: 180, Length: 3627, Width: 46, Surface: 4,
+			PrimaryNumber: 16, PrimaryDesignator: 1,
+			SecondaryNumber: 34, SecondaryDesignator: 2,
+			PrimaryILS: types.ILS{
+				Ident: "ISNQ", Region: "K1", Name: "ILS RWY 16L",
+				Frequency: 110300000, Course: 179.5, GlideSlope: 3, HasGlideSlope: true,
+			},
+		}},
+		Frequencies: []types.AirportFrequency{{Type: 6, Frequency: 119900000, Name: "SEATTLE TOWER"}},
+	}, airport)
+
+	// The definitions are registered once per connection.
+	_, err = c.AirportInfo(context.Background(), "KSEA")
+	require.NoError(t, err)
+	adds := srv.Messages(simconnect.SendAddToFacilityDef)
+	assert.Len(t, adds, 2+7+(2+15)+(2+3)+(2+5), "airport and ILS definitions")
+	assert.Len(t, srv.Messages(simconnect.SendRequestFacilityData), 4)
+}
+
+func TestAirportInfoNotFound(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+
+	_, err := c.AirportInfo(context.Background(), "ZZZZ")
+	require.ErrorIs(t, err, simconnect.ErrFacilityNotFound)
+}
+
+func TestAirportInfoRejected(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.FailMessages(simconnect.SendRequestFacilityData, simconnect.ExceptionError)
+	c := connectReading(t, srv)
+
+	_, err := c.AirportInfo(context.Background(), "ZZZZ")
+	require.ErrorIs(t, err, simconnect.ErrFacilityNotFound)
+	require.ErrorIs(t, err, simconnect.ErrException)
+}
+
+func TestAirportInfoReregistersRejectedDefinition(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.SetFacilityData("KSEA", seattleRecords...)
+	srv.SetFacilityData("ISNQ", simconnecttest.FacilityRecord{
+		Type: simconnect.FacilityDataVOR,
+		Data: facilityBlock(i32, 110300000, f32, 179.5, f32, 3.0, i32, 1, s64, "ILS RWY 16L"),
+	})
+	var rejected atomic.Bool
+	srv.Handle(simconnect.SendAddToFacilityDef, func(c *simconnecttest.Conn, m simconnecttest.Message) {
+		if rejected.CompareAndSwap(false, true) {
+			_ = c.SendException(simconnect.ExceptionNameUnrecognized, m.ID, 0)
+		}
+	})
+	c := connectReading(t, srv)
+
+	_, err := c.AirportInfo(context.Background(), "KSEA")
+	require.ErrorIs(t, err, simconnect.ErrException)
+	require.NotErrorIs(t, err, simconnect.ErrFacilityNotFound)
+
+	// The retry registers every field again under a fresh ID, once.
+	for range 2 {
+		_, err = c.AirportInfo(context.Background(), "KSEA")
+		require.NoError(t, err)
+	}
+	fields := make(map[uint32]int)
+	for _, m := range srv.Messages(simconnect.SendAddToFacilityDef) {
+		fields[binary.LittleEndian.Uint32(m.Payload[0:4])]++
+	}
+	assert.Len(t, fields, 3)
+	assert.Equal(t, fields[simconnect.DefIDFacilityAirport], fields[simconnect.DefIDFacilityRetry], "airport fields")
+	assert.Equal(t, 2+5, fields[simconnect.DefIDFacilityILS], "ILS fields")
+
+	var requested []uint32
+	for _, m := range srv.Messages(simconnect.SendRequestFacilityData) {
+		requested = append(requested, binary.LittleEndian.Uint32(m.Payload[0:4]))
+	}
+	assert.Equal(t, []uint32{
+		simconnect.DefIDFacilityAirport,
+		simconnect.DefIDFacilityRetry, simconnect.DefIDFacilityILS,
+		simconnect.DefIDFacilityRetry, simconnect.DefIDFacilityILS,
+	}, requested, "the airport is read through the new definition")
+	assert.Empty(t, srv.Messages(simconnect.SendClearDataDef))
+}
+
+func TestAirportInfoTimesOut(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.Handle(simconnect.SendRequestFacilityData, func(*simconnecttest.Conn, simconnecttest.Message) {})
+	c := connectReading(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.AirportInfo(ctx, "KSEA")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestListFacilities(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	airports := []types.Facility{
+		{Ident: "KSEA", Region: "K1", Latitude: 47.449, Longitude: -122.309, Altitude: 131.7},
+		{Ident: "KBFI", Region: "K1", Latitude: 47.53, Longitude: -122.302, Altitude: 6.4},
+	}
+	vors := []types.Facility{{
+		Ident: "SEA", Region: "K1", Latitude: 47.435, Longitude: -122.31, Altitude: 110, MagVar: -15,
+		Frequency: 116800000, HasNavSignal: true, HasDME: true,
+	}}
+	ndbs := []types.Facility{{Ident: "BF", Region: "K1", Latitude: 47.5, Longitude: -122.3, MagVar: -15, Frequency: 362000}}
+	srv.SetFacilities(simconnect.FacilityListAirport, airports...)
+	srv.SetFacilities(simconnect.FacilityListVOR, vors...)
+	srv.SetFacilities(simconnect.FacilityListNDB, ndbs...)
+	c := connectReading(t, srv)
+
+	tests := []struct {
+		name     string
+		listType simconnect.FacilityListType
+		want     []types.Facility
+	}{
+		{"airports", simconnect.FacilityListAirport, airports},
+		{"vors", simconnect.FacilityListVOR, vors},
+		{"ndbs", simconnect.FacilityListNDB, ndbs},
+		{"no waypoints", simconnect.FacilityListWaypoint, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.ListFacilities(context.Background(), tt.listType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package simconnect
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"unicode/utf8"
+)
+
+// flightDescriptionLength is the size of FLIGHT_SAVE's description buffer.
+const flightDescriptionLength = 2048
+
+// FlightLoad sends a FLIGHT_LOAD message that loads the .FLT flight at path,
+// on the simulator's machine, replacing the current flight. SimConnect adds
+// the .FLT extension itself, so a trailing one is removed. Like
+// FlightPlanLoad it waits writeConfirmWindow for an exception; the flight
+// itself loads later, announced by the FlightLoaded system event.
+func (c *Client) FlightLoad(ctx context.Context, path string) error {
+	path = trimExtension(path, ".flt")
+	payload, err := encodeFileName(path)
+	if err != nil {
+		return err
+	}
+	return c.sendConfirmed(ctx, SendFlightLoad, payload, "load flight "+path)
+}
+
+// FlightSave sends a FLIGHT_SAVE message that saves the current flight to
+// the .FLT file at path, on the simulator's machine, with a title and
+// description shown in the simulator's flight list. As with FlightLoad a
+// trailing .FLT extension is removed. Titles and descriptions too long for
+// SimConnect's buffers are truncated.
+func (c *Client) FlightSave(ctx context.Context, path, title, description string) error {
+	path = trimExtension(path, ".flt")
+	payload, err := encodeFileName(path)
+	if err != nil {
+		return err
+	}
+	payload = appendFixedString(payload, title, maxPathLength)
+	payload = appendFixedString(payload, description, flightDescriptionLength)
+	payload = binary.LittleEndian.AppendUint32(payload, 0) // flags, unused
+	return c.sendConfirmed(ctx, SendFlightSave, payload, "save flight "+path)
+}
+
+// appendFixedString appends s as a zero-padded char[size], cut at the first
+// NUL and truncated on a UTF-8 boundary to leave room for the terminator.
+func appendFixedString(buf []byte, s string, size int) []byte {
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	if len(s) >= size {
+		cut := size - 1
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		s = s[:cut]
+	}
+	field := make([]byte, size)
+	copy(field, s)
+	return append(buf, field...)
+}
@@ -0,0 +1,79 @@
+package simconnect_test
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+)
+
+// cField returns the zero-padded C string in b.
+func cField(b []byte) string {
+	if i := strings.IndexByte(string(b), 0); i >= 0 {
+		return string(b[:i])
+	}
+	return string(b)
+}
+
+func TestFlightLoad(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+
+	require.NoError(t, c.FlightLoad(context.Background(), `C:\Flights\short-final.FLT`))
+	msgs := srv.Messages(simconnect.SendFlightLoad)
+	require.Len(t, msgs, 1)
+	require.Len(t, msgs[0].Payload, 260)
+	assert.Equal(t, `C:\Flights\short-final`, cField(msgs[0].Payload))
+}
+
+func TestFlightSave(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+
+	require.NoError(t, c.FlightSave(context.Background(), "Flights/short-final.flt", "Short final", "1500 ft, gusty"))
+	msgs := srv.Messages(simconnect.SendFlightSave)
+	require.Len(t, msgs, 1)
+	p := msgs[0].Payload
+	require.Len(t, p, 260+260+2048+4)
+	assert.Equal(t, "Flights/short-final", cField(p[0:260]))
+	assert.Equal(t, "Short final", cField(p[260:520]))
+	assert.Equal(t, "1500 ft, gusty", cField(p[520:2568]))
+	assert.Zero(t, binary.LittleEndian.Uint32(p[2568:]))
+}
+
+func TestFlightSaveTruncatesText(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+
+	// 258 ASCII bytes and a two-byte rune do not fit in char[260] with its
+	// terminator, so the rune is dropped whole.
+	title := strings.Repeat("a", 258) + "é"
+	require.NoError(t, c.FlightSave(context.Background(), "long", title, "before\x00after"))
+	p := srv.Messages(simconnect.SendFlightSave)[0].Payload
+	assert.Equal(t, strings.Repeat("a", 258), cField(p[260:520]))
+	assert.Equal(t, "before", cField(p[520:2568]))
+}
+
+func TestFlightLoadRejected(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.FailMessages(simconnect.SendFlightLoad, simconnect.ExceptionError)
+	c := connectReading(t, srv)
+
+	err := c.FlightLoad(context.Background(), "missing.flt")
+	require.ErrorIs(t, err, simconnect.ErrException)
+	assert.Contains(t, err.Error(), `FLIGHT_LOAD "missing"`)
+}
+
+func TestFlightInvalidPath(t *testing.T) {
+	c := simconnect.NewClient(simconnect.Config{})
+	for _, path := range []string{"", ".flt", string(make([]byte, 300)), "a\x00b"} {
+		assert.ErrorIs(t, c.FlightLoad(context.Background(), path), simconnect.ErrInvalidPath, "load %q", path)
+		assert.ErrorIs(t, c.FlightSave(context.Background(), path, "", ""), simconnect.ErrInvalidPath, "save %q", path)
+	}
+}
@@ -0,0 +1,96 @@
+package simconnect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// maxPathLength is the size of the file name buffers in SimConnect's flight
+// and flight plan messages (MAX_PATH).
+const maxPathLength = 260
+
+// ReadFlightPlan reads FlightPlanSimVars once from the user aircraft. Like
+// ReadSimVars it needs a reader running ReadNext.
+func (c *Client) ReadFlightPlan(ctx context.Context) (types.FlightPlan, error) {
+	vals, err := c.ReadSimVars(ctx, FlightPlanSimVars)
+	if err != nil {
+		return types.FlightPlan{}, err
+	}
+	return ParseFlightPlanValues(vals), nil
+}
+
+// FlightPlanLoad sends a FLIGHT_PLAN_LOAD message that makes the .PLN file at
+// path, on the simulator's machine, the active GPS flight plan. SimConnect
+// adds the .PLN extension itself, so a trailing one is removed. SimConnect
+// never acknowledges a load, so like WriteSimVar it waits writeConfirmWindow
+// for an exception before treating the load as accepted; a rejected file
+// matches ErrLoadFlightPlan.
+func (c *Client) FlightPlanLoad(ctx context.Context, path string) error {
+	path = trimExtension(path, ".pln")
+	payload, err := encodeFileName(path)
+	if err != nil {
+		return err
+	}
+	return c.sendConfirmed(ctx, SendFlightPlanLoad, payload, "load flight plan "+path)
+}
+
+// trimExtension removes ext from the end of path, ignoring case.
+func trimExtension(path, ext string) string {
+	if strings.EqualFold(filepathExt(path), ext) {
+		return path[:len(path)-len(ext)]
+	}
+	return path
+}
+
+// filepathExt returns the extension of a Windows or POSIX path, which may
+// name a file on another machine.
+func filepathExt(path string) string {
+	for i := len(path) - 1; i >= 0 && path[i] != '/' && path[i] != '\\'; i-- {
+		if path[i] == '.' {
+			return path[i:]
+		}
+	}
+	return ""
+}
+
+// encodeFileName builds a payload holding a file name (char[260],
+// zero-padded), as used by FLIGHT_PLAN_LOAD, FLIGHT_LOAD and FLIGHT_SAVE.
+func encodeFileName(path string) ([]byte, error) {
+	if path == "" || len(path) >= maxPathLength {
+		return nil, fmt.Errorf("%w: file name must be 1-%d bytes", ErrInvalidPath, maxPathLength-1)
+	}
+	if strings.ContainsRune(path, 0) {
+		return nil, fmt.Errorf("%w: file name contains a NUL byte", ErrInvalidPath)
+	}
+	payload := make([]byte, maxPathLength)
+	copy(payload, path)
+	return payload, nil
+}
+
+// sendConfirmed sends a message SimConnect never acknowledges and waits
+// writeConfirmWindow for an exception, which is returned wrapped with what.
+func (c *Client) sendConfirmed(ctx context.Context, msgType uint32, payload []byte, what string) error {
+	waiter := make(chan *Exception, 1)
+	c.mu.Lock()
+	id, err := c.sendTrackedLocked(msgType, payload, waiter)
+	c.mu.Unlock()
+	defer c.untrackExceptions(id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+
+	timer := time.NewTimer(writeConfirmWindow)
+	defer timer.Stop()
+	select {
+	case exc := <-waiter:
+		return fmt.Errorf("%s: %w", what, exc)
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
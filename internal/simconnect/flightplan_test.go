@@ -0,0 +1,91 @@
+package simconnect_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func TestReadFlightPlan(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	for name, v := range map[string]any{
+		"GPS IS ACTIVE FLIGHT PLAN": 1,
+		"GPS FLIGHT PLAN WP COUNT":  5,
+		"GPS FLIGHT PLAN WP INDEX":  2,
+		"GPS WP PREV ID":            "SEA",
+		"GPS WP PREV LAT":           47.435,
+		"GPS WP PREV LON":           -122.31,
+		"GPS WP NEXT ID":            "OLM",
+		"GPS WP NEXT LAT":           46.97,
+		"GPS WP NEXT LON":           -122.9,
+		"GPS WP NEXT ALT":           8000,
+		"GPS WP DISTANCE":           24.5,
+		"GPS WP ETE":                735,
+		"GPS WP ETA":                45735,
+		"GPS ETE":                   3600,
+		"GPS ETA":                   48600,
+		"GPS WP CROSS TRK":          0.3,
+	} {
+		srv.SetSimVar(name, v)
+	}
+	c := connectReading(t, srv)
+
+	fp, err := c.ReadFlightPlan(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, types.FlightPlan{
+		IsActive: 1, WaypointCount: 5, ActiveWaypointIndex: 2,
+		PrevWaypointID: "SEA", PrevWaypointLat: 47.435, PrevWaypointLon: -122.31,
+		NextWaypointID: "OLM", NextWaypointLat: 46.97, NextWaypointLon: -122.9, NextWaypointAltitude: 8000,
+		NextWaypointDistance: 24.5, NextWaypointETE: 735, NextWaypointETA: 45735,
+		DestinationETE: 3600, DestinationETA: 48600, CrossTrackError: 0.3,
+	}, fp)
+}
+
+func TestFlightPlanLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		wantFile string
+	}{
+		{"strips extension", `C:\Plans\KSEA-KPDX.pln`, `C:\Plans\KSEA-KPDX`},
+		{"upper-case extension", "plans/KSEA-KPDX.PLN", "plans/KSEA-KPDX"},
+		{"no extension", `C:\Plans.v2\KSEA-KPDX`, `C:\Plans.v2\KSEA-KPDX`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := simconnecttest.NewServer()
+			c := connectReading(t, srv)
+
+			require.NoError(t, c.FlightPlanLoad(context.Background(), tt.path))
+			msgs := srv.Messages(simconnect.SendFlightPlanLoad)
+			require.Len(t, msgs, 1)
+			require.Len(t, msgs[0].Payload, 260)
+			assert.Equal(t, tt.wantFile, string(msgs[0].Payload[:len(tt.wantFile)]))
+			assert.Zero(t, msgs[0].Payload[len(tt.wantFile)])
+		})
+	}
+}
+
+func TestFlightPlanLoadRejected(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.FailMessages(simconnect.SendFlightPlanLoad, simconnect.ExceptionLoadFlightPlan)
+	c := connectReading(t, srv)
+
+	err := c.FlightPlanLoad(context.Background(), "missing.pln")
+	require.ErrorIs(t, err, simconnect.ErrLoadFlightPlan)
+	assert.Contains(t, err.Error(), `FLIGHT_PLAN_LOAD "missing"`)
+}
+
+func TestFlightPlanLoadInvalidPath(t *testing.T) {
+	c := simconnect.NewClient(simconnect.Config{})
+	for _, path := range []string{"", ".pln", string(make([]byte, 300)), "a\x00b"} {
+		err := c.FlightPlanLoad(context.Background(), path)
+		assert.ErrorIs(t, err, simconnect.ErrInvalidPath, "%q", path)
+	}
+}
@@ -0,0 +1,214 @@
+package simconnect
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// BackoffConfig controls the delay between reconnect attempts.
+type BackoffConfig struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	Multiplier      float64
+	MaxJitter       time.Duration // up to this much random delay is added to each wait
+}
+
+// HeartbeatConfig controls detection of silent, half-open connections.
+type HeartbeatConfig struct {
+	// Interval is how often the connection is checked. When nothing has been
+	// received for an interval, a system state request is sent to provoke a
+	// reply. Zero disables the heartbeat.
+	Interval time.Duration
+	// Timeout is how long the connection may stay silent before it is closed.
+	// Zero means three intervals.
+	Timeout time.Duration
+}
+
+// ManagerConfig holds configuration for the ConnectionManager.
+type ManagerConfig struct {
+	Backoff   BackoffConfig
+	Heartbeat HeartbeatConfig
+}
+
+// DefaultManagerConfig returns a ManagerConfig with sensible defaults.
+func DefaultManagerConfig() ManagerConfig {
+	return ManagerConfig{
+		Backoff: BackoffConfig{
+			InitialInterval: time.Second,
+			MaxInterval:     60 * time.Second,
+			Multiplier:      2.0,
+			MaxJitter:       time.Second,
+		},
+		Heartbeat: HeartbeatConfig{
+			Interval: 5 * time.Second,
+			Timeout:  15 * time.Second,
+		},
+	}
+}
+
+// Session runs on an open connection until the connection fails or ctx is
+// done. It must keep reading from the client (the Poller does), since the
+// heartbeat relies on LastReceived.
+type Session func(ctx context.Context, c *Client) error
+
+// ConnectionManager keeps a Client connected: it runs a Session on each
+// connection and reconnects with jittered exponential backoff when the
+// connection fails or the heartbeat finds it silent.
+type ConnectionManager struct {
+	client  *Client
+	session Session
+	cfg     ManagerConfig
+	jitter  func(maxJitter time.Duration) time.Duration
+}
+
+// NewConnectionManager creates a ConnectionManager for client.
+func NewConnectionManager(client *Client, session Session, cfg ManagerConfig) *ConnectionManager {
+	return &ConnectionManager{client: client, session: session, cfg: cfg, jitter: randomJitter}
+}
+
+// Run connects and runs the session, reconnecting whenever the connection is
+// lost, until ctx is done. The backoff resets after every successful connect,
+// and the client reports StateReconnecting while waiting to reconnect after a
+// lost connection. Run always returns ctx.Err().
+func (m *ConnectionManager) Run(ctx context.Context) error {
+	b := newBackoff(m.cfg.Backoff, m.jitter)
+	everConnected := false
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := m.client.Connect(ctx)
+		if err == nil {
+			b.Reset()
+			everConnected = true
+			err = m.runSession(ctx)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		wait := b.Next()
+		if everConnected {
+			m.client.setState(StateReconnecting)
+			log.Printf("simconnect: disconnected: %v (reconnecting in %s)", err, wait.Round(time.Millisecond))
+		} else {
+			log.Printf("simconnect: connect failed: %v (retrying in %s)", err, wait.Round(time.Millisecond))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// runSession runs the session alongside the heartbeat and closes the client
+// when either ends. A heartbeat failure closes the connection, which makes the
+// session's reads fail, and is reported in place of the read error.
+func (m *ConnectionManager) runSession(ctx context.Context) error {
+	defer m.client.Close() //nolint:errcheck // best-effort cleanup on disconnect
+
+	sessCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	hbErr := make(chan error, 1)
+	if m.cfg.Heartbeat.Interval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := m.heartbeat(sessCtx); err != nil {
+				hbErr <- err
+				m.client.recordError(err)
+				_ = m.client.Close()
+			}
+		}()
+	}
+
+	err := m.session(sessCtx, m.client)
+	cancel()
+	wg.Wait()
+
+	select {
+	case herr := <-hbErr:
+		return herr
+	default:
+		return err
+	}
+}
+
+// heartbeat returns an error once the connection has been silent longer than
+// the heartbeat timeout, or nil when ctx is done.
+func (m *ConnectionManager) heartbeat(ctx context.Context) error {
+	hb := m.cfg.Heartbeat
+	timeout := hb.Timeout
+	if timeout <= 0 {
+		timeout = 3 * hb.Interval
+	}
+	ticker := time.NewTicker(hb.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			silent := now.Sub(m.client.LastReceived())
+			if silent > timeout {
+				return fmt.Errorf("%w: nothing received for %s", ErrHeartbeatTimeout, silent.Round(time.Millisecond))
+			}
+			if silent >= hb.Interval {
+				if err := m.client.RequestSystemState(ReqIDHeartbeat, "Sim"); err != nil {
+					return fmt.Errorf("heartbeat: %w", err)
+				}
+			}
+		}
+	}
+}
+
+// backoff computes jittered exponential reconnect delays.
+type backoff struct {
+	cfg    BackoffConfig
+	next   time.Duration
+	jitter func(maxJitter time.Duration) time.Duration
+}
+
+// newBackoff returns a backoff, replacing unusable settings with defaults.
+func newBackoff(cfg BackoffConfig, jitter func(time.Duration) time.Duration) *backoff {
+	def := DefaultManagerConfig().Backoff
+	if cfg.InitialInterval <= 0 {
+		cfg.InitialInterval = def.InitialInterval
+	}
+	if cfg.MaxInterval < cfg.InitialInterval {
+		cfg.MaxInterval = max(def.MaxInterval, cfg.InitialInterval)
+	}
+	if cfg.Multiplier < 1 {
+		cfg.Multiplier = def.Multiplier
+	}
+	return &backoff{cfg: cfg, next: cfg.InitialInterval, jitter: jitter}
+}
+
+// Next returns the delay before the next attempt and grows the base interval.
+func (b *backoff) Next() time.Duration {
+	d := b.next
+	b.next = min(time.Duration(float64(b.next)*b.cfg.Multiplier), b.cfg.MaxInterval)
+	if b.cfg.MaxJitter > 0 {
+		d += b.jitter(b.cfg.MaxJitter)
+	}
+	return d
+}
+
+// Reset returns the delay to the initial interval.
+func (b *backoff) Reset() {
+	b.next = b.cfg.InitialInterval
+}
+
+// randomJitter returns a random duration in [0, maxJitter].
+func randomJitter(maxJitter time.Duration) time.Duration {
+	return time.Duration(rand.Int64N(int64(maxJitter) + 1)) // #nosec G404 -- jitter does not need a secure source
+}
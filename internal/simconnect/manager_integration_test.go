@@ -0,0 +1,171 @@
+package simconnect_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+)
+
+// readSession drains the client until the connection fails or ctx is done.
+func readSession(ctx context.Context, c *simconnect.Client) error {
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			if _, _, err := c.ReadNext(); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errc:
+		return err
+	}
+}
+
+// stateRecorder collects connection state transitions.
+type stateRecorder struct {
+	mu     sync.Mutex
+	states []simconnect.ConnectionState
+}
+
+func (r *stateRecorder) record(_, to simconnect.ConnectionState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states = append(r.states, to)
+}
+
+func (r *stateRecorder) seen(s simconnect.ConnectionState) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Contains(r.states, s)
+}
+
+func fastManagerConfig() simconnect.ManagerConfig {
+	return simconnect.ManagerConfig{
+		Backoff: simconnect.BackoffConfig{
+			InitialInterval: 10 * time.Millisecond,
+			MaxInterval:     50 * time.Millisecond,
+			Multiplier:      2,
+		},
+	}
+}
+
+func runManager(t *testing.T, client *simconnect.Client, cfg simconnect.ManagerConfig) (cancel func()) {
+	t.Helper()
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- simconnect.NewConnectionManager(client, readSession, cfg).Run(ctx)
+	}()
+	return func() {
+		cancelCtx()
+		assert.ErrorIs(t, <-done, context.Canceled)
+	}
+}
+
+func TestConnectionManagerReconnects(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	client := simconnect.NewClient(srv.Config())
+	rec := &stateRecorder{}
+	client.OnStateChange(rec.record)
+
+	stop := runManager(t, client, fastManagerConfig())
+
+	require.Eventually(t, func() bool { return client.State() == simconnect.StateConnected }, time.Second, time.Millisecond)
+	for range 3 {
+		before := client.Status().ConnectAttempts
+		// The client is connected once its OPEN is sent; wait until the server
+		// has read it so the connection is there to be closed.
+		require.Eventually(t, func() bool {
+			return int64(len(srv.Messages(simconnect.SendOpen))) == before
+		}, time.Second, time.Millisecond)
+		srv.CloseClientConnections()
+		require.Eventually(t, func() bool {
+			return client.Status().ConnectAttempts > before && client.State() == simconnect.StateConnected
+		}, time.Second, time.Millisecond, "backoff should reset after each successful connect")
+	}
+	assert.True(t, rec.seen(simconnect.StateReconnecting))
+	assert.Equal(t, int64(4), client.Status().ConnectAttempts)
+
+	stop()
+	assert.Equal(t, simconnect.StateDisconnected, client.State())
+}
+
+func TestConnectionManagerStaysDisconnectedUntilFirstConnect(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	cfg := srv.Config()
+	srv.Close()
+	client := simconnect.NewClient(cfg)
+	rec := &stateRecorder{}
+	client.OnStateChange(rec.record)
+
+	stop := runManager(t, client, fastManagerConfig())
+	require.Eventually(t, func() bool { return client.Status().ConnectAttempts >= 3 }, time.Second, time.Millisecond)
+	stop()
+
+	assert.Equal(t, simconnect.StateDisconnected, client.State())
+	assert.False(t, rec.seen(simconnect.StateReconnecting), "never connected, so never reconnecting")
+	assert.Error(t, client.Status().LastError)
+}
+
+func TestConnectionManagerHeartbeatDetectsSilentConnection(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	client := simconnect.NewClient(srv.Config())
+
+	cfg := fastManagerConfig()
+	cfg.Heartbeat = simconnect.HeartbeatConfig{Interval: 10 * time.Millisecond, Timeout: 50 * time.Millisecond}
+	stop := runManager(t, client, cfg)
+	defer stop()
+
+	require.Eventually(t, func() bool { return client.State() == simconnect.StateConnected }, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool {
+		return len(srv.Messages(simconnect.SendRequestSystemState)) > 0
+	}, time.Second, time.Millisecond, "an idle connection is pinged")
+	assert.Equal(t, simconnect.StateConnected, client.State(), "answered pings keep the connection up")
+
+	srv.SetSilent(true)
+	attempts := client.Status().ConnectAttempts
+	require.Eventually(t, func() bool {
+		st := client.Status()
+		return errors.Is(st.LastError, simconnect.ErrHeartbeatTimeout) && st.ConnectAttempts > attempts
+	}, time.Second, time.Millisecond, "a silent connection is dropped and redialed")
+
+	srv.SetSilent(false)
+	require.Eventually(t, func() bool {
+		return client.State() == simconnect.StateConnected && time.Since(client.LastReceived()) < 20*time.Millisecond
+	}, 2*time.Second, time.Millisecond, "the connection recovers once the server answers again")
+}
+
+func TestClientCloseDuringRead(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	client := simconnect.NewClient(srv.Config())
+	require.NoError(t, client.Connect(context.Background()))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := client.ReadNext(); err != nil {
+				return
+			}
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, client.Close())
+	<-done
+}
@@ -0,0 +1,55 @@
+package simconnect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	cfg := BackoffConfig{
+		InitialInterval: time.Second,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+		MaxJitter:       time.Second,
+	}
+	var jitterMax time.Duration
+	b := newBackoff(cfg, func(m time.Duration) time.Duration {
+		jitterMax = m
+		return 100 * time.Millisecond
+	})
+
+	var got []time.Duration
+	for range 5 {
+		got = append(got, b.Next())
+	}
+	assert.Equal(t, []time.Duration{
+		1100 * time.Millisecond,
+		2100 * time.Millisecond,
+		4100 * time.Millisecond,
+		5100 * time.Millisecond,
+		5100 * time.Millisecond,
+	}, got)
+	assert.Equal(t, time.Second, jitterMax)
+
+	b.Reset()
+	assert.Equal(t, 1100*time.Millisecond, b.Next())
+}
+
+func TestBackoffDefaultsUnusableSettings(t *testing.T) {
+	b := newBackoff(BackoffConfig{Multiplier: 0.5}, nil)
+	def := DefaultManagerConfig().Backoff
+
+	assert.Equal(t, def.InitialInterval, b.Next(), "no jitter configured")
+	assert.Equal(t, def.MaxInterval, b.cfg.MaxInterval)
+	assert.Equal(t, def.Multiplier, b.cfg.Multiplier)
+}
+
+func TestRandomJitterWithinBounds(t *testing.T) {
+	for range 100 {
+		d := randomJitter(10 * time.Millisecond)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, 10*time.Millisecond)
+	}
+}
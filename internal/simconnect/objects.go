@@ -0,0 +1,193 @@
+package simconnect
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// SimObjectType selects which objects RequestDataOnSimObjectType reports
+// (SIMCONNECT_SIMOBJECT_TYPE).
+type SimObjectType uint32
+
+const (
+	SimObjectTypeUser       SimObjectType = 0
+	SimObjectTypeAll        SimObjectType = 1
+	SimObjectTypeAircraft   SimObjectType = 2
+	SimObjectTypeHelicopter SimObjectType = 3
+	SimObjectTypeBoat       SimObjectType = 4
+	SimObjectTypeGround     SimObjectType = 5
+)
+
+var simObjectTypeNames = map[SimObjectType]string{
+	SimObjectTypeUser:       "user",
+	SimObjectTypeAll:        "all",
+	SimObjectTypeAircraft:   "aircraft",
+	SimObjectTypeHelicopter: "helicopter",
+	SimObjectTypeBoat:       "boat",
+	SimObjectTypeGround:     "ground",
+}
+
+// String returns the lowercase name of the object type, e.g. "aircraft".
+func (t SimObjectType) String() string {
+	if name, ok := simObjectTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("SimObjectType(%d)", uint32(t))
+}
+
+// MaxObjectRadiusMeters is the largest radius SimConnect searches for objects.
+const MaxObjectRadiusMeters = 200000
+
+// SimObjectValues holds the SimVars read from one object by ReadSimObjects.
+type SimObjectValues struct {
+	ObjectID uint32
+	Values   []any
+}
+
+// objectCollector gathers the SimObjectDataByType replies to one request.
+// Its fields are guarded by Client.pendingMu.
+type objectCollector struct {
+	objects []SimObjectValues
+	raw     [][]byte
+	done    chan struct{}
+}
+
+// RequestDataOnSimObjectType sends a REQUEST_DATA_ON_SIMOBJECT_TYPE message
+// for every object of objType within radiusMeters of the user aircraft. Each
+// object arrives as its own RecvSimObjectDataByType message.
+func (c *Client) RequestDataOnSimObjectType(requestID, defID, radiusMeters uint32, objType SimObjectType) error {
+	return c.sendMessage(SendRequestDataByType, encodeRequestDataByType(requestID, defID, radiusMeters, objType))
+}
+
+// encodeRequestDataByType builds a REQUEST_DATA_ON_SIMOBJECT_TYPE payload (16 bytes):
+//
+//	int32: requestID, defID, radiusMeters, objType
+func encodeRequestDataByType(requestID, defID, radiusMeters uint32, objType SimObjectType) []byte {
+	payload := make([]byte, 0, 16)
+	payload = binary.LittleEndian.AppendUint32(payload, requestID)
+	payload = binary.LittleEndian.AppendUint32(payload, defID)
+	payload = binary.LittleEndian.AppendUint32(payload, radiusMeters)
+	return binary.LittleEndian.AppendUint32(payload, uint32(objType))
+}
+
+// ReadSimObjects reads SimVars once from every object of objType within
+// radiusMeters of the user aircraft through a temporary data definition, which
+// is cleared afterwards. Like ReadSimVars it needs a reader running ReadNext.
+// A radius of 0 reports only the user aircraft.
+func (c *Client) ReadSimObjects(ctx context.Context, defs []SimVarDef, radiusMeters uint32, objType SimObjectType) ([]SimObjectValues, error) {
+	if err := validateQueryDefs(defs); err != nil {
+		return nil, err
+	}
+	if radiusMeters > MaxObjectRadiusMeters {
+		return nil, fmt.Errorf("simconnect: object radius %d m exceeds %d m", radiusMeters, MaxObjectRadiusMeters)
+	}
+
+	defID, reqID := c.nextQuerySlot()
+	excs := make(chan *Exception, len(defs)+1)
+	col := &objectCollector{done: make(chan struct{})}
+
+	c.pendingMu.Lock()
+	c.objectReplies[reqID] = col
+	c.pendingMu.Unlock()
+
+	sendIDs := make([]uint32, 0, len(defs)+1)
+	defer func() {
+		c.untrackExceptions(sendIDs...)
+		c.pendingMu.Lock()
+		delete(c.objectReplies, reqID)
+		c.pendingMu.Unlock()
+		_ = c.sendMessage(SendClearDataDef, binary.LittleEndian.AppendUint32(nil, defID))
+	}()
+
+	c.mu.Lock()
+	err := func() error {
+		if err := c.addQueryDefsLocked(defID, defs, excs, &sendIDs); err != nil {
+			return err
+		}
+		id, err := c.sendTrackedLocked(SendRequestDataByType,
+			encodeRequestDataByType(reqID, defID, radiusMeters, objType), excs)
+		sendIDs = append(sendIDs, id)
+		return err
+	}()
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case exc := <-excs:
+		return nil, exc
+	case <-col.done:
+		select {
+		case exc := <-excs:
+			return nil, exc
+		default:
+		}
+	}
+
+	c.pendingMu.Lock()
+	objects, raw := col.objects, col.raw
+	c.pendingMu.Unlock()
+	for i := range objects {
+		vals, err := ParseSimVarValues(raw[i], defs)
+		if err != nil {
+			return nil, fmt.Errorf("simconnect: object %d reply: %w", objects[i].ObjectID, err)
+		}
+		objects[i].Values = vals
+	}
+	return objects, nil
+}
+
+// deliverObjectReply adds a SimObjectDataByType payload to the ReadSimObjects
+// call waiting on its request ID, if any, and completes the call on the last
+// entry. SimConnect numbers entries from 1 and reports zero entries when no
+// object matches.
+func (c *Client) deliverObjectReply(data []byte) {
+	if len(data) < simObjectDataHeaderSize {
+		return
+	}
+	reqID := binary.LittleEndian.Uint32(data[0:4])
+	objectID := binary.LittleEndian.Uint32(data[4:8])
+	entry := binary.LittleEndian.Uint32(data[16:20])
+	outOf := binary.LittleEndian.Uint32(data[20:24])
+
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	col, ok := c.objectReplies[reqID]
+	if !ok {
+		return
+	}
+	if outOf > 0 {
+		col.objects = append(col.objects, SimObjectValues{ObjectID: objectID})
+		col.raw = append(col.raw, data[simObjectDataHeaderSize:])
+	}
+	if entry >= outOf {
+		close(col.done)
+		delete(c.objectReplies, reqID)
+	}
+}
+
+// QueryTraffic reads TrafficSimVars from every object of objType within
+// radiusMeters, skipping the user aircraft.
+func (c *Client) QueryTraffic(ctx context.Context, radiusMeters uint32, objType SimObjectType) ([]types.TrafficObject, error) {
+	objects, err := c.ReadSimObjects(ctx, TrafficSimVars, radiusMeters, objType)
+	if err != nil {
+		return nil, err
+	}
+	traffic := make([]types.TrafficObject, 0, len(objects))
+	for _, obj := range objects {
+		t := ParseTrafficValues(obj.Values)
+		if t.UserSim != 0 {
+			continue
+		}
+		t.ObjectID = obj.ObjectID
+		t.Type = objType.String()
+		traffic = append(traffic, t)
+	}
+	return traffic, nil
+}
@@ -0,0 +1,108 @@
+package simconnect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func TestReadSimObjects(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.AddSimObject(11, simconnect.SimObjectTypeAircraft, map[string]any{"PLANE ALTITUDE": 3500})
+	srv.AddSimObject(12, simconnect.SimObjectTypeHelicopter, map[string]any{"PLANE ALTITUDE": 800})
+	srv.AddSimObject(13, simconnect.SimObjectTypeAircraft, map[string]any{"PLANE ALTITUDE": 12000})
+	c := connectReading(t, srv)
+
+	objects, err := c.ReadSimObjects(context.Background(), []simconnect.SimVarDef{simconnect.PlaneAltitude},
+		10000, simconnect.SimObjectTypeAircraft)
+	require.NoError(t, err)
+	assert.Equal(t, []simconnect.SimObjectValues{
+		{ObjectID: 11, Values: []any{3500.0}},
+		{ObjectID: 13, Values: []any{12000.0}},
+	}, objects)
+
+	require.Eventually(t, func() bool {
+		return len(srv.Messages(simconnect.SendClearDataDef)) == 1
+	}, time.Second, time.Millisecond, "the temporary definition is cleared")
+}
+
+func TestReadSimObjectsNoneInRange(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.AddSimObject(12, simconnect.SimObjectTypeHelicopter, nil)
+	c := connectReading(t, srv)
+
+	objects, err := c.ReadSimObjects(context.Background(), []simconnect.SimVarDef{simconnect.PlaneAltitude},
+		10000, simconnect.SimObjectTypeGround)
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func TestReadSimObjectsRejectsLargeRadius(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+
+	_, err := c.ReadSimObjects(context.Background(), []simconnect.SimVarDef{simconnect.PlaneAltitude},
+		simconnect.MaxObjectRadiusMeters+1, simconnect.SimObjectTypeAircraft)
+	require.Error(t, err)
+	assert.Empty(t, srv.Messages(simconnect.SendRequestDataByType))
+}
+
+func TestReadSimObjectsTimesOut(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.Handle(simconnect.SendRequestDataByType, func(*simconnecttest.Conn, simconnecttest.Message) {})
+	c := connectReading(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.ReadSimObjects(ctx, []simconnect.SimVarDef{simconnect.PlaneAltitude},
+		10000, simconnect.SimObjectTypeAircraft)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestQueryTraffic(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.AddSimObject(1, simconnect.SimObjectTypeAircraft, map[string]any{"IS USER SIM": 1, "ATC ID": "N172SP"})
+	srv.AddSimObject(42, simconnect.SimObjectTypeAircraft, map[string]any{
+		"ATC ID":                     "N12345",
+		"ATC AIRLINE":                "Alaska",
+		"ATC FLIGHT NUMBER":          "123",
+		"ATC MODEL":                  "B738",
+		"PLANE LATITUDE":             47.5,
+		"PLANE LONGITUDE":            -122.3,
+		"PLANE ALTITUDE":             5000,
+		"PLANE HEADING DEGREES TRUE": 180,
+		"GROUND VELOCITY":            250,
+		"VERTICAL SPEED":             -800,
+	})
+	c := connectReading(t, srv)
+
+	traffic, err := c.QueryTraffic(context.Background(), 20000, simconnect.SimObjectTypeAircraft)
+	require.NoError(t, err)
+	assert.Equal(t, []types.TrafficObject{{
+		ObjectID:        42,
+		Type:            "aircraft",
+		ATCID:           "N12345",
+		ATCAirline:      "Alaska",
+		ATCFlightNumber: "123",
+		ATCModel:        "B738",
+		Latitude:        47.5,
+		Longitude:       -122.3,
+		Altitude:        5000,
+		HeadingTrue:     180,
+		GroundSpeed:     250,
+		VerticalSpeed:   -800,
+	}}, traffic, "the user aircraft is skipped")
+}
+
+func TestSimObjectTypeString(t *testing.T) {
+	assert.Equal(t, "aircraft", simconnect.SimObjectTypeAircraft.String())
+	assert.Equal(t, "ground", simconnect.SimObjectTypeGround.String())
+	assert.Equal(t, "SimObjectType(9)", simconnect.SimObjectType(9).String())
+}
@@ -0,0 +1,35 @@
+package simconnect
+
+import (
+	"fmt"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// ParseAircraftPayload decodes a SimObjectData payload laid out as
+// AircraftSimVars into AircraftInfo. The strings make the payload longer than
+// the float64 groups, but every field has a fixed size.
+func ParseAircraftPayload(data []byte) (types.AircraftInfo, error) {
+	vals, err := ParseSimVarValues(data, AircraftSimVars)
+	if err != nil {
+		return types.AircraftInfo{}, fmt.Errorf("parse aircraft payload: %w", err)
+	}
+	str := func(i int) string { return vals[i].(string) }
+	num := func(i int) float64 { return vals[i].(float64) }
+
+	return types.AircraftInfo{
+		Title:           str(0),
+		ATCID:           str(1),
+		ATCAirline:      str(2),
+		ATCFlightNumber: str(3),
+		ATCModel:        str(4),
+		Category:        str(5),
+		EngineType:      num(6),
+		NumberOfEngines: num(7),
+		MaxGrossWeight:  num(8),
+		DesignSpeedVS0:  num(9),
+		DesignSpeedVS1:  num(10),
+		DesignSpeedVC:   num(11),
+		DesignSpeedVMO:  num(12),
+	}, nil
+}
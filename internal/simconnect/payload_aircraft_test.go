@@ -0,0 +1,48 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func makeAircraftPayload(strs [6]string, nums [7]float64) []byte { //nolint:gocritic
+	var buf []byte
+	for i, s := range strs {
+		buf = append(buf, fixedString(s, AircraftSimVars[i].Size)...)
+	}
+	for _, v := range nums {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+	}
+	return buf
+}
+
+func TestParseAircraftPayload(t *testing.T) {
+	data := makeAircraftPayload(
+		[6]string{"Cessna Skyhawk G1000 Asobo", "N172SP", "", "", "C172", "Airplane"},
+		[7]float64{0, 1, 2550, 40, 48, 124, 163},
+	)
+	info, err := ParseAircraftPayload(data)
+	require.NoError(t, err)
+	assert.Equal(t, types.AircraftInfo{
+		Title:           "Cessna Skyhawk G1000 Asobo",
+		ATCID:           "N172SP",
+		ATCModel:        "C172",
+		Category:        "Airplane",
+		EngineType:      0,
+		NumberOfEngines: 1,
+		MaxGrossWeight:  2550,
+		DesignSpeedVS0:  40,
+		DesignSpeedVS1:  48,
+		DesignSpeedVC:   124,
+		DesignSpeedVMO:  163,
+	}, info)
+
+	_, err = ParseAircraftPayload(data[:len(data)-8])
+	assert.Error(t, err, "truncated payload")
+}
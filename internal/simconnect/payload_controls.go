@@ -0,0 +1,35 @@
+package simconnect
+
+import (
+	"fmt"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// ParseFlightControlsPayload decodes a SimObjectData payload laid out as
+// FlightControlsSimVars into FlightControls.
+func ParseFlightControlsPayload(data []byte) (types.FlightControls, error) {
+	vals, err := ParseSimVarValues(data, FlightControlsSimVars)
+	if err != nil {
+		return types.FlightControls{}, fmt.Errorf("parse flight controls payload: %w", err)
+	}
+	num := func(i int) float64 { return vals[i].(float64) }
+
+	return types.FlightControls{
+		Elevator:           num(0),
+		Aileron:            num(1),
+		Rudder:             num(2),
+		ElevatorTrim:       num(3),
+		AileronTrim:        num(4),
+		RudderTrim:         num(5),
+		FlapsHandleIndex:   num(6),
+		FlapsHandlePercent: num(7),
+		SpoilersHandle:     num(8),
+		SpoilersArmed:      num(9),
+		GearHandle:         num(10),
+		GearCenter:         num(11),
+		GearLeft:           num(12),
+		GearRight:          num(13),
+		ParkingBrake:       num(14),
+	}, nil
+}
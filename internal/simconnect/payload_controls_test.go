@@ -0,0 +1,40 @@
+package simconnect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func TestParseFlightControlsPayload(t *testing.T) {
+	data := le(
+		-0.25, 0.1, 0.05, // elevator, aileron, rudder
+		2.5, -10.0, 3.0, // trim
+		2.0, 50.0, 0.0, 1.0, // flaps index and percent, spoilers handle, armed
+		1.0, 100.0, 100.0, 99.5, // gear handle, center, left, right
+		0.0, // parking brake
+	)
+	fc, err := ParseFlightControlsPayload(data)
+	require.NoError(t, err)
+	assert.Equal(t, types.FlightControls{
+		Elevator:           -0.25,
+		Aileron:            0.1,
+		Rudder:             0.05,
+		ElevatorTrim:       2.5,
+		AileronTrim:        -10,
+		RudderTrim:         3,
+		FlapsHandleIndex:   2,
+		FlapsHandlePercent: 50,
+		SpoilersArmed:      1,
+		GearHandle:         1,
+		GearCenter:         100,
+		GearLeft:           100,
+		GearRight:          99.5,
+	}, fc)
+
+	_, err = ParseFlightControlsPayload(data[:len(data)-8])
+	assert.Error(t, err, "truncated payload")
+}
@@ -6,45 +6,38 @@ import (
 	"github.com/eytandecker/flightsim-mcp/pkg/types"
 )
 
-const enginePayloadSize = 20 * 8 // 20 float64 fields × 8 bytes each
-
-// ParseEnginePayload decodes a packed SimObjectData payload into EngineData.
-// Expects exactly 160 bytes in EngineSimVars order.
+// ParseEnginePayload decodes a SimObjectData payload laid out as
+// EngineSimVars into EngineData. Engines beyond NUMBER OF ENGINES are
+// registered but dropped here, so Engines has one entry per real engine.
 func ParseEnginePayload(data []byte) (types.EngineData, error) {
-	if len(data) < enginePayloadSize {
-		return types.EngineData{}, fmt.Errorf("payload too short: got %d bytes, need %d", len(data), enginePayloadSize)
+	vals, err := ParseSimVarValues(data, EngineSimVars)
+	if err != nil {
+		return types.EngineData{}, fmt.Errorf("parse engine payload: %w", err)
 	}
+	num := func(i int) float64 { return vals[i].(float64) }
 
-	vals := make([]float64, len(EngineSimVars))
-	for i := range EngineSimVars {
-		offset := i * 8
-		v, err := ParseSimVarValue(data[offset:offset+8], DataTypeFloat64)
-		if err != nil {
-			return types.EngineData{}, fmt.Errorf("parse simvar %d: %w", i, err)
+	eng := types.EngineData{NumberOfEngines: num(0)}
+	count := min(max(int(eng.NumberOfEngines), 0), types.MaxEngines)
+	eng.Engines = make([]types.Engine, count)
+	for i := range eng.Engines {
+		base := 1 + i*len(perEngineSimVars)
+		eng.Engines[i] = types.Engine{
+			ThrottlePosition: num(base),
+			RPM:              num(base + 1),
+			N1:               num(base + 2),
+			N2:               num(base + 3),
+			FuelFlow:         num(base + 4),
+			EGT:              num(base + 5),
+			ITT:              num(base + 6),
+			Torque:           num(base + 7),
+			OilTemp:          num(base + 8),
+			OilPressure:      num(base + 9),
 		}
-		vals[i] = v.(float64)
 	}
 
-	return types.EngineData{
-		NumberOfEngines:   vals[0],
-		ThrottlePosition1: vals[1],
-		ThrottlePosition2: vals[2],
-		RPM1:              vals[3],
-		RPM2:              vals[4],
-		N1Engine1:         vals[5],
-		N1Engine2:         vals[6],
-		N2Engine1:         vals[7],
-		N2Engine2:         vals[8],
-		FuelFlow1:         vals[9],
-		FuelFlow2:         vals[10],
-		EGT1:              vals[11],
-		EGT2:              vals[12],
-		OilTemp1:          vals[13],
-		OilTemp2:          vals[14],
-		OilPressure1:      vals[15],
-		OilPressure2:      vals[16],
-		FuelTotalQuantity: vals[17],
-		FuelLeftQuantity:  vals[18],
-		FuelRightQuantity: vals[19],
-	}, nil
+	fuel := 1 + types.MaxEngines*len(perEngineSimVars)
+	eng.FuelTotalQuantity = num(fuel)
+	eng.FuelLeftQuantity = num(fuel + 1)
+	eng.FuelRightQuantity = num(fuel + 2)
+	return eng, nil
 }
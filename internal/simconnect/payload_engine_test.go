@@ -7,45 +7,44 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
 )
 
-func makeEnginePayload(vals [20]float64) []byte { //nolint:gocritic
-	buf := make([]byte, 20*8)
-	for i, v := range vals {
-		binary.LittleEndian.PutUint64(buf[i*8:], math.Float64bits(v))
+// makeEnginePayload lays out an engine payload for count engines. Engine n
+// reads n*1000 plus the field's position in perEngineSimVars, so a value
+// shows which slot it was read from.
+func makeEnginePayload(count float64) []byte {
+	vals := []float64{count}
+	for n := 1; n <= types.MaxEngines; n++ {
+		for i := range perEngineSimVars {
+			vals = append(vals, float64(n*1000+i))
+		}
+	}
+	vals = append(vals, 500.0, 250.0, 250.0)
+
+	buf := make([]byte, 0, len(vals)*8)
+	for _, v := range vals {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
 	}
 	return buf
 }
 
 func TestParseEnginePayload(t *testing.T) {
 	tests := []struct {
-		name    string
-		data    []byte
-		wantErr bool
+		name      string
+		data      []byte
+		wantCount int
+		wantErr   bool
 	}{
-		{
-			name: "valid 160-byte payload",
-			data: makeEnginePayload([20]float64{
-				2.0, 85.0, 85.0, 2400.0, 2400.0,
-				92.0, 91.5, 98.0, 97.5, 120.0,
-				118.0, 650.0, 648.0, 95.0, 94.0,
-				55.0, 54.0, 500.0, 250.0, 250.0,
-			}),
-		},
-		{
-			name:    "truncated payload returns error",
-			data:    make([]byte, 100),
-			wantErr: true,
-		},
-		{
-			name:    "empty payload returns error",
-			data:    []byte{},
-			wantErr: true,
-		},
-		{
-			name: "all-zero payload produces zero struct",
-			data: make([]byte, 160),
-		},
+		{name: "single engine", data: makeEnginePayload(1), wantCount: 1},
+		{name: "twin", data: makeEnginePayload(2), wantCount: 2},
+		{name: "four engines", data: makeEnginePayload(4), wantCount: 4},
+		{name: "count above four is capped", data: makeEnginePayload(6), wantCount: 4},
+		{name: "negative count reads no engines", data: makeEnginePayload(-1), wantCount: 0},
+		{name: "all-zero payload has no engines", data: make([]byte, len(EngineSimVars)*8), wantCount: 0},
+		{name: "truncated payload returns error", data: make([]byte, 100), wantErr: true},
+		{name: "empty payload returns error", data: []byte{}, wantErr: true},
 	}
 
 	for _, tt := range tests {
@@ -56,22 +55,27 @@ func TestParseEnginePayload(t *testing.T) {
 				return
 			}
 			require.NoError(t, err)
-			if tt.name == "valid 160-byte payload" {
-				assert.InDelta(t, 2.0, eng.NumberOfEngines, 1e-9)
-				assert.InDelta(t, 85.0, eng.ThrottlePosition1, 1e-9)
-				assert.InDelta(t, 2400.0, eng.RPM1, 1e-9)
-				assert.InDelta(t, 92.0, eng.N1Engine1, 1e-9)
-				assert.InDelta(t, 120.0, eng.FuelFlow1, 1e-9)
-				assert.InDelta(t, 650.0, eng.EGT1, 1e-9)
-				assert.InDelta(t, 55.0, eng.OilPressure1, 1e-9)
+			require.Len(t, eng.Engines, tt.wantCount)
+			for i, e := range eng.Engines {
+				n := float64((i + 1) * 1000)
+				assert.Equal(t, types.Engine{
+					ThrottlePosition: n,
+					RPM:              n + 1,
+					N1:               n + 2,
+					N2:               n + 3,
+					FuelFlow:         n + 4,
+					EGT:              n + 5,
+					ITT:              n + 6,
+					Torque:           n + 7,
+					OilTemp:          n + 8,
+					OilPressure:      n + 9,
+				}, e)
+			}
+			if tt.wantCount > 0 {
 				assert.InDelta(t, 500.0, eng.FuelTotalQuantity, 1e-9)
 				assert.InDelta(t, 250.0, eng.FuelLeftQuantity, 1e-9)
 				assert.InDelta(t, 250.0, eng.FuelRightQuantity, 1e-9)
 			}
-			if tt.name == "all-zero payload produces zero struct" {
-				assert.Equal(t, 0.0, eng.NumberOfEngines)
-				assert.Equal(t, 0.0, eng.FuelTotalQuantity)
-			}
 		})
 	}
 }
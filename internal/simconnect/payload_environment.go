@@ -6,10 +6,10 @@ import (
 	"github.com/eytandecker/flightsim-mcp/pkg/types"
 )
 
-const environmentPayloadSize = 8 * 8 // 8 float64 fields × 8 bytes each
+const environmentPayloadSize = 10 * 8 // 10 float64 fields × 8 bytes each
 
 // ParseEnvironmentPayload decodes a packed SimObjectData payload into Environment.
-// Expects exactly 64 bytes in EnvironmentSimVars order.
+// Expects exactly 80 bytes in EnvironmentSimVars order.
 func ParseEnvironmentPayload(data []byte) (types.Environment, error) {
 	if len(data) < environmentPayloadSize {
 		return types.Environment{}, fmt.Errorf("payload too short: got %d bytes, need %d", len(data), environmentPayloadSize)
@@ -26,13 +26,15 @@ func ParseEnvironmentPayload(data []byte) (types.Environment, error) {
 	}
 
 	return types.Environment{
-		WindVelocity:  vals[0],
-		WindDirection: vals[1],
-		Temperature:   vals[2],
-		Pressure:      vals[3],
-		Visibility:    vals[4],
-		PrecipState:   vals[5],
-		LocalTime:     vals[6],
-		ZuluTime:      vals[7],
+		WindVelocity:     vals[0],
+		WindDirection:    vals[1],
+		Temperature:      vals[2],
+		Pressure:         vals[3],
+		Visibility:       vals[4],
+		PrecipState:      vals[5],
+		LocalTime:        vals[6],
+		ZuluTime:         vals[7],
+		SeaLevelPressure: vals[8],
+		ZuluDayOfMonth:   vals[9],
 	}, nil
 }
@@ -9,8 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func makeEnvironmentPayload(vals [8]float64) []byte { //nolint:gocritic
-	buf := make([]byte, 8*8)
+func makeEnvironmentPayload(vals [10]float64) []byte { //nolint:gocritic
+	buf := make([]byte, 10*8)
 	for i, v := range vals {
 		binary.LittleEndian.PutUint64(buf[i*8:], math.Float64bits(v))
 	}
@@ -24,9 +24,9 @@ func TestParseEnvironmentPayload(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "valid 64-byte payload",
-			data: makeEnvironmentPayload([8]float64{
-				15.0, 270.0, -5.5, 29.92, 10000.0, 4.0, 43200.0, 50400.0,
+			name: "valid 80-byte payload",
+			data: makeEnvironmentPayload([10]float64{
+				15.0, 270.0, -5.5, 29.92, 10000.0, 4.0, 43200.0, 50400.0, 1013.2, 14.0,
 			}),
 		},
 		{
@@ -41,7 +41,7 @@ func TestParseEnvironmentPayload(t *testing.T) {
 		},
 		{
 			name: "all-zero payload produces zero struct",
-			data: make([]byte, 64),
+			data: make([]byte, 80),
 		},
 	}
 
@@ -53,7 +53,7 @@ func TestParseEnvironmentPayload(t *testing.T) {
 				return
 			}
 			require.NoError(t, err)
-			if tt.name == "valid 64-byte payload" {
+			if tt.name == "valid 80-byte payload" {
 				assert.InDelta(t, 15.0, env.WindVelocity, 1e-9)
 				assert.InDelta(t, 270.0, env.WindDirection, 1e-9)
 				assert.InDelta(t, -5.5, env.Temperature, 1e-9)
@@ -62,6 +62,8 @@ func TestParseEnvironmentPayload(t *testing.T) {
 				assert.InDelta(t, 4.0, env.PrecipState, 1e-9)
 				assert.InDelta(t, 43200.0, env.LocalTime, 1e-9)
 				assert.InDelta(t, 50400.0, env.ZuluTime, 1e-9)
+				assert.InDelta(t, 1013.2, env.SeaLevelPressure, 1e-9)
+				assert.InDelta(t, 14.0, env.ZuluDayOfMonth, 1e-9)
 			}
 			if tt.name == "all-zero payload produces zero struct" {
 				assert.Equal(t, 0.0, env.WindVelocity)
@@ -0,0 +1,167 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// Facility list entry sizes (packed SIMCONNECT_DATA_FACILITY_* structs). Each
+// extends the one before it:
+//
+//	AIRPORT:  char[6] ident, char[3] region, float64 lat, lon, alt (meters)
+//	WAYPOINT: + float32 magVar
+//	NDB:      + uint32 frequency (Hz)
+//	VOR:      + uint32 flags, float32 localizer, float64 glideLat, glideLon,
+//	          glideAlt, float32 glideSlopeAngle
+const (
+	airportEntrySize  = 33
+	waypointEntrySize = 37
+	ndbEntrySize      = 41
+	vorEntrySize      = 77
+)
+
+// VOR list flags (SIMCONNECT_RECV_ID_VOR_LIST_HAS_*).
+const (
+	vorFlagNavSignal  = 0x1
+	vorFlagLocalizer  = 0x2
+	vorFlagGlideSlope = 0x4
+	vorFlagDME        = 0x8
+)
+
+var facilityEntrySizes = map[uint32]int{
+	RecvAirportList:  airportEntrySize,
+	RecvWaypointList: waypointEntrySize,
+	RecvNDBList:      ndbEntrySize,
+	RecvVORList:      vorEntrySize,
+}
+
+// decodeFacilityList decodes the entries of an airport, waypoint, NDB or VOR
+// list page.
+func decodeFacilityList(recvType uint32, data []byte) ([]types.Facility, error) {
+	size, ok := facilityEntrySizes[recvType]
+	if !ok {
+		return nil, fmt.Errorf("facility list: unexpected message type 0x%02x", recvType)
+	}
+	if len(data) < facilityListHeaderSize {
+		return nil, fmt.Errorf("facility list: %d-byte payload is shorter than its header", len(data))
+	}
+	count := int(binary.LittleEndian.Uint32(data[4:8]))
+	entries := data[facilityListHeaderSize:]
+	if len(entries) < count*size {
+		return nil, fmt.Errorf("facility list: %d entries need %d bytes, got %d", count, count*size, len(entries))
+	}
+
+	le := binary.LittleEndian
+	f64 := func(b []byte) float64 { return math.Float64frombits(le.Uint64(b)) }
+	f32 := func(b []byte) float64 { return float64(math.Float32frombits(le.Uint32(b))) }
+
+	facilities := make([]types.Facility, count)
+	for i := range facilities {
+		e := entries[i*size : (i+1)*size]
+		f := types.Facility{
+			Ident:     cString(e[0:6]),
+			Region:    cString(e[6:9]),
+			Latitude:  f64(e[9:17]),
+			Longitude: f64(e[17:25]),
+			Altitude:  f64(e[25:33]),
+		}
+		if size >= waypointEntrySize {
+			f.MagVar = f32(e[33:37])
+		}
+		if size >= ndbEntrySize {
+			f.Frequency = float64(le.Uint32(e[37:41]))
+		}
+		if size >= vorEntrySize {
+			flags := le.Uint32(e[41:45])
+			f.HasNavSignal = flags&vorFlagNavSignal != 0
+			f.HasLocalizer = flags&vorFlagLocalizer != 0
+			f.HasGlideSlope = flags&vorFlagGlideSlope != 0
+			f.HasDME = flags&vorFlagDME != 0
+			f.LocalizerHeading = f32(e[45:49])
+			f.GlideSlopeAngle = f32(e[73:77])
+		}
+		facilities[i] = f
+	}
+	return facilities, nil
+}
+
+// decodeAirport assembles an airport from the records of an airportFacility
+// request. It returns ErrFacilityNotFound when there is no airport record.
+func decodeAirport(records []facilityRecord) (types.Airport, error) {
+	var airport types.Airport
+	found := false
+	for _, r := range records {
+		switch r.dataType {
+		case FacilityDataAirport:
+			vals, err := ParseSimVarValues(r.data, airportFacilityFields)
+			if err != nil {
+				return types.Airport{}, err
+			}
+			found = true
+			airport.Latitude = vals[0].(float64)
+			airport.Longitude = vals[1].(float64)
+			airport.Altitude = vals[2].(float64)
+			airport.MagVar = float64(vals[3].(float32))
+			airport.Name = vals[4].(string)
+			airport.ICAO = vals[5].(string)
+			airport.Region = vals[6].(string)
+		case FacilityDataRunway:
+			vals, err := ParseSimVarValues(r.data, runwayFacilityFields)
+			if err != nil {
+				return types.Airport{}, err
+			}
+			airport.Runways = append(airport.Runways, types.Runway{
+				Latitude:            vals[0].(float64),
+				Longitude:           vals[1].(float64),
+				Altitude:            vals[2].(float64),
+				Heading:             float64(vals[3].(float32)),
+				Length:              float64(vals[4].(float32)),
+				Width:               float64(vals[5].(float32)),
+				Surface:             int(vals[6].(int32)),
+				PrimaryNumber:       int(vals[7].(int32)),
+				PrimaryDesignator:   int(vals[8].(int32)),
+				PrimaryILS:          types.ILS{Ident: vals[9].(string), Region: vals[10].(string)},
+				SecondaryNumber:     int(vals[11].(int32)),
+				SecondaryDesignator: int(vals[12].(int32)),
+				SecondaryILS:        types.ILS{Ident: vals[13].(string), Region: vals[14].(string)},
+			})
+		case FacilityDataFrequency:
+			vals, err := ParseSimVarValues(r.data, frequencyFacilityFields)
+			if err != nil {
+				return types.Airport{}, err
+			}
+			airport.Frequencies = append(airport.Frequencies, types.AirportFrequency{
+				Type:      int(vals[0].(int32)),
+				Frequency: float64(vals[1].(int32)),
+				Name:      vals[2].(string),
+			})
+		}
+	}
+	if !found {
+		return types.Airport{}, ErrFacilityNotFound
+	}
+	return airport, nil
+}
+
+// decodeILS fills ils from the records of an ilsFacility request.
+func decodeILS(records []facilityRecord, ils *types.ILS) error {
+	for _, r := range records {
+		if r.dataType != FacilityDataVOR {
+			continue
+		}
+		vals, err := ParseSimVarValues(r.data, ilsFacilityFields)
+		if err != nil {
+			return err
+		}
+		ils.Frequency = float64(vals[0].(int32))
+		ils.Course = float64(vals[1].(float32))
+		ils.GlideSlope = float64(vals[2].(float32))
+		ils.HasGlideSlope = vals[3].(int32) != 0
+		ils.Name = vals[4].(string)
+		return nil
+	}
+	return ErrFacilityNotFound
+}
@@ -0,0 +1,29 @@
+package simconnect
+
+import "github.com/eytandecker/flightsim-mcp/pkg/types"
+
+// ParseFlightPlanValues maps values read with FlightPlanSimVars, as returned
+// by ReadSimVars, onto a FlightPlan.
+func ParseFlightPlanValues(vals []any) types.FlightPlan {
+	str := func(i int) string { return vals[i].(string) }
+	num := func(i int) float64 { return vals[i].(float64) }
+
+	return types.FlightPlan{
+		IsActive:             num(0),
+		WaypointCount:        num(1),
+		ActiveWaypointIndex:  num(2),
+		PrevWaypointID:       str(3),
+		PrevWaypointLat:      num(4),
+		PrevWaypointLon:      num(5),
+		NextWaypointID:       str(6),
+		NextWaypointLat:      num(7),
+		NextWaypointLon:      num(8),
+		NextWaypointAltitude: num(9),
+		NextWaypointDistance: num(10),
+		NextWaypointETE:      num(11),
+		NextWaypointETA:      num(12),
+		DestinationETE:       num(13),
+		DestinationETA:       num(14),
+		CrossTrackError:      num(15),
+	}
+}
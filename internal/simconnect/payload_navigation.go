@@ -0,0 +1,51 @@
+package simconnect
+
+import (
+	"fmt"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// ParseNavigationPayload decodes a SimObjectData payload laid out as
+// NavigationSimVars into Navigation.
+func ParseNavigationPayload(data []byte) (types.Navigation, error) {
+	vals, err := ParseSimVarValues(data, NavigationSimVars)
+	if err != nil {
+		return types.Navigation{}, fmt.Errorf("parse navigation payload: %w", err)
+	}
+	num := func(i int) float64 { return vals[i].(float64) }
+	radio := func(n int) types.NavRadio {
+		i := (n - 1) * len(perNavRadioSimVars)
+		return types.NavRadio{
+			ActiveFrequency:  num(i),
+			StandbyFrequency: num(i + 1),
+			OBS:              num(i + 2),
+			Radial:           num(i + 3),
+			CDI:              num(i + 4),
+			GSI:              num(i + 5),
+			ToFrom:           num(i + 6),
+			DME:              num(i + 7),
+			HasNav:           num(i + 8),
+			HasLocalizer:     num(i + 9),
+			HasGlideSlope:    num(i + 10),
+		}
+	}
+	i := NavRadios * len(perNavRadioSimVars)
+
+	return types.Navigation{
+		Nav1:                    radio(1),
+		Nav2:                    radio(2),
+		ADFFrequency:            num(i),
+		ADFRadial:               num(i + 1),
+		Com1ActiveFrequency:     num(i + 2),
+		Com1StandbyFrequency:    num(i + 3),
+		Com2ActiveFrequency:     num(i + 4),
+		Com2StandbyFrequency:    num(i + 5),
+		TransponderCode:         DecodeBCD16(uint16(num(i + 6))),
+		GPSCourseToSteer:        num(i + 7),
+		GPSDesiredTrack:         num(i + 8),
+		GPSNextWaypointDistance: num(i + 9),
+		GPSNextWaypointETE:      num(i + 10),
+		GPSNextWaypointID:       vals[i+11].(string),
+	}, nil
+}
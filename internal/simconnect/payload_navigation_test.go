@@ -0,0 +1,63 @@
+package simconnect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func TestParseNavigationPayload(t *testing.T) {
+	data := le(
+		110.5, 113.9, 271.0, 268.5, -40.0, 12.0, 1.0, 8.4, 1.0, 1.0, 1.0, // NAV1
+		114.8, 108.0, 90.0, 95.0, 0.0, 0.0, 2.0, 22.1, 1.0, 0.0, 0.0, // NAV2
+		350.0, 45.0, // ADF
+		121.5, 118.3, 124.85, 121.9, // COM1, COM2
+		float64(0x7000),           // transponder, Bco16
+		275.0, 270.0, 12.5, 360.0, // GPS course to steer, DTK, distance, ETE
+	)
+	data = append(data, fixedString("KSEA", 32)...)
+
+	nav, err := ParseNavigationPayload(data)
+	require.NoError(t, err)
+	assert.Equal(t, types.Navigation{
+		Nav1: types.NavRadio{
+			ActiveFrequency: 110.5, StandbyFrequency: 113.9, OBS: 271, Radial: 268.5,
+			CDI: -40, GSI: 12, ToFrom: 1, DME: 8.4, HasNav: 1, HasLocalizer: 1, HasGlideSlope: 1,
+		},
+		Nav2: types.NavRadio{
+			ActiveFrequency: 114.8, StandbyFrequency: 108, OBS: 90, Radial: 95,
+			ToFrom: 2, DME: 22.1, HasNav: 1,
+		},
+		ADFFrequency:            350,
+		ADFRadial:               45,
+		Com1ActiveFrequency:     121.5,
+		Com1StandbyFrequency:    118.3,
+		Com2ActiveFrequency:     124.85,
+		Com2StandbyFrequency:    121.9,
+		TransponderCode:         7000,
+		GPSCourseToSteer:        275,
+		GPSDesiredTrack:         270,
+		GPSNextWaypointID:       "KSEA",
+		GPSNextWaypointDistance: 12.5,
+		GPSNextWaypointETE:      360,
+	}, nav)
+
+	_, err = ParseNavigationPayload(data[:len(data)-8])
+	assert.Error(t, err, "truncated payload")
+}
+
+func TestNavigationSimVars(t *testing.T) {
+	require.Len(t, NavigationSimVars, 34)
+	assert.Equal(t, "NAV ACTIVE FREQUENCY:1", NavigationSimVars[0].Name)
+	assert.Equal(t, "NAV ACTIVE FREQUENCY:2", NavigationSimVars[len(perNavRadioSimVars)].Name)
+	assert.Equal(t, "TRANSPONDER CODE:1", NavigationSimVars[28].Name)
+	assert.Equal(t, "Bco16", NavigationSimVars[28].Unit)
+
+	reg := NewSimVarRegistry()
+	for _, v := range NavigationSimVars {
+		assert.NoError(t, reg.Validate(v.Name), v.Name)
+	}
+}
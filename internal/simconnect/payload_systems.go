@@ -0,0 +1,46 @@
+package simconnect
+
+import (
+	"fmt"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// ParseSystemsPayload decodes a SimObjectData payload laid out as
+// SystemsSimVars into SystemsStatus.
+func ParseSystemsPayload(data []byte) (types.SystemsStatus, error) {
+	vals, err := ParseSimVarValues(data, SystemsSimVars)
+	if err != nil {
+		return types.SystemsStatus{}, fmt.Errorf("parse systems payload: %w", err)
+	}
+	num := func(i int) float64 { return vals[i].(float64) }
+
+	return types.SystemsStatus{
+		Fuel: types.FuelSystem{
+			TotalQuantity: num(0),
+			TotalWeight:   num(1),
+			LeftMain:      num(2),
+			RightMain:     num(3),
+			Center:        num(4),
+		},
+		Electrical: types.ElectricalSystem{
+			MasterBattery:     num(5),
+			MainBusVoltage:    num(6),
+			BatteryVoltage:    num(7),
+			BatteryLoad:       num(8),
+			GenAltBusVoltage1: num(9),
+			GenAltBusVoltage2: num(10),
+		},
+		Hydraulics: types.HydraulicSystem{
+			Pressure1: num(11),
+			Pressure2: num(12),
+			Pressure3: num(13),
+		},
+		Pressurization: types.PressurizationSystem{
+			CabinAltitude:        num(14),
+			CabinAltitudeRate:    num(15),
+			PressureDifferential: num(16),
+			DumpSwitch:           num(17),
+		},
+	}, nil
+}
@@ -0,0 +1,45 @@
+package simconnect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func TestParseSystemsPayload(t *testing.T) {
+	data := le(
+		53.0, 318.0, 26.0, 25.5, 0.0, // fuel: total gal, total lbs, left, right, center
+		1.0, 28.1, 24.4, 3.2, 28.0, 0.0, // electrical: master, main bus, battery, load, gen 1, gen 2
+		3000.0, 2950.0, 0.0, // hydraulics
+		8000.0, 500.0, 7.8, 0.0, // pressurization: cabin alt, rate, diff, dump
+	)
+	sys, err := ParseSystemsPayload(data)
+	require.NoError(t, err)
+	assert.Equal(t, types.SystemsStatus{
+		Fuel: types.FuelSystem{
+			TotalQuantity: 53,
+			TotalWeight:   318,
+			LeftMain:      26,
+			RightMain:     25.5,
+		},
+		Electrical: types.ElectricalSystem{
+			MasterBattery:     1,
+			MainBusVoltage:    28.1,
+			BatteryVoltage:    24.4,
+			BatteryLoad:       3.2,
+			GenAltBusVoltage1: 28,
+		},
+		Hydraulics: types.HydraulicSystem{Pressure1: 3000, Pressure2: 2950},
+		Pressurization: types.PressurizationSystem{
+			CabinAltitude:        8000,
+			CabinAltitudeRate:    500,
+			PressureDifferential: 7.8,
+		},
+	}, sys)
+
+	_, err = ParseSystemsPayload(data[:len(data)-8])
+	assert.Error(t, err, "truncated payload")
+}
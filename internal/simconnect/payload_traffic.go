@@ -0,0 +1,25 @@
+package simconnect
+
+import "github.com/eytandecker/flightsim-mcp/pkg/types"
+
+// ParseTrafficValues maps values read with TrafficSimVars, as returned by
+// ReadSimObjects, onto a TrafficObject. ObjectID and Type are left to the caller.
+func ParseTrafficValues(vals []any) types.TrafficObject {
+	str := func(i int) string { return vals[i].(string) }
+	num := func(i int) float64 { return vals[i].(float64) }
+
+	return types.TrafficObject{
+		UserSim:         num(0),
+		ATCID:           str(1),
+		ATCAirline:      str(2),
+		ATCFlightNumber: str(3),
+		ATCModel:        str(4),
+		Latitude:        num(5),
+		Longitude:       num(6),
+		Altitude:        num(7),
+		HeadingTrue:     num(8),
+		GroundSpeed:     num(9),
+		VerticalSpeed:   num(10),
+		OnGround:        num(11),
+	}
+}
@@ -17,16 +17,47 @@ type StateUpdater interface {
 	UpdateEngine(eng types.EngineData)
 	UpdateEnvironment(env types.Environment)
 	UpdateAutopilot(ap types.AutopilotState)
+	UpdateAircraft(info types.AircraftInfo)
+	UpdateFlightControls(fc types.FlightControls)
+	UpdateSystems(sys types.SystemsStatus)
+	UpdateNavigation(nav types.Navigation)
+	UpdateLifecycle(l types.SimLifecycle)
 }
 
+// PollMode selects how the Poller asks SimConnect for data.
+type PollMode int
+
+const (
+	// PollModeOnce sends a PERIOD_ONCE request for each group when its interval elapses.
+	PollModeOnce PollMode = iota
+	// PollModeSubscribe subscribes once per group and consumes the stream.
+	PollModeSubscribe
+)
+
+// nominalFrameTime is the frame duration assumed when converting a group
+// interval into a frame count for PERIOD_SIM_FRAME and PERIOD_VISUAL_FRAME.
+const nominalFrameTime = time.Second / 30
+
 // PollerConfig holds configuration for the Poller.
 type PollerConfig struct {
+	// PollInterval is the interval for groups without an entry in GroupIntervals.
 	PollInterval time.Duration
+	// GroupIntervals sets a poll interval per data definition ID (DefID*).
+	GroupIntervals map[uint32]time.Duration
+	Mode           PollMode
+	// Subscription holds the period used in PollModeSubscribe. When a group
+	// has an interval, it replaces Subscription.Interval with the number of
+	// periods to skip. Flags are ignored: TAGGED data cannot be decoded as a
+	// group, and CHANGED would let unchanging groups go stale.
+	Subscription RequestOptions
 }
 
 // DefaultPollerConfig returns a PollerConfig with sensible defaults.
 func DefaultPollerConfig() PollerConfig {
-	return PollerConfig{PollInterval: 500 * time.Millisecond}
+	return PollerConfig{
+		PollInterval: 500 * time.Millisecond,
+		Subscription: RequestOptions{Period: PeriodSecond},
+	}
 }
 
 // Poller manages periodic SimConnect data requests and feeds updates to a StateUpdater.
@@ -34,6 +65,7 @@ type Poller struct {
 	client  *Client
 	updater StateUpdater
 	cfg     PollerConfig
+	life    types.SimLifecycle // owned by readLoop
 }
 
 // NewPoller creates a Poller backed by the given client and updater.
@@ -41,19 +73,24 @@ func NewPoller(client *Client, updater StateUpdater, cfg PollerConfig) *Poller {
 	return &Poller{client: client, updater: updater, cfg: cfg}
 }
 
-// dataGroup pairs a definition ID with a SimVar slice for registration.
+// dataGroup ties a definition ID and request ID to the SimVars it carries.
 type dataGroup struct {
 	defID uint32
+	reqID uint32
 	vars  []SimVarDef
 }
 
 // allGroups lists all data groups to register and poll.
 var allGroups = []dataGroup{
-	{DefIDPosition, PositionSimVars},
-	{DefIDInstruments, InstrumentsSimVars},
-	{DefIDEngine, EngineSimVars},
-	{DefIDEnvironment, EnvironmentSimVars},
-	{DefIDAutopilot, AutopilotSimVars},
+	{DefIDPosition, ReqIDPosition, PositionSimVars},
+	{DefIDInstruments, ReqIDInstruments, InstrumentsSimVars},
+	{DefIDEngine, ReqIDEngine, EngineSimVars},
+	{DefIDEnvironment, ReqIDEnvironment, EnvironmentSimVars},
+	{DefIDAutopilot, ReqIDAutopilot, AutopilotSimVars},
+	{DefIDAircraft, ReqIDAircraft, AircraftSimVars},
+	{DefIDFlightControls, ReqIDFlightControls, FlightControlsSimVars},
+	{DefIDSystems, ReqIDSystems, SystemsSimVars},
+	{DefIDNavigation, ReqIDNavigation, NavigationSimVars},
 }
 
 // RegisterSimVars calls AddToDataDefinition for each var in all data groups.
@@ -68,48 +105,145 @@ func (p *Poller) RegisterSimVars() error {
 	return nil
 }
 
-// requestIDs maps definition IDs to request IDs for polling.
-var requestIDs = []struct {
-	defID uint32
-	reqID uint32
+// systemEvents lists the system events the Poller subscribes to, by ID.
+var systemEvents = []struct {
+	id   uint32
+	name string
 }{
-	{DefIDPosition, ReqIDPosition},
-	{DefIDInstruments, ReqIDInstruments},
-	{DefIDEngine, ReqIDEngine},
-	{DefIDEnvironment, ReqIDEnvironment},
-	{DefIDAutopilot, ReqIDAutopilot},
+	{SysEventIDAircraftLoaded, SystemEventAircraftLoaded},
+	{SysEventIDFlightLoaded, SystemEventFlightLoaded},
+	{SysEventIDPause, SystemEventPause},
+	{SysEventIDCrashed, SystemEventCrashed},
+	{SysEventIDCrashReset, SystemEventCrashReset},
+	{SysEventIDSimStart, SystemEventSimStart},
+	{SysEventIDSimStop, SystemEventSimStop},
 }
 
-// Start blocks, sending periodic RequestData messages and processing responses.
-// It exits when ctx is canceled or the connection is closed.
+// SubscribeSystemEvents subscribes to the system events the Poller reacts to
+// and asks for the "Sim" state, since SimStart and SimStop only report
+// changes. The events keep the updater's SimLifecycle current; AircraftLoaded
+// also re-reads the aircraft group. Like data definitions, system event
+// subscriptions must be renewed on every connection.
+func (p *Poller) SubscribeSystemEvents() error {
+	for _, ev := range systemEvents {
+		if err := p.client.SubscribeToSystemEvent(ev.id, ev.name); err != nil {
+			return err
+		}
+	}
+	return p.client.RequestSystemState(ReqIDSimState, "Sim")
+}
+
+// interval returns the poll interval for the group with the given definition ID.
+func (p *Poller) interval(defID uint32) time.Duration {
+	if d := p.cfg.GroupIntervals[defID]; d > 0 {
+		return d
+	}
+	if p.cfg.PollInterval > 0 {
+		return p.cfg.PollInterval
+	}
+	return 500 * time.Millisecond
+}
+
+// Start blocks, requesting data and processing responses. In PollModeOnce it
+// sends RequestData for each group whenever that group's interval elapses; in
+// PollModeSubscribe it subscribes once and cancels the subscriptions when ctx
+// is done. It exits when ctx is canceled or the connection is closed.
+// Subscriptions belong to the connection, so calling Start after a reconnect
+// subscribes again. The updater's SimLifecycle is reset when Start begins and
+// returns, so a lifecycle seen on an old connection is never reported.
 func (p *Poller) Start(ctx context.Context) error {
-	interval := p.cfg.PollInterval
-	if interval == 0 {
-		interval = 500 * time.Millisecond
+	p.updater.UpdateLifecycle(types.SimLifecycle{})
+	defer p.updater.UpdateLifecycle(types.SimLifecycle{})
+
+	if p.cfg.Mode == PollModeSubscribe {
+		return p.runSubscribed(ctx)
 	}
 
-	ticker := time.NewTicker(interval)
+	// Tick at the fastest group's rate and request each group when it is due.
+	tick := p.interval(allGroups[0].defID)
+	for _, g := range allGroups[1:] {
+		tick = min(tick, p.interval(g.defID))
+	}
+	ticker := time.NewTicker(tick)
 	defer ticker.Stop()
 
 	done := make(chan error, 1)
 	go p.readLoop(done)
 
+	due := make(map[uint32]time.Time, len(allGroups))
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		case err := <-done:
 			return err
-		case <-ticker.C:
-			for _, r := range requestIDs {
-				if err := p.client.RequestData(r.defID, ObjectIDUser, r.reqID); err != nil {
+		case now := <-ticker.C:
+			for _, g := range allGroups {
+				if now.Before(due[g.defID]) {
+					continue
+				}
+				if err := p.client.RequestData(g.defID, ObjectIDUser, g.reqID); err != nil {
 					return err
 				}
+				// Half a tick of slack keeps ticker jitter from skipping a beat.
+				due[g.defID] = now.Add(p.interval(g.defID) - tick/2)
 			}
 		}
 	}
 }
 
+// runSubscribed subscribes every group and consumes the resulting stream.
+func (p *Poller) runSubscribed(ctx context.Context) error {
+	done := make(chan error, 1)
+	go p.readLoop(done)
+
+	for _, g := range allGroups {
+		if err := p.client.RequestDataWithOptions(g.defID, ObjectIDUser, g.reqID, p.subscription(g.defID)); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		p.unsubscribe()
+		return ctx.Err()
+	case err := <-done:
+		return err
+	}
+}
+
+// subscription returns the request options for a group in PollModeSubscribe.
+func (p *Poller) subscription(defID uint32) RequestOptions {
+	opts := p.cfg.Subscription
+	opts.Flags = DataRequestFlagDefault
+	if opts.Period == PeriodNever || opts.Period == PeriodOnce {
+		opts.Period = PeriodSecond
+	}
+	d, ok := p.cfg.GroupIntervals[defID]
+	if !ok || d <= 0 {
+		return opts
+	}
+
+	period := nominalFrameTime
+	if opts.Period == PeriodSecond {
+		period = time.Second
+	}
+	n := (d + period/2) / period        // round to the nearest whole period
+	opts.Interval = uint32(max(n-1, 0)) // #nosec G115 -- clamped to non-negative; intervals are small
+	return opts
+}
+
+// unsubscribe cancels every group subscription. Failures are logged only:
+// the sim drops subscriptions with the connection anyway.
+func (p *Poller) unsubscribe() {
+	for _, g := range allGroups {
+		if err := p.client.CancelDataRequest(g.defID, ObjectIDUser, g.reqID); err != nil {
+			log.Printf("simconnect: cancel request %d: %v", g.reqID, err)
+			return
+		}
+	}
+}
+
 // simObjectDataHeaderSize is the size of the SimObjectData response header
 // that precedes the raw SimVar data.
 const simObjectDataHeaderSize = 28
@@ -129,15 +263,63 @@ func (p *Poller) readLoop(done chan<- error) {
 				continue
 			}
 			reqID := binary.LittleEndian.Uint32(data[0:4])
-			p.dispatchPayload(reqID, data[simObjectDataHeaderSize:])
+			if reqID == ReqIDAircraftRefresh {
+				reqID = ReqIDAircraft
+			}
+			p.dispatchPayload(reqID, p.repairLayout(reqID, data[simObjectDataHeaderSize:]))
+		case RecvEvent, RecvEventFilename:
+			p.handleEvent(h.Type, data)
+		case RecvSystemState:
+			p.handleSystemState(data)
 		case RecvException:
 			p.handleException(data)
 		case RecvOpen:
-			log.Printf("simconnect: received OPEN ack")
+			if info, err := ParseOpen(data); err == nil {
+				log.Printf("simconnect: connected to %s %s (SimConnect %s)", info.AppName, info.AppVersion, info.SimConnectVersion)
+			}
 		}
 	}
 }
 
+// repairLayout re-inserts zeroed slots for SimVars the simulator rejected, so
+// one bad SimVar does not shift every later value in its group. Payloads that
+// already have the full layout are returned unchanged.
+func (p *Poller) repairLayout(reqID uint32, data []byte) []byte {
+	var g *dataGroup
+	for i := range allGroups {
+		if allGroups[i].reqID == reqID {
+			g = &allGroups[i]
+			break
+		}
+	}
+	if g == nil {
+		return data
+	}
+	full := 0
+	for _, sv := range g.vars {
+		full += sv.Size
+	}
+	if len(data) >= full {
+		return data
+	}
+
+	simvars := p.client.SimVars()
+	out := make([]byte, 0, full)
+	rest := data
+	for _, sv := range g.vars {
+		if simvars.IsRejected(g.defID, sv.Name) {
+			out = append(out, make([]byte, sv.Size)...)
+			continue
+		}
+		if len(rest) < sv.Size {
+			return data // not explained by rejections; let the parser report it
+		}
+		out = append(out, rest[:sv.Size]...)
+		rest = rest[sv.Size:]
+	}
+	return out
+}
+
 // dispatchPayload parses and routes raw SimVar data by request ID.
 func (p *Poller) dispatchPayload(reqID uint32, data []byte) {
 	switch reqID {
@@ -176,17 +358,102 @@ func (p *Poller) dispatchPayload(reqID uint32, data []byte) {
 			return
 		}
 		p.updater.UpdateAutopilot(ap)
+	case ReqIDAircraft:
+		info, err := ParseAircraftPayload(data)
+		if err != nil {
+			log.Printf("simconnect: parse aircraft payload: %v", err)
+			return
+		}
+		p.updater.UpdateAircraft(info)
+	case ReqIDFlightControls:
+		fc, err := ParseFlightControlsPayload(data)
+		if err != nil {
+			log.Printf("simconnect: parse flight controls payload: %v", err)
+			return
+		}
+		p.updater.UpdateFlightControls(fc)
+	case ReqIDSystems:
+		sys, err := ParseSystemsPayload(data)
+		if err != nil {
+			log.Printf("simconnect: parse systems payload: %v", err)
+			return
+		}
+		p.updater.UpdateSystems(sys)
+	case ReqIDNavigation:
+		nav, err := ParseNavigationPayload(data)
+		if err != nil {
+			log.Printf("simconnect: parse navigation payload: %v", err)
+			return
+		}
+		p.updater.UpdateNavigation(nav)
+	}
+}
+
+// handleEvent reacts to subscribed system events.
+func (p *Poller) handleEvent(recvType uint32, data []byte) {
+	parse := ParseEvent
+	if recvType == RecvEventFilename {
+		parse = ParseEventFilename
+	}
+	ev, err := parse(data)
+	if err != nil {
+		log.Printf("simconnect: %v", err)
+		return
+	}
+	switch ev.EventID {
+	case SysEventIDAircraftLoaded:
+		log.Printf("simconnect: aircraft loaded: %s", ev.Filename)
+		p.life.AircraftFile = ev.Filename
+		if err := p.client.RequestData(DefIDAircraft, ObjectIDUser, ReqIDAircraftRefresh); err != nil {
+			log.Printf("simconnect: refresh aircraft info: %v", err)
+		}
+	case SysEventIDFlightLoaded:
+		log.Printf("simconnect: flight loaded: %s", ev.Filename)
+		p.life.FlightFile = ev.Filename
+	case SysEventIDPause:
+		p.life.Paused = ev.Data != 0
+	case SysEventIDCrashed:
+		log.Printf("simconnect: user aircraft crashed")
+		p.life.Crashed = true
+	case SysEventIDCrashReset:
+		p.life.Crashed = false
+	case SysEventIDSimStart:
+		p.life.Known, p.life.Running, p.life.Crashed = true, true, false
+	case SysEventIDSimStop:
+		p.life.Known, p.life.Running = true, false
+	default:
+		return
+	}
+	p.updater.UpdateLifecycle(p.life)
+}
+
+// handleSystemState records whether a flight is running from "Sim" state
+// replies, both the one SubscribeSystemEvents asks for and the heartbeat's.
+func (p *Poller) handleSystemState(data []byte) {
+	st, err := ParseSystemState(data)
+	if err != nil {
+		log.Printf("simconnect: %v", err)
+		return
+	}
+	if st.RequestID != ReqIDSimState && st.RequestID != ReqIDHeartbeat {
+		return
+	}
+	running := st.Integer != 0
+	if p.life.Known && p.life.Running == running {
+		return
 	}
+	p.life.Known, p.life.Running = true, running
+	p.updater.UpdateLifecycle(p.life)
 }
 
-// handleException logs SimConnect exception details.
+// handleException logs SimConnect exception details, including the message
+// that caused it when the client still remembers it.
 func (p *Poller) handleException(data []byte) {
-	if len(data) < 12 {
+	exc, err := ParseException(data)
+	if err != nil {
 		log.Printf("simconnect: received exception (payload too short to parse)")
 		return
 	}
-	exceptionCode := binary.LittleEndian.Uint32(data[0:4])
-	sendID := binary.LittleEndian.Uint32(data[4:8])
-	index := binary.LittleEndian.Uint32(data[8:12])
-	log.Printf("simconnect: exception code=%d sendID=%d index=%d", exceptionCode, sendID, index)
+	exc.Message, _ = p.client.LookupSend(exc.SendID)
+	log.Printf("%v", exc)
 }
@@ -0,0 +1,52 @@
+package simconnect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+)
+
+func TestPollerRefreshesAircraftOnAircraftLoaded(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := simconnect.NewClient(srv.Config())
+	require.NoError(t, client.Connect(ctx))
+	mgr := state.NewManager(time.Minute)
+	poller := simconnect.NewPoller(client, mgr, simconnect.PollerConfig{
+		// No group is polled during the test, so aircraft info can only come
+		// from the AircraftLoaded refresh.
+		PollInterval: time.Hour,
+		Mode:         simconnect.PollModeOnce,
+	})
+	require.NoError(t, poller.RegisterSimVars())
+	require.NoError(t, poller.SubscribeSystemEvents())
+	go func() { _ = poller.Start(ctx) }()
+
+	require.Eventually(t, func() bool {
+		return srv.SystemEventSubscriptions()[simconnect.SystemEventAircraftLoaded] == simconnect.SysEventIDAircraftLoaded
+	}, time.Second, 5*time.Millisecond)
+
+	srv.SetSimVar("TITLE", "Airbus A320neo Asobo")
+	srv.SetSimVar("ATC ID", "D-AINA")
+	srv.SetSimVar("NUMBER OF ENGINES", 2)
+	srv.FireSystemEvent(simconnect.SystemEventAircraftLoaded, 0, `SimObjects\Airplanes\Asobo_A320_NEO\aircraft.cfg`)
+
+	require.Eventually(t, func() bool {
+		info, err := mgr.GetAircraftInfo()
+		return err == nil && info.Title == "Airbus A320neo Asobo"
+	}, time.Second, 5*time.Millisecond)
+	info, err := mgr.GetAircraftInfo()
+	require.NoError(t, err)
+	assert.Equal(t, "D-AINA", info.ATCID)
+	assert.Equal(t, 2.0, info.NumberOfEngines)
+}
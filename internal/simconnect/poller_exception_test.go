@@ -0,0 +1,57 @@
+package simconnect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+)
+
+func TestPollerSurvivesRejectedSimVar(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.RejectSimVar(simconnect.PlaneAltAboveGround.Name, simconnect.ExceptionNameUnrecognized)
+	// The sim omits the rejected SimVar from the payload.
+	srv.SetFloat64s(simconnect.ReqIDPosition, 47.5, -122.25, 1000, 90, 88, 100, 105, 98, -500, 2, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := simconnect.NewClient(srv.Config())
+	mgr := state.NewManager(5 * time.Second)
+	done := runSubscribedPoller(ctx, t, client, mgr)
+
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetPosition()
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+	pos, err := mgr.GetPosition()
+	require.NoError(t, err)
+	assert.Equal(t, 47.5, pos.Latitude)
+	assert.Equal(t, 1000.0, pos.AltitudeMSL)
+	assert.Zero(t, pos.AltitudeAGL, "rejected SimVar reads as zero")
+	assert.Equal(t, 90.0, pos.HeadingTrue)
+	assert.Equal(t, -500.0, pos.VerticalSpeed)
+	assert.Equal(t, 3.0, pos.Bank)
+
+	diag := client.Diagnostics()
+	require.Len(t, diag.RejectedSimVars, 1)
+	rejected := diag.RejectedSimVars[0]
+	assert.Equal(t, simconnect.PlaneAltAboveGround.Name, rejected.Name)
+	assert.Equal(t, simconnect.DefIDPosition, rejected.DefID)
+	assert.ErrorIs(t, rejected.Exception, simconnect.ErrNameUnrecognized)
+
+	require.Len(t, diag.RecentExceptions, 1)
+	exc := diag.RecentExceptions[0]
+	assert.Equal(t, simconnect.SendAddToDataDef, exc.Message.Type)
+	assert.Equal(t, simconnect.PlaneAltAboveGround.Name, exc.Message.SimVar)
+	assert.False(t, exc.Received.IsZero())
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
@@ -0,0 +1,102 @@
+package simconnect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+func TestPollerTracksSimLifecycle(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.SetSimVar("PLANE LATITUDE", 47.45)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := simconnect.NewClient(srv.Config())
+	require.NoError(t, client.Connect(ctx))
+	mgr := state.NewManager(time.Minute)
+	poller := simconnect.NewPoller(client, mgr, simconnect.PollerConfig{
+		PollInterval: 20 * time.Millisecond,
+		Mode:         simconnect.PollModeOnce,
+	})
+	require.NoError(t, poller.RegisterSimVars())
+	require.NoError(t, poller.SubscribeSystemEvents())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		_ = poller.Start(ctx)
+	}()
+
+	lifecycleIs := func(want types.SimLifecycle) func() bool {
+		return func() bool { return mgr.GetLifecycle() == want }
+	}
+
+	// The "Sim" state reply says a flight is running.
+	require.Eventually(t, lifecycleIs(types.SimLifecycle{Known: true, Running: true}), time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetPosition()
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+	for _, name := range []string{
+		simconnect.SystemEventAircraftLoaded, simconnect.SystemEventFlightLoaded, simconnect.SystemEventPause,
+		simconnect.SystemEventCrashed, simconnect.SystemEventCrashReset,
+		simconnect.SystemEventSimStart, simconnect.SystemEventSimStop,
+	} {
+		assert.Contains(t, srv.SystemEventSubscriptions(), name)
+	}
+
+	srv.FireSystemEvent(simconnect.SystemEventPause, 1, "")
+	require.Eventually(t, lifecycleIs(types.SimLifecycle{Known: true, Running: true, Paused: true}), time.Second, 5*time.Millisecond)
+	_, err := mgr.GetPosition()
+	assert.ErrorIs(t, err, state.ErrPaused)
+
+	srv.FireSystemEvent(simconnect.SystemEventPause, 0, "")
+	srv.FireSystemEvent(simconnect.SystemEventCrashed, 0, "")
+	require.Eventually(t, lifecycleIs(types.SimLifecycle{Known: true, Running: true, Crashed: true}), time.Second, 5*time.Millisecond)
+	srv.FireSystemEvent(simconnect.SystemEventCrashReset, 0, "")
+	require.Eventually(t, lifecycleIs(types.SimLifecycle{Known: true, Running: true}), time.Second, 5*time.Millisecond)
+
+	srv.FireSystemEvent(simconnect.SystemEventSimStop, 0, "")
+	srv.FireSystemEvent(simconnect.SystemEventFlightLoaded, 0, `flights\other\MainMenu.FLT`)
+	require.Eventually(t, lifecycleIs(types.SimLifecycle{Known: true, FlightFile: `flights\other\MainMenu.FLT`}), time.Second, 5*time.Millisecond)
+	_, err = mgr.GetPosition()
+	assert.ErrorIs(t, err, state.ErrInMenu)
+
+	srv.FireSystemEvent(simconnect.SystemEventSimStart, 0, "")
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetPosition()
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-stopped
+	assert.Equal(t, types.SimLifecycle{}, mgr.GetLifecycle(), "lifecycle is reset when the poller stops")
+}
+
+func TestPollerReadsSimStateFromHeartbeat(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.FireSystemEvent(simconnect.SystemEventSimStop, 0, "") // no subscribers yet; only sets the "Sim" state
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := simconnect.NewClient(srv.Config())
+	require.NoError(t, client.Connect(ctx))
+	mgr := state.NewManager(time.Minute)
+	poller := simconnect.NewPoller(client, mgr, simconnect.PollerConfig{PollInterval: time.Hour})
+	go func() { _ = poller.Start(ctx) }()
+
+	require.Eventually(t, func() bool {
+		_ = client.RequestSystemState(simconnect.ReqIDHeartbeat, "Sim")
+		return mgr.GetLifecycle() == types.SimLifecycle{Known: true}
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,66 @@
+package simconnect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+	"github.com/eytandecker/flightsim-mcp/internal/state"
+)
+
+// runSubscribedPoller connects client to srv and runs a subscribe-mode poller
+// until the connection drops or ctx is done.
+func runSubscribedPoller(ctx context.Context, t *testing.T, client *simconnect.Client, mgr *state.Manager) <-chan error {
+	t.Helper()
+	require.NoError(t, client.Connect(ctx))
+	poller := simconnect.NewPoller(client, mgr, simconnect.PollerConfig{
+		Mode:         simconnect.PollModeSubscribe,
+		Subscription: simconnect.RequestOptions{Period: simconnect.PeriodSimFrame},
+	})
+	require.NoError(t, poller.RegisterSimVars())
+
+	done := make(chan error, 1)
+	go func() { done <- poller.Start(ctx) }()
+	return done
+}
+
+func TestPollerResubscribesAfterReconnect(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.SetFloat64s(simconnect.ReqIDPosition, make([]float64, len(simconnect.PositionSimVars))...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := simconnect.NewClient(srv.Config())
+	mgr := state.NewManager(5 * time.Second)
+
+	done := runSubscribedPoller(ctx, t, client, mgr)
+	require.Eventually(t, func() bool { return len(srv.Subscriptions()) == 9 }, time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool {
+		_, err := mgr.GetPosition()
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+
+	// Simulate the sim dropping the connection: subscriptions die with it.
+	srv.CloseClientConnections()
+	require.Error(t, <-done)
+	require.Eventually(t, func() bool { return len(srv.Subscriptions()) == 0 }, time.Second, 5*time.Millisecond)
+
+	srv.SetFloat64s(simconnect.ReqIDPosition, 47.5, -122.25, 1000, 900, 90, 88, 100, 105, 98, 0, 0, 0)
+	done = runSubscribedPoller(ctx, t, client, mgr)
+	require.Eventually(t, func() bool { return len(srv.Subscriptions()) == 9 }, time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool {
+		pos, err := mgr.GetPosition()
+		return err == nil && pos.Latitude == 47.5
+	}, time.Second, 5*time.Millisecond)
+
+	// Shutdown cancels the subscriptions before the connection closes.
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	require.Eventually(t, func() bool { return len(srv.Subscriptions()) == 0 }, time.Second, 5*time.Millisecond)
+}
@@ -23,6 +23,11 @@ type mockUpdater struct {
 	engines      []types.EngineData
 	environments []types.Environment
 	autopilots   []types.AutopilotState
+	aircraft     []types.AircraftInfo
+	controls     []types.FlightControls
+	systems      []types.SystemsStatus
+	navigation   []types.Navigation
+	lifecycles   []types.SimLifecycle
 }
 
 func (m *mockUpdater) Update(pos types.AircraftPosition) { //nolint:gocritic
@@ -55,6 +60,58 @@ func (m *mockUpdater) UpdateAutopilot(ap types.AutopilotState) { //nolint:gocrit
 	m.autopilots = append(m.autopilots, ap)
 }
 
+func (m *mockUpdater) UpdateAircraft(info types.AircraftInfo) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aircraft = append(m.aircraft, info)
+}
+
+func (m *mockUpdater) UpdateFlightControls(fc types.FlightControls) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.controls = append(m.controls, fc)
+}
+
+func (m *mockUpdater) ControlsCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.controls)
+}
+
+func (m *mockUpdater) UpdateSystems(sys types.SystemsStatus) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.systems = append(m.systems, sys)
+}
+
+func (m *mockUpdater) UpdateNavigation(nav types.Navigation) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.navigation = append(m.navigation, nav)
+}
+
+func (m *mockUpdater) UpdateLifecycle(l types.SimLifecycle) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lifecycles = append(m.lifecycles, l)
+}
+
+// Lifecycle returns the last lifecycle stored, or the zero value.
+func (m *mockUpdater) Lifecycle() types.SimLifecycle {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.lifecycles) == 0 {
+		return types.SimLifecycle{}
+	}
+	return m.lifecycles[len(m.lifecycles)-1]
+}
+
+func (m *mockUpdater) AircraftCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.aircraft)
+}
+
 func (m *mockUpdater) PositionCount() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -137,10 +194,11 @@ func TestRegisterSimVarsSendsAllDefinitions(t *testing.T) {
 	updater := &mockUpdater{}
 	p, serverConn := newConnectedPoller(t, updater, DefaultPollerConfig())
 
-	// Total SimVars across all 5 groups: 12 + 11 + 20 + 8 + 12 = 63
+	// Total SimVars across all 9 groups: 12 + 11 + 44 + 10 + 12 + 13 + 15 + 18 + 34 = 169
 	totalVars := len(PositionSimVars) + len(InstrumentsSimVars) + len(EngineSimVars) +
-		len(EnvironmentSimVars) + len(AutopilotSimVars)
-	assert.Equal(t, 63, totalVars)
+		len(EnvironmentSimVars) + len(AutopilotSimVars) + len(AircraftSimVars) + len(FlightControlsSimVars) +
+		len(SystemsSimVars) + len(NavigationSimVars)
+	assert.Equal(t, 169, totalVars)
 
 	received := make(chan SendHeader, totalVars)
 	go func() {
@@ -267,7 +325,7 @@ func TestReadLoopDispatchesEngine(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	vals := make([]float64, 20)
+	vals := make([]float64, len(EngineSimVars))
 	vals[0] = 2.0 // NumberOfEngines
 	rawData := buildFloat64Payload(vals)
 	payload := buildSimObjectDataResponse(ReqIDEngine, 0, DefIDEngine, rawData)
@@ -292,7 +350,7 @@ func TestReadLoopDispatchesEnvironment(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	vals := make([]float64, 8)
+	vals := make([]float64, len(EnvironmentSimVars))
 	vals[0] = 15.0 // WindVelocity
 	vals[2] = -5.5 // Temperature
 	rawData := buildFloat64Payload(vals)
@@ -375,3 +433,156 @@ func TestStartExitsWhenContextCancelled(t *testing.T) {
 		t.Fatal("Start did not exit after context cancellation")
 	}
 }
+
+// requestPeriods drains messages from serverConn and reports the period of
+// every RequestData message.
+func requestPeriods(serverConn net.Conn) <-chan Period {
+	out := make(chan Period, 32)
+	go func() {
+		for {
+			h, p, err := drainOneMessage(serverConn)
+			if err != nil {
+				close(out)
+				return
+			}
+			if h.Type == SendRequestData|SendTypeMask && len(p) >= 16 {
+				out <- Period(binary.LittleEndian.Uint32(p[12:16]))
+			}
+		}
+	}()
+	return out
+}
+
+func TestStartSubscribesOncePerGroup(t *testing.T) {
+	updater := &mockUpdater{}
+	cfg := PollerConfig{
+		PollInterval: 10 * time.Millisecond,
+		Mode:         PollModeSubscribe,
+		Subscription: RequestOptions{Period: PeriodSimFrame, Interval: 5},
+	}
+	p, serverConn := newConnectedPoller(t, updater, cfg)
+	periods := requestPeriods(serverConn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- p.Start(ctx) }()
+
+	for i := range allGroups {
+		select {
+		case period := <-periods:
+			assert.Equal(t, PeriodSimFrame, period, "request %d", i)
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for subscription %d", i)
+		}
+	}
+
+	select {
+	case period := <-periods:
+		t.Fatalf("unexpected extra request with period %d", period)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	for i := range allGroups {
+		select {
+		case period := <-periods:
+			assert.Equal(t, PeriodNever, period, "cancel %d", i)
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for cancellation %d", i)
+		}
+	}
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestStartSubscribeDefaultsToPeriodSecond(t *testing.T) {
+	updater := &mockUpdater{}
+	p, serverConn := newConnectedPoller(t, updater, PollerConfig{Mode: PollModeSubscribe})
+	defer serverConn.Close()
+	periods := requestPeriods(serverConn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = p.Start(ctx) }()
+
+	select {
+	case period := <-periods:
+		assert.Equal(t, PeriodSecond, period)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for subscription")
+	}
+}
+
+func TestSubscriptionIgnoresFlags(t *testing.T) {
+	for _, flags := range []uint32{DataRequestFlagChanged, DataRequestFlagTagged, DataRequestFlagChanged | DataRequestFlagTagged} {
+		p := NewPoller(nil, nil, PollerConfig{
+			GroupIntervals: map[uint32]time.Duration{DefIDPosition: time.Second},
+			Subscription:   RequestOptions{Period: PeriodSecond, Flags: flags},
+		})
+		assert.Equal(t, DataRequestFlagDefault, p.subscription(DefIDPosition).Flags, "flags %#x", flags)
+		assert.Equal(t, DataRequestFlagDefault, p.subscription(DefIDEngine).Flags, "flags %#x", flags)
+	}
+}
+
+func TestStartPollsEachGroupAtItsOwnInterval(t *testing.T) {
+	updater := &mockUpdater{}
+	cfg := PollerConfig{
+		PollInterval: time.Second,
+		GroupIntervals: map[uint32]time.Duration{
+			DefIDPosition:    10 * time.Millisecond,
+			DefIDEnvironment: 100 * time.Millisecond,
+		},
+	}
+	p, serverConn := newConnectedPoller(t, updater, cfg)
+
+	var mu sync.Mutex
+	counts := make(map[uint32]int)
+	go func() {
+		for {
+			h, payload, err := drainOneMessage(serverConn)
+			if err != nil {
+				return
+			}
+			if h.Type == SendRequestData|SendTypeMask {
+				mu.Lock()
+				counts[binary.LittleEndian.Uint32(payload[0:4])]++
+				mu.Unlock()
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 350*time.Millisecond)
+	defer cancel()
+	_ = p.Start(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.GreaterOrEqual(t, counts[ReqIDPosition], 15, "fast group")
+	assert.GreaterOrEqual(t, counts[ReqIDEnvironment], 2, "slow group")
+	assert.LessOrEqual(t, counts[ReqIDEnvironment], 5, "slow group")
+	assert.Equal(t, 1, counts[ReqIDEngine], "groups without an interval use PollInterval")
+}
+
+func TestSubscriptionIntervalFollowsGroupInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		period   Period
+		interval time.Duration
+		want     RequestOptions
+	}{
+		{"sim frame every other frame", PeriodSimFrame, 50 * time.Millisecond, RequestOptions{Period: PeriodSimFrame, Interval: 1}},
+		{"sim frame once a second", PeriodSimFrame, time.Second, RequestOptions{Period: PeriodSimFrame, Interval: 29}},
+		{"every frame", PeriodVisualFrame, 10 * time.Millisecond, RequestOptions{Period: PeriodVisualFrame, Interval: 0}},
+		{"seconds", PeriodSecond, 5 * time.Second, RequestOptions{Period: PeriodSecond, Interval: 4}},
+		{"sub-second on PERIOD_SECOND", PeriodSecond, 200 * time.Millisecond, RequestOptions{Period: PeriodSecond, Interval: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPoller(nil, nil, PollerConfig{
+				GroupIntervals: map[uint32]time.Duration{DefIDPosition: tt.interval},
+				Subscription:   RequestOptions{Period: tt.period, Interval: 99},
+			})
+			assert.Equal(t, tt.want, p.subscription(DefIDPosition))
+			assert.Equal(t, uint32(99), p.subscription(DefIDEngine).Interval, "groups without an interval keep Subscription.Interval")
+		})
+	}
+}
@@ -15,15 +15,57 @@ const (
 	SendTypeMask uint32 = 0xf0000000
 
 	// Send types (raw values — mask applied in EncodeSendHeader).
-	SendOpen         uint32 = 0x01
-	SendClose        uint32 = 0x02
-	SendAddToDataDef uint32 = 0x0c
-	SendRequestData  uint32 = 0x0e
+	SendOpen                   uint32 = 0x01
+	SendClose                  uint32 = 0x02
+	SendMapClientEvent         uint32 = 0x04
+	SendTransmitClientEvent    uint32 = 0x05
+	SendAddClientEventToGroup  uint32 = 0x07
+	SendSetGroupPriority       uint32 = 0x09
+	SendAddToDataDef           uint32 = 0x0c
+	SendClearDataDef           uint32 = 0x0d
+	SendRequestData            uint32 = 0x0e
+	SendRequestDataByType      uint32 = 0x0f
+	SendSetDataOnSimObject     uint32 = 0x10
+	SendSubscribeToSystemEvent uint32 = 0x17
+	SendWeatherObsAtStation    uint32 = 0x1a
+	SendWeatherObsAtNearest    uint32 = 0x1b
+	SendRequestSystemState     uint32 = 0x35
+	SendFlightLoad             uint32 = 0x3d
+	SendFlightSave             uint32 = 0x3e
+	SendFlightPlanLoad         uint32 = 0x3f
+	SendRequestFacilitiesList  uint32 = 0x43
+	SendAddToFacilityDef       uint32 = 0x45 // MSFS facility API
+	SendRequestFacilityData    uint32 = 0x46 // MSFS facility API
 
 	// Receive types (no mask).
-	RecvException     uint32 = 0x01
-	RecvOpen          uint32 = 0x02
-	RecvSimObjectData uint32 = 0x08
+	RecvException           uint32 = 0x01
+	RecvOpen                uint32 = 0x02
+	RecvEvent               uint32 = 0x04
+	RecvEventFilename       uint32 = 0x06
+	RecvSimObjectData       uint32 = 0x08
+	RecvSimObjectDataByType uint32 = 0x09
+	RecvWeatherObservation  uint32 = 0x0a
+	RecvSystemState         uint32 = 0x0f
+	RecvAirportList         uint32 = 0x12
+	RecvVORList             uint32 = 0x13
+	RecvNDBList             uint32 = 0x14
+	RecvWaypointList        uint32 = 0x15
+	RecvFacilityData        uint32 = 0x1c
+	RecvFacilityDataEnd     uint32 = 0x1d
+
+	// Exception codes carried in RecvException payloads (SIMCONNECT_EXCEPTION).
+	ExceptionNone             uint32 = 0
+	ExceptionError            uint32 = 1
+	ExceptionSizeMismatch     uint32 = 2
+	ExceptionUnrecognizedID   uint32 = 3
+	ExceptionUnopened         uint32 = 4
+	ExceptionVersionMismatch  uint32 = 5
+	ExceptionNameUnrecognized uint32 = 7
+	ExceptionNoObservation    uint32 = 15 // WEATHER_UNABLE_TO_GET_OBSERVATION
+	ExceptionInvalidDataType  uint32 = 18
+	ExceptionInvalidDataSize  uint32 = 19
+	ExceptionDataError        uint32 = 20
+	ExceptionLoadFlightPlan   uint32 = 23 // LOAD_FLIGHTPLAN_FAILED
 
 	// KittyHawk OPEN version constants.
 	KHMajor      uint32 = 11
@@ -33,6 +75,24 @@ const (
 	KHAlias             = "HK"
 )
 
+// Period controls how often SimConnect sends data for a request (SIMCONNECT_PERIOD).
+type Period uint32
+
+const (
+	PeriodNever       Period = 0
+	PeriodOnce        Period = 1
+	PeriodVisualFrame Period = 2
+	PeriodSimFrame    Period = 3
+	PeriodSecond      Period = 4
+)
+
+// Data request flags (SIMCONNECT_DATA_REQUEST_FLAG_*).
+const (
+	DataRequestFlagDefault uint32 = 0x00
+	DataRequestFlagChanged uint32 = 0x01 // send only when a value changes
+	DataRequestFlagTagged  uint32 = 0x02 // send datumID/value pairs instead of the full block
+)
+
 // SendHeader represents an outgoing SimConnect message header (16 bytes).
 type SendHeader struct {
 	Size    uint32
@@ -0,0 +1,289 @@
+package simconnect
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxQuerySimVars caps how many SimVars one ReadSimVars call may define.
+const maxQuerySimVars = 16
+
+// querySlots is how many temporary definition/request ID pairs ReadSimVars
+// rotates through, starting at DefIDQueryBase and ReqIDQueryBase.
+const querySlots = 64
+
+// isQueryDefinition reports whether defID is one of the temporary
+// definitions, which are cleared right after use.
+func isQueryDefinition(defID uint32) bool {
+	return defID >= DefIDQueryBase && defID < DefIDQueryBase+querySlots
+}
+
+// QuerySimVars are allowlisted for ad-hoc reads only; no data group polls them.
+var QuerySimVars = []SimVarDef{
+	{Name: "LIGHT BEACON", Unit: "bool", DataType: DataTypeFloat64, Size: 8},
+	{Name: "LIGHT LANDING", Unit: "bool", DataType: DataTypeFloat64, Size: 8},
+	{Name: "LIGHT NAV", Unit: "bool", DataType: DataTypeFloat64, Size: 8},
+	{Name: "LIGHT STROBE", Unit: "bool", DataType: DataTypeFloat64, Size: 8},
+	{Name: "LIGHT TAXI", Unit: "bool", DataType: DataTypeFloat64, Size: 8},
+	{Name: "STALL WARNING", Unit: "bool", DataType: DataTypeFloat64, Size: 8},
+	{Name: "OVERSPEED WARNING", Unit: "bool", DataType: DataTypeFloat64, Size: 8},
+	{Name: "G FORCE", Unit: "gforce", DataType: DataTypeFloat64, Size: 8},
+	{Name: "INCIDENCE ALPHA", Unit: "degrees", DataType: DataTypeFloat64, Size: 8},
+	{Name: "TOTAL WEIGHT", Unit: "pounds", DataType: DataTypeFloat64, Size: 8},
+	{Name: "PRESSURE ALTITUDE", Unit: "feet", DataType: DataTypeFloat64, Size: 8},
+	{Name: "DENSITY ALTITUDE", Unit: "feet", DataType: DataTypeFloat64, Size: 8},
+	{Name: "SIMULATION RATE", Unit: "number", DataType: DataTypeFloat64, Size: 8},
+	{Name: "ATC TYPE", DataType: DataTypeString64, Size: 64},
+	{Name: "STRUCT LATLONALT", DataType: DataTypeLatLonAlt, Size: 24},
+	{Name: "STRUCT WORLDVELOCITY", DataType: DataTypeXYZ, Size: 24},
+}
+
+// indexedSimVar describes a SimVar family addressed as "NAME:index".
+type indexedSimVar struct {
+	unit     string
+	maxIndex int
+}
+
+// indexedSimVars lists SimVar families that may be read with any index from
+// 1 to maxIndex, keyed by base name.
+var indexedSimVars = map[string]indexedSimVar{
+	"GENERAL ENG RPM":                     {"rpm", 4},
+	"GENERAL ENG THROTTLE LEVER POSITION": {"percent", 4},
+	"GENERAL ENG COMBUSTION":              {"bool", 4},
+	"GENERAL ENG OIL TEMPERATURE":         {"celsius", 4},
+	"GENERAL ENG OIL PRESSURE":            {"psi", 4},
+	"TURB ENG N1":                         {"percent", 4},
+	"TURB ENG N2":                         {"percent", 4},
+	"ENG FUEL FLOW GPH":                   {"gallons per hour", 4},
+	"ENG EXHAUST GAS TEMPERATURE":         {"celsius", 4},
+	"TURB ENG ITT":                        {"celsius", 4},
+	"ENG TORQUE":                          {"foot pounds", 4},
+	"HYDRAULIC PRESSURE":                  {"psi", 4},
+	"ELECTRICAL GENALT BUS VOLTAGE":       {"volts", 4},
+	"COM ACTIVE FREQUENCY":                {"MHz", 3},
+	"COM STANDBY FREQUENCY":               {"MHz", 3},
+	"NAV ACTIVE FREQUENCY":                {"MHz", 4},
+	"NAV STANDBY FREQUENCY":               {"MHz", 4},
+	"NAV OBS":                             {"degrees", 4},
+	"NAV RADIAL":                          {"degrees", 4},
+	"NAV CDI":                             {"number", 4},
+	"NAV GSI":                             {"number", 4},
+	"NAV TOFROM":                          {"enum", 4},
+	"NAV DME":                             {"nautical miles", 4},
+	"NAV HAS NAV":                         {"bool", 4},
+	"NAV HAS LOCALIZER":                   {"bool", 4},
+	"NAV HAS GLIDE SLOPE":                 {"bool", 4},
+	"ADF ACTIVE FREQUENCY":                {"KHz", 2},
+	"ADF RADIAL":                          {"degrees", 2},
+	"TRANSPONDER CODE":                    {"Bco16", 1},
+}
+
+// allowedUnits lists unit names accepted as overrides, keyed by lowercase name.
+var allowedUnits = unitSet(
+	"bool", "number", "percent", "percent over 100", "position", "position 128", "mask", "enum",
+	"feet", "meters", "kilometers", "nautical miles", "miles",
+	"knots", "kilometers per hour", "miles per hour", "meters per second", "feet per second",
+	"feet/minute", "feet per minute", "mach",
+	"degrees", "radians", "radians per second", "degrees per second",
+	"celsius", "fahrenheit", "kelvin", "rankine",
+	"psi", "inHg", "millibars", "hectopascals", "pascals",
+	"pounds", "kilograms", "foot pounds", "gallons", "liters", "gallons per hour", "pounds per hour",
+	"MHz", "KHz", "Hz", "BCD16", "Bco16",
+	"seconds", "minutes", "hours", "rpm", "gforce", "volts", "amperes",
+)
+
+func unitSet(units ...string) map[string]string {
+	m := make(map[string]string, len(units))
+	for _, u := range units {
+		m[strings.ToLower(u)] = u
+	}
+	return m
+}
+
+// Resolve returns the definition for an allowlisted SimVar name, optionally
+// read in a different unit. Names are case-insensitive; indexed families take
+// an index suffix such as "GENERAL ENG RPM:2". Unknown names wrap
+// ErrInvalidSimVar; unknown units, and any unit for a string or struct
+// SimVar, wrap ErrInvalidUnit.
+func (r *SimVarRegistry) Resolve(name, unit string) (SimVarDef, error) {
+	key := strings.ToUpper(strings.TrimSpace(name))
+	def, ok := r.vars[key]
+	if !ok {
+		var err error
+		if def, err = resolveIndexed(key); err != nil {
+			return SimVarDef{}, err
+		}
+	}
+	if unit != "" {
+		if def.DataType.IsString() || def.DataType.IsStruct() {
+			return SimVarDef{}, fmt.Errorf("%w: %s is a %s and takes no unit", ErrInvalidUnit, def.Name, def.DataType)
+		}
+		canonical, ok := allowedUnits[strings.ToLower(strings.TrimSpace(unit))]
+		if !ok {
+			return SimVarDef{}, fmt.Errorf("%w: %q", ErrInvalidUnit, unit)
+		}
+		def.Unit = canonical
+	}
+	return def, nil
+}
+
+// resolveIndexed resolves "BASE:index" against indexedSimVars.
+func resolveIndexed(key string) (SimVarDef, error) {
+	base, idx, found := strings.Cut(key, ":")
+	family, ok := indexedSimVars[base]
+	if !ok {
+		return SimVarDef{}, fmt.Errorf("%w: %s", ErrInvalidSimVar, key)
+	}
+	if !found {
+		return SimVarDef{}, fmt.Errorf("%w: %s needs an index 1-%d, e.g. %s:1", ErrInvalidSimVar, key, family.maxIndex, key)
+	}
+	n, err := strconv.Atoi(idx)
+	if err != nil || n < 1 || n > family.maxIndex {
+		return SimVarDef{}, fmt.Errorf("%w: %s index must be 1-%d", ErrInvalidSimVar, key, family.maxIndex)
+	}
+	return indexedDef(base, n), nil
+}
+
+// indexedDef returns the definition of an indexedSimVars family at index n.
+func indexedDef(base string, n int) SimVarDef {
+	return SimVarDef{
+		Name:     fmt.Sprintf("%s:%d", base, n),
+		Unit:     indexedSimVars[base].unit,
+		DataType: DataTypeFloat64,
+		Size:     8,
+	}
+}
+
+// SimVarQuery names a SimVar to read, with an optional unit override.
+type SimVarQuery struct {
+	Name string
+	Unit string
+}
+
+// SimVarValue is a SimVar read by QuerySimVars. Value holds the type decoded
+// for the SimVar's DataType, e.g. float64, string or LatLonAlt.
+type SimVarValue struct {
+	Name  string
+	Unit  string
+	Value any
+}
+
+// QuerySimVars validates the queries against the allowlist and reads them
+// once from the user aircraft with ReadSimVars.
+func (c *Client) QuerySimVars(ctx context.Context, queries []SimVarQuery) ([]SimVarValue, error) {
+	defs := make([]SimVarDef, 0, len(queries))
+	for _, q := range queries {
+		def, err := c.simvars.Resolve(q.Name, q.Unit)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+	vals, err := c.ReadSimVars(ctx, defs)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SimVarValue, len(defs))
+	for i, def := range defs {
+		out[i] = SimVarValue{Name: def.Name, Unit: def.Unit, Value: vals[i]}
+	}
+	return out, nil
+}
+
+// ReadSimVars reads SimVars of any data type once from the user aircraft through a
+// temporary data definition, which is cleared afterwards. It needs a reader
+// running ReadNext (the Poller) to receive the reply, and fails with the
+// exception if the simulator rejects any SimVar.
+func (c *Client) ReadSimVars(ctx context.Context, defs []SimVarDef) ([]any, error) {
+	if err := validateQueryDefs(defs); err != nil {
+		return nil, err
+	}
+
+	defID, reqID := c.nextQuerySlot()
+	excs := make(chan *Exception, len(defs)+1)
+	reply := make(chan []byte, 1)
+
+	c.pendingMu.Lock()
+	c.replies[reqID] = reply
+	c.pendingMu.Unlock()
+
+	sendIDs := make([]uint32, 0, len(defs)+1)
+	defer func() {
+		c.untrackExceptions(sendIDs...)
+		c.pendingMu.Lock()
+		delete(c.replies, reqID)
+		c.pendingMu.Unlock()
+		_ = c.sendMessage(SendClearDataDef, binary.LittleEndian.AppendUint32(nil, defID))
+	}()
+
+	c.mu.Lock()
+	err := func() error {
+		if err := c.addQueryDefsLocked(defID, defs, excs, &sendIDs); err != nil {
+			return err
+		}
+		id, err := c.sendTrackedLocked(SendRequestData,
+			encodeRequestData(defID, ObjectIDUser, reqID, RequestOptions{Period: PeriodOnce}), excs)
+		sendIDs = append(sendIDs, id)
+		return err
+	}()
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case exc := <-excs:
+		return nil, exc
+	case data := <-reply:
+		// Exceptions for the definitions are read before the reply, so any
+		// rejection is already queued.
+		select {
+		case exc := <-excs:
+			return nil, exc
+		default:
+		}
+		vals, err := ParseSimVarValues(data, defs)
+		if err != nil {
+			return nil, fmt.Errorf("simconnect: query reply: %w", err)
+		}
+		return vals, nil
+	}
+}
+
+// validateQueryDefs checks that a temporary definition has 1 to
+// maxQuerySimVars SimVars, each of a data type the client can decode.
+func validateQueryDefs(defs []SimVarDef) error {
+	if len(defs) == 0 || len(defs) > maxQuerySimVars {
+		return fmt.Errorf("%w: a query needs 1-%d simvars, got %d", ErrInvalidSimVar, maxQuerySimVars, len(defs))
+	}
+	for _, def := range defs {
+		if def.DataType.Size() == 0 && def.DataType != DataTypeStringV {
+			return fmt.Errorf("%w: %s has unsupported data type %d", ErrInvalidSimVar, def.Name, def.DataType)
+		}
+	}
+	return nil
+}
+
+// nextQuerySlot returns the next temporary definition and request ID pair.
+func (c *Client) nextQuerySlot() (defID, reqID uint32) {
+	slot := c.querySeq.Add(1) % querySlots
+	return DefIDQueryBase + slot, ReqIDQueryBase + slot
+}
+
+// addQueryDefsLocked adds defs to the temporary definition defID, tracking
+// exceptions on excs and appending each send ID to sendIDs. Caller must hold c.mu.
+func (c *Client) addQueryDefsLocked(defID uint32, defs []SimVarDef, excs chan<- *Exception, sendIDs *[]uint32) error {
+	for _, def := range defs {
+		id, err := c.sendTrackedLocked(SendAddToDataDef, encodeAddToDataDefinition(defID, def), excs)
+		*sendIDs = append(*sendIDs, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package simconnect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+)
+
+func TestQuerySimVars(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.SetSimVar("GENERAL ENG RPM:3", 2350)
+	srv.SetSimVar("SIM ON GROUND", 1)
+	c := connectReading(t, srv)
+
+	vals, err := c.QuerySimVars(context.Background(), []simconnect.SimVarQuery{
+		{Name: "general eng rpm:3"},
+		{Name: "SIM ON GROUND"},
+		{Name: "PLANE ALTITUDE", Unit: "meters"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []simconnect.SimVarValue{
+		{Name: "GENERAL ENG RPM:3", Unit: "rpm", Value: 2350.0},
+		{Name: "SIM ON GROUND", Unit: "bool", Value: 1.0},
+		{Name: "PLANE ALTITUDE", Unit: "meters", Value: 0.0},
+	}, vals)
+
+	defs := srv.Messages(simconnect.SendAddToDataDef)
+	require.Len(t, defs, 3)
+	require.Eventually(t, func() bool {
+		return len(srv.Messages(simconnect.SendClearDataDef)) == 1
+	}, time.Second, time.Millisecond, "the temporary definition is cleared")
+}
+
+func TestQuerySimVarsStringsAndStructs(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.SetSimVar("TITLE", "Cessna Skyhawk G1000 Asobo")
+	srv.SetSimVar("ATC ID", "N172SP")
+	srv.SetSimVar("STRUCT LATLONALT", simconnect.LatLonAlt{Latitude: 47.45, Longitude: -122.31, Altitude: 130})
+	c := connectReading(t, srv)
+
+	vals, err := c.QuerySimVars(context.Background(), []simconnect.SimVarQuery{
+		{Name: "TITLE"},
+		{Name: "SIM ON GROUND"},
+		{Name: "ATC ID"},
+		{Name: "STRUCT LATLONALT"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []simconnect.SimVarValue{
+		{Name: "TITLE", Value: "Cessna Skyhawk G1000 Asobo"},
+		{Name: "SIM ON GROUND", Unit: "bool", Value: 0.0},
+		{Name: "ATC ID", Value: "N172SP"},
+		{Name: "STRUCT LATLONALT", Value: simconnect.LatLonAlt{Latitude: 47.45, Longitude: -122.31, Altitude: 130}},
+	}, vals)
+
+	_, err = c.QuerySimVars(context.Background(), []simconnect.SimVarQuery{{Name: "TITLE", Unit: "number"}})
+	assert.ErrorIs(t, err, simconnect.ErrInvalidUnit, "string simvars take no unit")
+}
+
+func TestQuerySimVarsRejectsUnlistedNames(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+
+	_, err := c.QuerySimVars(context.Background(), []simconnect.SimVarQuery{{Name: "NOT A SIMVAR"}})
+	assert.ErrorIs(t, err, simconnect.ErrInvalidSimVar)
+	_, err = c.QuerySimVars(context.Background(), []simconnect.SimVarQuery{{Name: "PLANE ALTITUDE", Unit: "cubits"}})
+	assert.ErrorIs(t, err, simconnect.ErrInvalidUnit)
+	assert.Empty(t, srv.Messages(simconnect.SendAddToDataDef), "nothing is sent for invalid queries")
+}
+
+func TestQuerySimVarsSurfacesRejection(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.RejectSimVar("LIGHT STROBE", simconnect.ExceptionNameUnrecognized)
+	srv.SetSimVar("LIGHT BEACON", 1)
+	c := connectReading(t, srv)
+
+	_, err := c.QuerySimVars(context.Background(), []simconnect.SimVarQuery{
+		{Name: "LIGHT BEACON"}, {Name: "LIGHT STROBE"},
+	})
+	assert.ErrorIs(t, err, simconnect.ErrNameUnrecognized)
+
+	// The temporary definition is gone, so the SimVar is not flagged as
+	// rejected; the exception is still recorded.
+	diag := c.Diagnostics()
+	assert.Empty(t, diag.RejectedSimVars)
+	require.NotEmpty(t, diag.RecentExceptions)
+	assert.Equal(t, "LIGHT STROBE", diag.RecentExceptions[0].Message.SimVar)
+	assert.Equal(t, "query", simconnect.DefinitionName(diag.RecentExceptions[0].Message.DefID))
+}
+
+func TestQuerySimVarsTimesOut(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.QuerySimVars(ctx, []simconnect.SimVarQuery{{Name: "LIGHT NAV"}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
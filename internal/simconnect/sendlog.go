@@ -0,0 +1,167 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// sendLogSize is how many outgoing messages are remembered for exception
+// correlation. SimConnect reports exceptions shortly after the offending
+// message, so a small window is enough.
+const sendLogSize = 512
+
+// sendTypeNames maps send types to SimConnect function names for diagnostics.
+var sendTypeNames = map[uint32]string{
+	SendOpen:                   "OPEN",
+	SendClose:                  "CLOSE",
+	SendMapClientEvent:         "MAP_CLIENT_EVENT_TO_SIM_EVENT",
+	SendTransmitClientEvent:    "TRANSMIT_CLIENT_EVENT",
+	SendAddClientEventToGroup:  "ADD_CLIENT_EVENT_TO_NOTIFICATION_GROUP",
+	SendSetGroupPriority:       "SET_NOTIFICATION_GROUP_PRIORITY",
+	SendAddToDataDef:           "ADD_TO_DATA_DEFINITION",
+	SendClearDataDef:           "CLEAR_DATA_DEFINITION",
+	SendRequestData:            "REQUEST_DATA_ON_SIMOBJECT",
+	SendRequestDataByType:      "REQUEST_DATA_ON_SIMOBJECT_TYPE",
+	SendSetDataOnSimObject:     "SET_DATA_ON_SIMOBJECT",
+	SendSubscribeToSystemEvent: "SUBSCRIBE_TO_SYSTEM_EVENT",
+	SendWeatherObsAtStation:    "WEATHER_REQUEST_OBSERVATION_AT_STATION",
+	SendWeatherObsAtNearest:    "WEATHER_REQUEST_OBSERVATION_AT_NEAREST_STATION",
+	SendRequestSystemState:     "REQUEST_SYSTEM_STATE",
+	SendFlightLoad:             "FLIGHT_LOAD",
+	SendFlightSave:             "FLIGHT_SAVE",
+	SendFlightPlanLoad:         "FLIGHT_PLAN_LOAD",
+	SendRequestFacilitiesList:  "REQUEST_FACILITIES_LIST",
+	SendAddToFacilityDef:       "ADD_TO_FACILITY_DEFINITION",
+	SendRequestFacilityData:    "REQUEST_FACILITY_DATA",
+}
+
+// SentMessage describes an outgoing message, remembered so exceptions can be
+// traced back to what caused them.
+type SentMessage struct {
+	SendID    uint32
+	Type      uint32
+	DefID     uint32 // data definition, for definition, request and write messages
+	RequestID uint32 // for data requests
+	SimVar    string // for definitions and writes; the field name for facility definitions
+	Event     string // for MAP_CLIENT_EVENT_TO_SIM_EVENT and SUBSCRIBE_TO_SYSTEM_EVENT
+	File      string // for FLIGHT_LOAD, FLIGHT_SAVE and FLIGHT_PLAN_LOAD
+}
+
+// TypeName returns the SimConnect function name of the message type.
+func (m SentMessage) TypeName() string {
+	if name, ok := sendTypeNames[m.Type]; ok {
+		return name
+	}
+	return fmt.Sprintf("SEND_0x%02x", m.Type)
+}
+
+func (m SentMessage) String() string {
+	parts := []string{m.TypeName()}
+	if m.SimVar != "" {
+		parts = append(parts, fmt.Sprintf("%q", m.SimVar))
+	}
+	if m.Event != "" {
+		parts = append(parts, fmt.Sprintf("%q", m.Event))
+	}
+	if m.File != "" {
+		parts = append(parts, fmt.Sprintf("%q", m.File))
+	}
+	if m.DefID != 0 {
+		parts = append(parts, fmt.Sprintf("def=%d", m.DefID))
+	}
+	if m.RequestID != 0 {
+		parts = append(parts, fmt.Sprintf("req=%d", m.RequestID))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeSend decodes the fields of an outgoing payload that identify it.
+func describeSend(sendID, msgType uint32, payload []byte) SentMessage {
+	m := SentMessage{SendID: sendID, Type: msgType}
+	switch msgType {
+	case SendAddToDataDef:
+		if len(payload) >= 260 {
+			m.DefID = binary.LittleEndian.Uint32(payload[0:4])
+			m.SimVar = cString(payload[4:260])
+		}
+	case SendClearDataDef:
+		if len(payload) >= 4 {
+			m.DefID = binary.LittleEndian.Uint32(payload[0:4])
+		}
+	case SendRequestData, SendRequestDataByType:
+		if len(payload) >= 8 {
+			m.RequestID = binary.LittleEndian.Uint32(payload[0:4])
+			m.DefID = binary.LittleEndian.Uint32(payload[4:8])
+		}
+	case SendSetDataOnSimObject:
+		if len(payload) >= 4 {
+			m.DefID = binary.LittleEndian.Uint32(payload[0:4])
+			switch {
+			case m.DefID == DefIDInitPosition:
+				m.SimVar = InitialPosition.Name
+			case m.DefID >= DefIDWriteBase && int(m.DefID-DefIDWriteBase) < len(WritableSimVars):
+				m.SimVar = WritableSimVars[m.DefID-DefIDWriteBase].Name
+			}
+		}
+	case SendRequestSystemState, SendWeatherObsAtStation, SendWeatherObsAtNearest:
+		if len(payload) >= 4 {
+			m.RequestID = binary.LittleEndian.Uint32(payload[0:4])
+		}
+	case SendRequestFacilitiesList:
+		if len(payload) >= 8 {
+			m.RequestID = binary.LittleEndian.Uint32(payload[4:8])
+		}
+	case SendRequestFacilityData:
+		if len(payload) >= 8 {
+			m.DefID = binary.LittleEndian.Uint32(payload[0:4])
+			m.RequestID = binary.LittleEndian.Uint32(payload[4:8])
+		}
+	case SendAddToFacilityDef:
+		if len(payload) >= 260 {
+			m.DefID = binary.LittleEndian.Uint32(payload[0:4])
+			m.SimVar = cString(payload[4:260])
+		}
+	case SendFlightLoad, SendFlightSave, SendFlightPlanLoad:
+		m.File = cString(payload[:min(len(payload), maxPathLength)])
+	case SendMapClientEvent, SendSubscribeToSystemEvent:
+		if len(payload) >= 260 {
+			m.Event = cString(payload[4:260])
+		}
+	}
+	return m
+}
+
+// sendLog is a fixed-size ring of recent outgoing messages keyed by send ID.
+type sendLog struct {
+	mu      sync.Mutex
+	entries [sendLogSize]SentMessage
+}
+
+func (l *sendLog) record(m SentMessage) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[m.SendID%sendLogSize] = m
+}
+
+// lookup returns the message with the given send ID if it is still remembered.
+func (l *sendLog) lookup(sendID uint32) (SentMessage, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	m := l.entries[sendID%sendLogSize]
+	if m.SendID != sendID || m.Type == 0 {
+		return SentMessage{}, false
+	}
+	return m, true
+}
+
+// cString returns the bytes of a zero-padded C string up to the first NUL.
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,113 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeSend(t *testing.T) {
+	tests := []struct {
+		name    string
+		msgType uint32
+		payload []byte
+		want    SentMessage
+	}{
+		{
+			name:    "add to data definition",
+			msgType: SendAddToDataDef,
+			payload: encodeAddToDataDefinition(DefIDEngine, indexedDef("GENERAL ENG RPM", 1)),
+			want:    SentMessage{SendID: 9, Type: SendAddToDataDef, DefID: DefIDEngine, SimVar: "GENERAL ENG RPM:1"},
+		},
+		{
+			name:    "request data",
+			msgType: SendRequestData,
+			payload: encodeRequestData(DefIDAutopilot, ObjectIDUser, ReqIDAutopilot, RequestOptions{Period: PeriodOnce}),
+			want:    SentMessage{SendID: 9, Type: SendRequestData, DefID: DefIDAutopilot, RequestID: ReqIDAutopilot},
+		},
+		{
+			name:    "write",
+			msgType: SendSetDataOnSimObject,
+			payload: binary.LittleEndian.AppendUint32(nil, DefIDWriteBase),
+			want:    SentMessage{SendID: 9, Type: SendSetDataOnSimObject, DefID: DefIDWriteBase, SimVar: WritableSimVars[0].Name},
+		},
+		{
+			name:    "set initial position",
+			msgType: SendSetDataOnSimObject,
+			payload: binary.LittleEndian.AppendUint32(nil, DefIDInitPosition),
+			want:    SentMessage{SendID: 9, Type: SendSetDataOnSimObject, DefID: DefIDInitPosition, SimVar: "Initial Position"},
+		},
+		{
+			name:    "add to facility definition",
+			msgType: SendAddToFacilityDef,
+			payload: encodeAddToFacilityDefinition(DefIDFacilityAirport, "OPEN AIRPORT"),
+			want:    SentMessage{SendID: 9, Type: SendAddToFacilityDef, DefID: DefIDFacilityAirport, SimVar: "OPEN AIRPORT"},
+		},
+		{
+			name:    "request facility data",
+			msgType: SendRequestFacilityData,
+			payload: encodeRequestFacilityData(DefIDFacilityAirport, ReqIDFacilityBase+1, "KSEA", ""),
+			want:    SentMessage{SendID: 9, Type: SendRequestFacilityData, DefID: DefIDFacilityAirport, RequestID: ReqIDFacilityBase + 1},
+		},
+		{
+			name:    "request facilities list",
+			msgType: SendRequestFacilitiesList,
+			payload: encodeRequestFacilitiesList(FacilityListVOR, ReqIDFacilityBase+2),
+			want:    SentMessage{SendID: 9, Type: SendRequestFacilitiesList, RequestID: ReqIDFacilityBase + 2},
+		},
+		{
+			name:    "weather observation at station",
+			msgType: SendWeatherObsAtStation,
+			payload: encodeWeatherObsAtStation(ReqIDWeatherBase+1, "KSEA"),
+			want:    SentMessage{SendID: 9, Type: SendWeatherObsAtStation, RequestID: ReqIDWeatherBase + 1},
+		},
+		{
+			name:    "flight plan load",
+			msgType: SendFlightPlanLoad,
+			payload: mustEncodeFileName(t, `C:\Plans\KSEA-KPDX`),
+			want:    SentMessage{SendID: 9, Type: SendFlightPlanLoad, File: `C:\Plans\KSEA-KPDX`},
+		},
+		{
+			name:    "flight save",
+			msgType: SendFlightSave,
+			payload: appendFixedString(mustEncodeFileName(t, "short-final"), "Short final", maxPathLength),
+			want:    SentMessage{SendID: 9, Type: SendFlightSave, File: "short-final"},
+		},
+		{
+			name:    "truncated payload",
+			msgType: SendAddToDataDef,
+			payload: []byte{1, 0, 0, 0},
+			want:    SentMessage{SendID: 9, Type: SendAddToDataDef},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, describeSend(9, tt.msgType, tt.payload))
+		})
+	}
+}
+
+func mustEncodeFileName(t *testing.T, path string) []byte {
+	t.Helper()
+	payload, err := encodeFileName(path)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestSendLogLookup(t *testing.T) {
+	var l sendLog
+	l.record(SentMessage{SendID: 3, Type: SendRequestData})
+
+	m, ok := l.lookup(3)
+	require.True(t, ok)
+	assert.Equal(t, "REQUEST_DATA_ON_SIMOBJECT", m.TypeName())
+
+	_, ok = l.lookup(4)
+	assert.False(t, ok, "unknown send ID")
+
+	l.record(SentMessage{SendID: 3 + sendLogSize, Type: SendTransmitClientEvent})
+	_, ok = l.lookup(3)
+	assert.False(t, ok, "evicted send ID")
+}
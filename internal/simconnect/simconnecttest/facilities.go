@@ -0,0 +1,141 @@
+package simconnecttest
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// FacilityRecord is one RecvFacilityData message: the facility data type and
+// the data of one OPEN…CLOSE block, laid out as the client's definition.
+type FacilityRecord struct {
+	Type uint32
+	Data []byte
+}
+
+// facilityListTypes maps list types to the message type their pages use.
+var facilityListTypes = map[simconnect.FacilityListType]uint32{
+	simconnect.FacilityListAirport:  simconnect.RecvAirportList,
+	simconnect.FacilityListWaypoint: simconnect.RecvWaypointList,
+	simconnect.FacilityListNDB:      simconnect.RecvNDBList,
+	simconnect.FacilityListVOR:      simconnect.RecvVORList,
+}
+
+// SetFacilities sets the facilities REQUEST_FACILITIES_LIST reports for
+// listType. Each facility is sent as its own page.
+func (s *Server) SetFacilities(listType simconnect.FacilityListType, facilities ...types.Facility) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.facilities[listType] = append([]types.Facility(nil), facilities...)
+}
+
+// SetFacilityData scripts the records REQUEST_FACILITY_DATA returns for an
+// ICAO ident, whatever definition it names. Unscripted idents get only
+// RecvFacilityDataEnd.
+func (s *Server) SetFacilityData(icao string, records ...FacilityRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.facilityData[icao] = append([]FacilityRecord(nil), records...)
+}
+
+func (s *Server) handleRequestFacilitiesList(c *Conn, m Message) {
+	if len(m.Payload) < 8 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	listType := simconnect.FacilityListType(binary.LittleEndian.Uint32(m.Payload[0:4]))
+	reqID := binary.LittleEndian.Uint32(m.Payload[4:8])
+	recvType, ok := facilityListTypes[listType]
+	if !ok {
+		_ = c.SendException(simconnect.ExceptionError, m.ID, 1)
+		return
+	}
+
+	s.mu.Lock()
+	facilities := s.facilities[listType]
+	s.mu.Unlock()
+
+	if len(facilities) == 0 {
+		_ = c.Send(recvType, EncodeFacilityList(recvType, reqID, 0, 1))
+		return
+	}
+	outOf := uint32(len(facilities)) // #nosec G115 -- test lists are small
+	for i, f := range facilities {
+		_ = c.Send(recvType, EncodeFacilityList(recvType, reqID, uint32(i), outOf, f)) // #nosec G115 -- test lists are small
+	}
+}
+
+func (s *Server) handleRequestFacilityData(c *Conn, m Message) {
+	if len(m.Payload) < 24 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	reqID := binary.LittleEndian.Uint32(m.Payload[4:8])
+	icao := cString(m.Payload[8:24])
+
+	s.mu.Lock()
+	records := s.facilityData[icao]
+	s.mu.Unlock()
+
+	for _, r := range records {
+		_ = c.Send(simconnect.RecvFacilityData, EncodeFacilityData(reqID, r.Type, r.Data))
+	}
+	_ = c.Send(simconnect.RecvFacilityDataEnd, binary.LittleEndian.AppendUint32(nil, reqID))
+}
+
+// EncodeFacilityList builds a facility list page of the given message type
+// (RecvAirportList, RecvVORList, RecvNDBList or RecvWaypointList), page
+// entry of outOf, holding facilities.
+func EncodeFacilityList(recvType, requestID, entry, outOf uint32, facilities ...types.Facility) []byte {
+	buf := binary.LittleEndian.AppendUint32(nil, requestID)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(facilities))) // #nosec G115 -- test lists are small
+	buf = binary.LittleEndian.AppendUint32(buf, entry)
+	buf = binary.LittleEndian.AppendUint32(buf, outOf)
+	for _, f := range facilities {
+		ident := make([]byte, 6)
+		copy(ident[:5], f.Ident)
+		region := make([]byte, 3)
+		copy(region[:2], f.Region)
+		buf = append(buf, ident...)
+		buf = append(buf, region...)
+		buf = append(buf, Float64s(f.Latitude, f.Longitude, f.Altitude)...)
+		if recvType == simconnect.RecvAirportList {
+			continue
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(f.MagVar)))
+		if recvType == simconnect.RecvWaypointList {
+			continue
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(f.Frequency))
+		if recvType == simconnect.RecvNDBList {
+			continue
+		}
+		var flags uint32
+		for bit, set := range []bool{f.HasNavSignal, f.HasLocalizer, f.HasGlideSlope, f.HasDME} {
+			if set {
+				flags |= 1 << bit
+			}
+		}
+		buf = binary.LittleEndian.AppendUint32(buf, flags)
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(f.LocalizerHeading)))
+		buf = append(buf, Float64s(0, 0, 0)...) // glideslope position
+		buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(f.GlideSlopeAngle)))
+	}
+	return buf
+}
+
+// EncodeFacilityData prepends the 28-byte SIMCONNECT_RECV_FACILITY_DATA
+// header to the data of one facility block.
+func EncodeFacilityData(requestID, dataType uint32, data []byte) []byte {
+	buf := make([]byte, 0, 28+len(data))
+	buf = binary.LittleEndian.AppendUint32(buf, requestID)
+	buf = binary.LittleEndian.AppendUint32(buf, requestID) // uniqueRequestID
+	buf = binary.LittleEndian.AppendUint32(buf, 0)         // parentUniqueRequestID
+	buf = binary.LittleEndian.AppendUint32(buf, dataType)
+	buf = binary.LittleEndian.AppendUint32(buf, 0) // isListItem
+	buf = binary.LittleEndian.AppendUint32(buf, 0) // itemIndex
+	buf = binary.LittleEndian.AppendUint32(buf, 0) // listSize
+	return append(buf, data...)
+}
@@ -0,0 +1,690 @@
+// Package simconnecttest provides an in-process fake SimConnect server for
+// end-to-end tests. It speaks the KittyHawk wire protocol over TCP so the real
+// simconnect.Client, Poller, state.Manager and MCP server can be exercised
+// together without a Windows machine running MSFS.
+package simconnecttest
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/pkg/types"
+)
+
+// Default values reported in the OPEN acknowledgement.
+const (
+	DefaultAppName          = "SimConnect Fake"
+	DefaultAppVersionMajor  = 12
+	DefaultSimConnectMajor  = 12
+	serverProtocolVersion   = 0x06
+	simObjectDataHeaderSize = 28
+)
+
+// Message is a decoded client-to-server SimConnect message.
+type Message struct {
+	Type    uint32 // raw send type with SendTypeMask stripped
+	ID      uint32 // client-assigned send ID
+	Payload []byte
+}
+
+// Event is a client event transmitted to the fake server.
+type Event struct {
+	Name     string
+	ObjectID uint32
+	Data     uint32
+	GroupID  uint32
+}
+
+// Write is a SET_DATA_ON_SIMOBJECT request received by the fake server.
+type Write struct {
+	DefID    uint32
+	ObjectID uint32
+	SimVars  []string // names registered for DefID when the write arrived
+	Data     []byte
+}
+
+// HandlerFunc handles a client message. It replaces the server's built-in
+// behavior for the message type it is registered for.
+type HandlerFunc func(c *Conn, m Message)
+
+// Conn is a single client session on the fake server.
+type Conn struct {
+	nc     net.Conn
+	mu     sync.Mutex
+	silent *atomic.Bool // shared with the Server
+}
+
+// Send writes a framed receive message (12-byte header + payload) to the
+// client. While the server is silenced it drops the message instead.
+func (c *Conn) Send(recvType uint32, payload []byte) error {
+	if c.silent != nil && c.silent.Load() {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return writeFrame(c.nc, recvType, payload)
+}
+
+// SendException writes a SIMCONNECT_RECV_EXCEPTION frame to the client.
+func (c *Conn) SendException(code, sendID, index uint32) error {
+	return c.Send(simconnect.RecvException, EncodeException(code, sendID, index))
+}
+
+// Server is a fake SimConnect server listening on a loopback TCP port.
+type Server struct {
+	ln net.Listener
+	wg sync.WaitGroup
+
+	mu       sync.Mutex
+	conns    map[*Conn]struct{}
+	messages []Message
+	defs     map[uint32][]datum
+	data     map[uint32][]byte
+	rejects  map[string]uint32
+	values   map[string]any
+	failures map[uint32]uint32
+	handlers map[uint32]HandlerFunc
+	objects  []simObject
+
+	facilities   map[simconnect.FacilityListType][]types.Facility
+	facilityData map[string][]FacilityRecord
+	metars       map[string]string
+	closed       bool
+
+	eventNames map[uint32]string
+	events     []Event
+	eventHooks map[string]func(data uint32)
+
+	writes []Write
+
+	subs       map[*Conn]map[uint32]*subscription
+	sysEvents  map[*Conn]map[string]uint32
+	simStopped bool // answers the "Sim" system state; set by firing SimStop
+	frameTick  time.Duration
+	secondTick time.Duration
+
+	silent atomic.Bool
+}
+
+// NewServer starts a fake SimConnect server on 127.0.0.1 with an ephemeral port.
+// It panics if the listener cannot be created, mirroring httptest.NewServer.
+func NewServer() *Server {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("simconnecttest: listen: %v", err))
+	}
+	s := &Server{
+		ln:       ln,
+		conns:    make(map[*Conn]struct{}),
+		defs:     make(map[uint32][]datum),
+		data:     make(map[uint32][]byte),
+		rejects:  make(map[string]uint32),
+		values:   make(map[string]any),
+		failures: make(map[uint32]uint32),
+		handlers: make(map[uint32]HandlerFunc),
+
+		facilities:   make(map[simconnect.FacilityListType][]types.Facility),
+		facilityData: make(map[string][]FacilityRecord),
+		metars:       make(map[string]string),
+
+		eventNames: make(map[uint32]string),
+		eventHooks: make(map[string]func(data uint32)),
+
+		subs:       make(map[*Conn]map[uint32]*subscription),
+		sysEvents:  make(map[*Conn]map[string]uint32),
+		frameTick:  DefaultFrameTick,
+		secondTick: DefaultSecondTick,
+	}
+	s.wg.Add(1)
+	go s.acceptLoop()
+	return s
+}
+
+// Addr returns the listener address in host:port form.
+func (s *Server) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// Config returns a simconnect.Config that dials this server.
+func (s *Server) Config() simconnect.Config {
+	host, portStr, _ := net.SplitHostPort(s.Addr())
+	port, _ := strconv.Atoi(portStr)
+	return simconnect.Config{
+		Host:    host,
+		Port:    port,
+		Timeout: 5 * time.Second,
+		AppName: "simconnecttest-client",
+	}
+}
+
+// Close stops accepting connections, closes all client sessions and waits
+// for the server goroutines to exit.
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	_ = s.ln.Close()
+	s.CloseClientConnections()
+	s.wg.Wait()
+}
+
+// CloseClientConnections drops every connected client without stopping the
+// listener, simulating a simulator crash or network failure.
+func (s *Server) CloseClientConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		_ = c.nc.Close()
+	}
+}
+
+// ConnCount returns the number of currently connected clients.
+func (s *Server) ConnCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// SetData scripts the raw SimVar data returned for REQUEST_DATA calls with
+// the given request ID. Requests without scripted data get no response.
+func (s *Server) SetData(requestID uint32, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[requestID] = append([]byte(nil), data...)
+}
+
+// SetFloat64s scripts a data block of packed float64 values for requestID.
+func (s *Server) SetFloat64s(requestID uint32, vals ...float64) {
+	s.SetData(requestID, Float64s(vals...))
+}
+
+// SetSimVar sets the value the server reports for a SimVar by name: a number,
+// a string, or one of the simconnect struct types. Requests without scripted
+// data (SetData) are answered from these values, each encoded as the data type
+// its SimVar was registered with, when at least one of the definition's
+// SimVars is set.
+func (s *Server) SetSimVar(name string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[name] = value
+}
+
+// dataLocked returns the payload for a request: scripted data for the request
+// ID, or values by SimVar name for the definition. Caller must hold s.mu.
+func (s *Server) dataLocked(reqID, defID uint32) ([]byte, bool) {
+	if data, ok := s.data[reqID]; ok {
+		return data, true
+	}
+	known := false
+	var buf []byte
+	for _, d := range s.defs[defID] {
+		v, ok := s.values[d.name]
+		known = known || ok
+		buf = append(buf, EncodeValue(d.dataType, v)...)
+	}
+	if !known {
+		return nil, false
+	}
+	return buf, true
+}
+
+// SetSilent makes the server keep its connections open but stop sending
+// anything, like a half-open TCP connection to a hung simulator.
+func (s *Server) SetSilent(silent bool) {
+	s.silent.Store(silent)
+}
+
+// RejectSimVar makes ADD_TO_DATA_DEFINITION for the named SimVar fail with
+// the given SimConnect exception code.
+func (s *Server) RejectSimVar(name string, code uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejects[name] = code
+}
+
+// FailMessages makes every client message of msgType fail with the given
+// SimConnect exception code.
+func (s *Server) FailMessages(msgType, code uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[msgType] = code
+}
+
+// Handle registers a handler that replaces the built-in behavior for msgType.
+func (s *Server) Handle(msgType uint32, fn HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[msgType] = fn
+}
+
+// OnEvent registers a hook invoked whenever the client transmits the named
+// sim event. Hooks typically update scripted data to emulate the sim reacting.
+func (s *Server) OnEvent(name string, fn func(data uint32)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.eventHooks[name] = fn
+}
+
+// Events returns a copy of all transmitted client events, in order.
+func (s *Server) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Event(nil), s.events...)
+}
+
+// Writes returns a copy of all SET_DATA_ON_SIMOBJECT requests, in order.
+func (s *Server) Writes() []Write {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Write(nil), s.writes...)
+}
+
+// Broadcast sends a frame to every connected client.
+func (s *Server) Broadcast(recvType uint32, payload []byte) error {
+	s.mu.Lock()
+	conns := make([]*Conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for _, c := range conns {
+		errs = append(errs, c.Send(recvType, payload))
+	}
+	return errors.Join(errs...)
+}
+
+// Messages returns a copy of all received messages, optionally filtered to
+// the given send types.
+func (s *Server) Messages(msgTypes ...uint32) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Message, 0, len(s.messages))
+	for _, m := range s.messages {
+		if len(msgTypes) == 0 || slices.Contains(msgTypes, m.Type) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Definition returns the SimVar names registered for defID, in order.
+func (s *Server) Definition(defID uint32) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.namesLocked(defID)
+}
+
+// datum is one SimVar of a data definition.
+type datum struct {
+	name     string
+	dataType simconnect.DataType
+}
+
+// namesLocked returns the SimVar names of defID. Caller must hold s.mu.
+func (s *Server) namesLocked(defID uint32) []string {
+	var names []string
+	for _, d := range s.defs[defID] {
+		names = append(names, d.name)
+	}
+	return names
+}
+
+func (s *Server) acceptLoop() {
+	defer s.wg.Done()
+	for {
+		nc, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		c := &Conn{nc: nc, silent: &s.silent}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = nc.Close()
+			return
+		}
+		s.conns[c] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.serve(c)
+	}
+}
+
+func (s *Server) serve(c *Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.stopSubscriptionsLocked(c)
+		delete(s.sysEvents, c)
+		s.mu.Unlock()
+		_ = c.nc.Close()
+	}()
+
+	for {
+		m, err := readMessage(c.nc)
+		if err != nil {
+			return
+		}
+		s.handle(c, m)
+	}
+}
+
+// handle records m and dispatches it to a custom handler or the built-in behavior.
+func (s *Server) handle(c *Conn, m Message) {
+	s.mu.Lock()
+	s.messages = append(s.messages, m)
+	fn := s.handlers[m.Type]
+	code, fail := s.failures[m.Type]
+	s.mu.Unlock()
+
+	if fail {
+		_ = c.SendException(code, m.ID, 0)
+		return
+	}
+	if fn != nil {
+		fn(c, m)
+		return
+	}
+
+	switch m.Type {
+	case simconnect.SendOpen:
+		_ = c.Send(simconnect.RecvOpen, EncodeOpenAck(DefaultAppName, DefaultAppVersionMajor, DefaultSimConnectMajor))
+	case simconnect.SendAddToDataDef:
+		s.handleAddToDataDefinition(c, m)
+	case simconnect.SendClearDataDef:
+		s.handleClearDataDefinition(c, m)
+	case simconnect.SendRequestData:
+		s.handleRequestData(c, m)
+	case simconnect.SendRequestDataByType:
+		s.handleRequestDataByType(c, m)
+	case simconnect.SendSetDataOnSimObject:
+		s.handleSetDataOnSimObject(c, m)
+	case simconnect.SendMapClientEvent:
+		s.handleMapClientEvent(c, m)
+	case simconnect.SendTransmitClientEvent:
+		s.handleTransmitClientEvent(c, m)
+	case simconnect.SendRequestSystemState:
+		s.handleRequestSystemState(c, m)
+	case simconnect.SendSubscribeToSystemEvent:
+		s.handleSubscribeToSystemEvent(c, m)
+	case simconnect.SendRequestFacilitiesList:
+		s.handleRequestFacilitiesList(c, m)
+	case simconnect.SendRequestFacilityData:
+		s.handleRequestFacilityData(c, m)
+	case simconnect.SendWeatherObsAtStation:
+		s.handleWeatherObsAtStation(c, m)
+	case simconnect.SendWeatherObsAtNearest:
+		s.handleWeatherObsAtNearest(c, m)
+	}
+}
+
+func (s *Server) handleAddToDataDefinition(c *Conn, m Message) {
+	if len(m.Payload) < 260 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	defID := binary.LittleEndian.Uint32(m.Payload[0:4])
+	name := cString(m.Payload[4:260])
+	dataType := simconnect.DataTypeFloat64
+	if len(m.Payload) >= 520 {
+		dataType = simconnect.DataType(binary.LittleEndian.Uint32(m.Payload[516:520]))
+	}
+
+	s.mu.Lock()
+	code, rejected := s.rejects[name]
+	if !rejected {
+		s.defs[defID] = append(s.defs[defID], datum{name: name, dataType: dataType})
+	}
+	s.mu.Unlock()
+
+	if rejected {
+		_ = c.SendException(code, m.ID, 0)
+	}
+}
+
+func (s *Server) handleClearDataDefinition(c *Conn, m Message) {
+	if len(m.Payload) < 4 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	s.mu.Lock()
+	delete(s.defs, binary.LittleEndian.Uint32(m.Payload[0:4]))
+	s.mu.Unlock()
+}
+
+func (s *Server) handleRequestData(c *Conn, m Message) {
+	if len(m.Payload) < 12 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	reqID := binary.LittleEndian.Uint32(m.Payload[0:4])
+	defID := binary.LittleEndian.Uint32(m.Payload[4:8])
+	objectID := binary.LittleEndian.Uint32(m.Payload[8:12])
+
+	if len(m.Payload) >= 32 {
+		sub := Subscription{
+			RequestID: reqID,
+			DefID:     defID,
+			ObjectID:  objectID,
+			Period:    simconnect.Period(binary.LittleEndian.Uint32(m.Payload[12:16])),
+			Flags:     binary.LittleEndian.Uint32(m.Payload[16:20]),
+			Origin:    binary.LittleEndian.Uint32(m.Payload[20:24]),
+			Interval:  binary.LittleEndian.Uint32(m.Payload[24:28]),
+			Limit:     binary.LittleEndian.Uint32(m.Payload[28:32]),
+		}
+		if sub.Period != simconnect.PeriodOnce {
+			s.subscribe(c, sub)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	data, ok := s.dataLocked(reqID, defID)
+	defineCount := len(s.defs[defID])
+	s.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	_ = c.Send(simconnect.RecvSimObjectData,
+		EncodeSimObjectData(reqID, objectID, defID, uint32(defineCount), data)) // #nosec G115 -- definition counts are tiny
+}
+
+func (s *Server) handleSetDataOnSimObject(c *Conn, m Message) {
+	if len(m.Payload) < 20 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	defID := binary.LittleEndian.Uint32(m.Payload[0:4])
+
+	s.mu.Lock()
+	vars := s.namesLocked(defID)
+	if len(vars) > 0 {
+		s.writes = append(s.writes, Write{
+			DefID:    defID,
+			ObjectID: binary.LittleEndian.Uint32(m.Payload[4:8]),
+			SimVars:  vars,
+			Data:     append([]byte(nil), m.Payload[20:]...),
+		})
+	}
+	s.mu.Unlock()
+
+	if len(vars) == 0 {
+		_ = c.SendException(simconnect.ExceptionUnrecognizedID, m.ID, 0)
+	}
+}
+
+func (s *Server) handleMapClientEvent(c *Conn, m Message) {
+	if len(m.Payload) < 260 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	eventID := binary.LittleEndian.Uint32(m.Payload[0:4])
+	s.mu.Lock()
+	s.eventNames[eventID] = cString(m.Payload[4:260])
+	s.mu.Unlock()
+}
+
+func (s *Server) handleTransmitClientEvent(c *Conn, m Message) {
+	if len(m.Payload) < 20 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	eventID := binary.LittleEndian.Uint32(m.Payload[4:8])
+	s.mu.Lock()
+	name, mapped := s.eventNames[eventID]
+	ev := Event{
+		Name:     name,
+		ObjectID: binary.LittleEndian.Uint32(m.Payload[0:4]),
+		Data:     binary.LittleEndian.Uint32(m.Payload[8:12]),
+		GroupID:  binary.LittleEndian.Uint32(m.Payload[12:16]),
+	}
+	if mapped {
+		s.events = append(s.events, ev)
+	}
+	hook := s.eventHooks[name]
+	s.mu.Unlock()
+
+	if !mapped {
+		_ = c.SendException(simconnect.ExceptionUnrecognizedID, m.ID, 0)
+		return
+	}
+	if hook != nil {
+		hook(ev.Data)
+	}
+}
+
+func (s *Server) handleRequestSystemState(c *Conn, m Message) {
+	if len(m.Payload) < 4 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	// Only "Sim" has an answer: 1 while a flight runs, which it does until
+	// SimStop is fired.
+	var integer uint32
+	if len(m.Payload) >= 260 && cString(m.Payload[4:260]) == "Sim" {
+		s.mu.Lock()
+		if !s.simStopped {
+			integer = 1
+		}
+		s.mu.Unlock()
+	}
+	_ = c.Send(simconnect.RecvSystemState, EncodeSystemState(binary.LittleEndian.Uint32(m.Payload[0:4]), integer, 0, ""))
+}
+
+// EncodeSystemState builds a SIMCONNECT_RECV_SYSTEM_STATE payload: request ID,
+// integer and float results and a 260-byte string result.
+func EncodeSystemState(requestID, integer uint32, float float32, str string) []byte {
+	buf := make([]byte, 0, 272)
+	buf = binary.LittleEndian.AppendUint32(buf, requestID)
+	buf = binary.LittleEndian.AppendUint32(buf, integer)
+	buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(float))
+	name := make([]byte, 260)
+	copy(name, str)
+	return append(buf, name...)
+}
+
+// EncodeOpenAck builds a SIMCONNECT_RECV_OPEN payload: a 256-byte application
+// name followed by application and SimConnect version quadruples and two
+// reserved fields.
+func EncodeOpenAck(appName string, appMajor, simConnectMajor uint32) []byte {
+	buf := make([]byte, 256, 296)
+	copy(buf, appName)
+	for _, v := range []uint32{appMajor, 0, 0, 0, simConnectMajor, 0, 0, 0, 0, 0} {
+		buf = binary.LittleEndian.AppendUint32(buf, v)
+	}
+	return buf
+}
+
+// EncodeSimObjectData prepends the 28-byte SIMCONNECT_RECV_SIMOBJECT_DATA
+// header to raw SimVar data for a single-entry response.
+func EncodeSimObjectData(requestID, objectID, defineID, defineCount uint32, data []byte) []byte {
+	buf := make([]byte, 0, simObjectDataHeaderSize+len(data))
+	buf = binary.LittleEndian.AppendUint32(buf, requestID)
+	buf = binary.LittleEndian.AppendUint32(buf, objectID)
+	buf = binary.LittleEndian.AppendUint32(buf, defineID)
+	buf = binary.LittleEndian.AppendUint32(buf, 0) // flags
+	buf = binary.LittleEndian.AppendUint32(buf, 1) // entryNumber
+	buf = binary.LittleEndian.AppendUint32(buf, 1) // outOf
+	buf = binary.LittleEndian.AppendUint32(buf, defineCount)
+	return append(buf, data...)
+}
+
+// EncodeException builds a SIMCONNECT_RECV_EXCEPTION payload.
+func EncodeException(code, sendID, index uint32) []byte {
+	buf := make([]byte, 0, 12)
+	buf = binary.LittleEndian.AppendUint32(buf, code)
+	buf = binary.LittleEndian.AppendUint32(buf, sendID)
+	return binary.LittleEndian.AppendUint32(buf, index)
+}
+
+// Float64s packs values as consecutive little-endian float64s.
+func Float64s(vals ...float64) []byte {
+	buf := make([]byte, 0, len(vals)*8)
+	for _, v := range vals {
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+	}
+	return buf
+}
+
+// readMessage reads one client message (16-byte send header + payload).
+func readMessage(r io.Reader) (Message, error) {
+	header := make([]byte, simconnect.SendHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return Message{}, err
+	}
+	size := binary.LittleEndian.Uint32(header[0:4])
+	if size < simconnect.SendHeaderSize {
+		return Message{}, fmt.Errorf("simconnecttest: invalid message size %d", size)
+	}
+	m := Message{
+		Type: binary.LittleEndian.Uint32(header[8:12]) &^ simconnect.SendTypeMask,
+		ID:   binary.LittleEndian.Uint32(header[12:16]),
+	}
+	if n := size - simconnect.SendHeaderSize; n > 0 {
+		m.Payload = make([]byte, n)
+		if _, err := io.ReadFull(r, m.Payload); err != nil {
+			return Message{}, err
+		}
+	}
+	return m, nil
+}
+
+// writeFrame writes a 12-byte receive header followed by payload.
+func writeFrame(w io.Writer, recvType uint32, payload []byte) error {
+	buf := make([]byte, 0, simconnect.RecvHeaderSize+len(payload))
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(simconnect.RecvHeaderSize+len(payload))) // #nosec G115 -- frame sizes are small
+	buf = binary.LittleEndian.AppendUint32(buf, serverProtocolVersion)
+	buf = binary.LittleEndian.AppendUint32(buf, recvType)
+	buf = append(buf, payload...)
+	_, err := w.Write(buf)
+	return err
+}
+
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
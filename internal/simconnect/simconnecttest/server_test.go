@@ -0,0 +1,265 @@
+package simconnecttest_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+)
+
+func connectClient(t *testing.T, srv *simconnecttest.Server) *simconnect.Client {
+	t.Helper()
+	c := simconnect.NewClient(srv.Config())
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	require.NoError(t, c.Connect(ctx))
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestServerAnswersOpen(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+
+	c := connectClient(t, srv)
+
+	h, data, err := c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, simconnect.RecvOpen, h.Type)
+	require.Len(t, data, 296)
+	assert.Equal(t, simconnecttest.DefaultAppName, string(data[:len(simconnecttest.DefaultAppName)]))
+
+	opens := srv.Messages(simconnect.SendOpen)
+	require.Len(t, opens, 1)
+	assert.Equal(t, "simconnecttest-client", string(opens[0].Payload[:len("simconnecttest-client")]))
+}
+
+func TestServerReturnsScriptedData(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.SetFloat64s(100, 47.5)
+
+	c := connectClient(t, srv)
+	_, _, err := c.ReadNext() // OPEN ack
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddToDataDefinition(7, simconnect.PlaneLatitude))
+	require.NoError(t, c.RequestData(7, simconnect.ObjectIDUser, 100))
+
+	h, data, err := c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, simconnect.RecvSimObjectData, h.Type)
+	require.Len(t, data, 28+8)
+	assert.Equal(t, uint32(100), binary.LittleEndian.Uint32(data[0:4]))
+	assert.Equal(t, uint32(7), binary.LittleEndian.Uint32(data[8:12]))
+	assert.Equal(t, uint32(1), binary.LittleEndian.Uint32(data[24:28]), "defineCount")
+	assert.Equal(t, simconnecttest.Float64s(47.5), data[28:])
+
+	assert.Equal(t, []string{"PLANE LATITUDE"}, srv.Definition(7))
+}
+
+func TestServerRejectsSimVar(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.RejectSimVar("PLANE LATITUDE", simconnect.ExceptionNameUnrecognized)
+
+	c := connectClient(t, srv)
+	_, _, err := c.ReadNext() // OPEN ack
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddToDataDefinition(1, simconnect.PlaneLatitude))
+
+	h, data, err := c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, simconnect.RecvException, h.Type)
+	require.Len(t, data, 12)
+	assert.Equal(t, simconnect.ExceptionNameUnrecognized, binary.LittleEndian.Uint32(data[0:4]))
+
+	adds := srv.Messages(simconnect.SendAddToDataDef)
+	require.Len(t, adds, 1)
+	assert.Equal(t, adds[0].ID, binary.LittleEndian.Uint32(data[4:8]), "exception should reference the send ID")
+	assert.Empty(t, srv.Definition(1))
+}
+
+func TestServerFailMessages(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.SetFloat64s(1, 1.0)
+	srv.FailMessages(simconnect.SendRequestData, simconnect.ExceptionUnrecognizedID)
+
+	c := connectClient(t, srv)
+	_, _, err := c.ReadNext() // OPEN ack
+	require.NoError(t, err)
+
+	require.NoError(t, c.RequestData(1, simconnect.ObjectIDUser, 1))
+
+	h, data, err := c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, simconnect.RecvException, h.Type)
+	assert.Equal(t, simconnect.ExceptionUnrecognizedID, binary.LittleEndian.Uint32(data[0:4]))
+}
+
+func TestServerCustomHandlerAndBroadcast(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.Handle(simconnect.SendOpen, func(c *simconnecttest.Conn, _ simconnecttest.Message) {
+		_ = c.Send(simconnect.RecvOpen, simconnecttest.EncodeOpenAck("Custom", 1, 2))
+	})
+
+	c := connectClient(t, srv)
+	_, data, err := c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, "Custom", string(data[:6]))
+
+	require.Eventually(t, func() bool { return srv.ConnCount() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, srv.Broadcast(simconnect.RecvException, simconnecttest.EncodeException(1, 2, 3)))
+
+	h, _, err := c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, simconnect.RecvException, h.Type)
+}
+
+func TestServerCloseClientConnections(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+
+	c := connectClient(t, srv)
+	_, _, err := c.ReadNext() // OPEN ack
+	require.NoError(t, err)
+
+	srv.CloseClientConnections()
+
+	_, _, err = c.ReadNext()
+	assert.Error(t, err)
+	require.Eventually(t, func() bool { return srv.ConnCount() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestServerRecordsTransmittedEvents(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+
+	hooked := make(chan uint32, 1)
+	srv.OnEvent(simconnect.EventHeadingBugSet, func(data uint32) { hooked <- data })
+
+	c := connectClient(t, srv)
+	_, _, err := c.ReadNext() // OPEN ack
+	require.NoError(t, err)
+
+	require.NoError(t, c.TransmitEvent(simconnect.EventHeadingBugSet, 135))
+
+	select {
+	case data := <-hooked:
+		assert.Equal(t, uint32(135), data)
+	case <-time.After(time.Second):
+		t.Fatal("event hook not called")
+	}
+
+	events := srv.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, simconnect.EventHeadingBugSet, events[0].Name)
+	assert.Equal(t, simconnect.ObjectIDUser, events[0].ObjectID)
+	assert.Equal(t, simconnect.GroupIDDefault, events[0].GroupID)
+}
+
+func TestServerRejectsUnmappedEvent(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+
+	c := connectClient(t, srv)
+	_, _, err := c.ReadNext() // OPEN ack
+	require.NoError(t, err)
+
+	require.NoError(t, c.TransmitClientEvent(simconnect.ObjectIDUser, 4242, 0, simconnect.GroupIDDefault, 0))
+
+	h, data, err := c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, simconnect.RecvException, h.Type)
+	assert.Equal(t, simconnect.ExceptionUnrecognizedID, binary.LittleEndian.Uint32(data[0:4]))
+	assert.Empty(t, srv.Events())
+}
+
+func TestServerRecordsWrites(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+
+	c := connectClient(t, srv)
+	_, _, err := c.ReadNext() // OPEN ack
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddToDataDefinition(100, simconnect.KohlsmanSettingHg))
+	require.NoError(t, c.SetDataOnSimObject(100, simconnect.ObjectIDUser, simconnecttest.Float64s(29.92)))
+	require.NoError(t, c.SetDataOnSimObject(101, simconnect.ObjectIDUser, simconnecttest.Float64s(1)))
+
+	h, data, err := c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, simconnect.RecvException, h.Type, "write to an undefined definition should fail")
+	assert.Equal(t, simconnect.ExceptionUnrecognizedID, binary.LittleEndian.Uint32(data[0:4]))
+
+	writes := srv.Writes()
+	require.Len(t, writes, 1)
+	assert.Equal(t, uint32(100), writes[0].DefID)
+	assert.Equal(t, []string{"KOHLSMAN SETTING HG"}, writes[0].SimVars)
+	assert.Equal(t, simconnecttest.Float64s(29.92), writes[0].Data)
+}
+
+func TestServerStreamsPeriodicRequests(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.SetFloat64s(1, 1.0)
+
+	c := connectClient(t, srv)
+	_, _, err := c.ReadNext() // OPEN ack
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddToDataDefinition(1, simconnect.PlaneLatitude))
+	require.NoError(t, c.RequestDataWithOptions(1, simconnect.ObjectIDUser, 1,
+		simconnect.RequestOptions{Period: simconnect.PeriodSimFrame, Limit: 3}))
+
+	for i := 0; i < 3; i++ {
+		h, _, err := c.ReadNext()
+		require.NoError(t, err)
+		assert.Equal(t, simconnect.RecvSimObjectData, h.Type)
+	}
+	require.Eventually(t, func() bool { return len(srv.Subscriptions()) == 0 },
+		time.Second, 5*time.Millisecond, "subscription should end at its limit")
+}
+
+func TestServerChangedFlagAndCancel(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	defer srv.Close()
+	srv.SetFloat64s(1, 1.0)
+
+	c := connectClient(t, srv)
+	_, _, err := c.ReadNext() // OPEN ack
+	require.NoError(t, err)
+
+	require.NoError(t, c.AddToDataDefinition(1, simconnect.PlaneLatitude))
+	require.NoError(t, c.RequestDataWithOptions(1, simconnect.ObjectIDUser, 1, simconnect.RequestOptions{
+		Period: simconnect.PeriodSimFrame,
+		Flags:  simconnect.DataRequestFlagChanged,
+	}))
+
+	_, data, err := c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, simconnecttest.Float64s(1.0), data[28:])
+
+	subs := srv.Subscriptions()
+	require.Len(t, subs, 1)
+	assert.Equal(t, simconnect.PeriodSimFrame, subs[0].Period)
+	assert.Equal(t, simconnect.DataRequestFlagChanged, subs[0].Flags)
+
+	// Unchanged data is not resent; the next frame carries the new value.
+	srv.SetFloat64s(1, 2.0)
+	_, data, err = c.ReadNext()
+	require.NoError(t, err)
+	assert.Equal(t, simconnecttest.Float64s(2.0), data[28:])
+
+	require.NoError(t, c.CancelDataRequest(1, simconnect.ObjectIDUser, 1))
+	require.Eventually(t, func() bool { return len(srv.Subscriptions()) == 0 }, time.Second, 5*time.Millisecond)
+}
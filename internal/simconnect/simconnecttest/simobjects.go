@@ -0,0 +1,80 @@
+package simconnecttest
+
+import (
+	"encoding/binary"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+// simObject is a non-user object reported to REQUEST_DATA_ON_SIMOBJECT_TYPE.
+type simObject struct {
+	id      uint32
+	objType simconnect.SimObjectType
+	values  map[string]any
+}
+
+// AddSimObject adds an object that REQUEST_DATA_ON_SIMOBJECT_TYPE reports for
+// objType and for SimObjectTypeAll, with SimVar values by name. The request
+// radius is ignored: every matching object is in range.
+func (s *Server) AddSimObject(id uint32, objType simconnect.SimObjectType, values map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects = append(s.objects, simObject{id: id, objType: objType, values: values})
+}
+
+// handleRequestDataByType answers with one RecvSimObjectDataByType per
+// matching object, numbered 1 to n, or a single empty reply numbered 0 of 0
+// when nothing matches.
+func (s *Server) handleRequestDataByType(c *Conn, m Message) {
+	if len(m.Payload) < 16 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	reqID := binary.LittleEndian.Uint32(m.Payload[0:4])
+	defID := binary.LittleEndian.Uint32(m.Payload[4:8])
+	objType := simconnect.SimObjectType(binary.LittleEndian.Uint32(m.Payload[12:16]))
+
+	type reply struct {
+		objectID uint32
+		data     []byte
+	}
+	s.mu.Lock()
+	defs := s.defs[defID]
+	var replies []reply
+	for _, obj := range s.objects {
+		if objType != simconnect.SimObjectTypeAll && obj.objType != objType {
+			continue
+		}
+		var data []byte
+		for _, d := range defs {
+			data = append(data, EncodeValue(d.dataType, obj.values[d.name])...)
+		}
+		replies = append(replies, reply{obj.id, data})
+	}
+	s.mu.Unlock()
+
+	defineCount := uint32(len(defs)) // #nosec G115 -- definition counts are tiny
+	if len(replies) == 0 {
+		_ = c.Send(simconnect.RecvSimObjectDataByType, EncodeSimObjectDataByType(reqID, 0, defID, 0, 0, defineCount, nil))
+		return
+	}
+	outOf := uint32(len(replies)) // #nosec G115 -- object counts are tiny
+	for i, r := range replies {
+		_ = c.Send(simconnect.RecvSimObjectDataByType,
+			EncodeSimObjectDataByType(reqID, r.objectID, defID, uint32(i+1), outOf, defineCount, r.data)) // #nosec G115 -- object counts are tiny
+	}
+}
+
+// EncodeSimObjectDataByType prepends the 28-byte header of a
+// SIMCONNECT_RECV_SIMOBJECT_DATA_BYTYPE message, entry of outOf, to raw SimVar data.
+func EncodeSimObjectDataByType(requestID, objectID, defineID, entry, outOf, defineCount uint32, data []byte) []byte {
+	buf := make([]byte, 0, simObjectDataHeaderSize+len(data))
+	buf = binary.LittleEndian.AppendUint32(buf, requestID)
+	buf = binary.LittleEndian.AppendUint32(buf, objectID)
+	buf = binary.LittleEndian.AppendUint32(buf, defineID)
+	buf = binary.LittleEndian.AppendUint32(buf, 0) // flags
+	buf = binary.LittleEndian.AppendUint32(buf, entry)
+	buf = binary.LittleEndian.AppendUint32(buf, outOf)
+	buf = binary.LittleEndian.AppendUint32(buf, defineCount)
+	return append(buf, data...)
+}
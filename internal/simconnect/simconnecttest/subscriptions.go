@@ -0,0 +1,148 @@
+package simconnecttest
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+// Default durations the fake server uses for one SimConnect period. They are
+// much shorter than the real sim's so periodic requests stream quickly in tests.
+const (
+	DefaultFrameTick  = 5 * time.Millisecond  // PERIOD_SIM_FRAME and PERIOD_VISUAL_FRAME
+	DefaultSecondTick = 50 * time.Millisecond // PERIOD_SECOND
+)
+
+// Subscription is a periodic REQUEST_DATA the fake server is streaming.
+type Subscription struct {
+	RequestID uint32
+	DefID     uint32
+	ObjectID  uint32
+	Period    simconnect.Period
+	Flags     uint32
+	Origin    uint32
+	Interval  uint32
+	Limit     uint32
+}
+
+type subscription struct {
+	Subscription
+	stop chan struct{}
+}
+
+// SetPeriodTicks overrides how long one frame and one "second" last for
+// periodic requests started after the call.
+func (s *Server) SetPeriodTicks(frame, second time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.frameTick = frame
+	s.secondTick = second
+}
+
+// Subscriptions returns the active periodic requests across all clients,
+// ordered by request ID.
+func (s *Server) Subscriptions() []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Subscription
+	for _, subs := range s.subs {
+		for _, sub := range subs {
+			out = append(out, sub.Subscription)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RequestID < out[j].RequestID })
+	return out
+}
+
+// subscribe replaces any existing request with the same ID on c. PERIOD_NEVER
+// only cancels. Scripted data is streamed every period; requests without
+// scripted data stay silent. The TAGGED flag is accepted but data is always
+// sent in the untagged layout.
+func (s *Server) subscribe(c *Conn, sub Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.subs[c][sub.RequestID]; ok {
+		close(old.stop)
+		delete(s.subs[c], sub.RequestID)
+	}
+	if sub.Period == simconnect.PeriodNever || s.closed {
+		return
+	}
+
+	tick := s.frameTick
+	if sub.Period == simconnect.PeriodSecond {
+		tick = s.secondTick
+	}
+	st := &subscription{Subscription: sub, stop: make(chan struct{})}
+	if s.subs[c] == nil {
+		s.subs[c] = make(map[uint32]*subscription)
+	}
+	s.subs[c][sub.RequestID] = st
+
+	s.wg.Add(1)
+	go s.stream(c, st, tick)
+}
+
+// stopSubscriptionsLocked stops every stream for c. Caller must hold s.mu.
+func (s *Server) stopSubscriptionsLocked(c *Conn) {
+	for _, sub := range s.subs[c] {
+		close(sub.stop)
+	}
+	delete(s.subs, c)
+}
+
+func (s *Server) stream(c *Conn, sub *subscription, tick time.Duration) {
+	defer s.wg.Done()
+
+	if sub.Origin > 0 {
+		select {
+		case <-sub.stop:
+			return
+		case <-time.After(tick * time.Duration(sub.Origin)):
+		}
+	}
+
+	ticker := time.NewTicker(tick * time.Duration(sub.Interval+1))
+	defer ticker.Stop()
+
+	var last []byte
+	var sent uint32
+	for {
+		s.mu.Lock()
+		data, ok := s.dataLocked(sub.RequestID, sub.DefID)
+		defineCount := len(s.defs[sub.DefID])
+		s.mu.Unlock()
+
+		changedOnly := sub.Flags&simconnect.DataRequestFlagChanged != 0
+		if ok && (!changedOnly || !bytes.Equal(data, last)) {
+			if err := c.Send(simconnect.RecvSimObjectData, EncodeSimObjectData(
+				sub.RequestID, sub.ObjectID, sub.DefID, uint32(defineCount), data)); err != nil { // #nosec G115 -- definition counts are tiny
+				return
+			}
+			last = data
+			sent++
+			if sub.Limit > 0 && sent >= sub.Limit {
+				s.finish(c, sub)
+				return
+			}
+		}
+
+		select {
+		case <-sub.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// finish removes a subscription that reached its limit.
+func (s *Server) finish(c *Conn, sub *subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subs[c][sub.RequestID] == sub {
+		delete(s.subs[c], sub.RequestID)
+	}
+}
@@ -0,0 +1,85 @@
+package simconnecttest
+
+import (
+	"encoding/binary"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+// SystemEventSubscriptions returns the system events clients subscribed to,
+// mapped to the client event ID of the most recent subscription.
+func (s *Server) SystemEventSubscriptions() map[string]uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]uint32)
+	for _, events := range s.sysEvents {
+		for name, id := range events {
+			out[name] = id
+		}
+	}
+	return out
+}
+
+// FireSystemEvent sends the named system event to every client subscribed to
+// it, as RecvEventFilename when filename is set and RecvEvent otherwise.
+// Firing SimStop or SimStart also changes the reply to the "Sim" system state.
+func (s *Server) FireSystemEvent(name string, data uint32, filename string) {
+	s.mu.Lock()
+	switch name {
+	case simconnect.SystemEventSimStop:
+		s.simStopped = true
+	case simconnect.SystemEventSimStart:
+		s.simStopped = false
+	}
+	type target struct {
+		c  *Conn
+		id uint32
+	}
+	var targets []target
+	for c, events := range s.sysEvents {
+		if id, ok := events[name]; ok {
+			targets = append(targets, target{c, id})
+		}
+	}
+	s.mu.Unlock()
+
+	for _, t := range targets {
+		if filename != "" {
+			_ = t.c.Send(simconnect.RecvEventFilename, EncodeEventFilename(t.id, data, filename))
+		} else {
+			_ = t.c.Send(simconnect.RecvEvent, EncodeEvent(t.id, data))
+		}
+	}
+}
+
+func (s *Server) handleSubscribeToSystemEvent(c *Conn, m Message) {
+	if len(m.Payload) < 260 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sysEvents[c] == nil {
+		s.sysEvents[c] = make(map[string]uint32)
+	}
+	s.sysEvents[c][cString(m.Payload[4:260])] = binary.LittleEndian.Uint32(m.Payload[0:4])
+}
+
+// EncodeEvent builds a SIMCONNECT_RECV_EVENT payload for a system event,
+// which has no notification group.
+func EncodeEvent(eventID, data uint32) []byte {
+	buf := make([]byte, 0, 12)
+	buf = binary.LittleEndian.AppendUint32(buf, 0xffffffff) // SIMCONNECT_UNUSED group
+	buf = binary.LittleEndian.AppendUint32(buf, eventID)
+	return binary.LittleEndian.AppendUint32(buf, data)
+}
+
+// EncodeEventFilename builds a SIMCONNECT_RECV_EVENT_FILENAME payload: the
+// event, a 260-byte file name and a flags field.
+func EncodeEventFilename(eventID, data uint32, filename string) []byte {
+	buf := EncodeEvent(eventID, data)
+	name := make([]byte, 260)
+	copy(name, filename)
+	buf = append(buf, name...)
+	return binary.LittleEndian.AppendUint32(buf, 0) // flags
+}
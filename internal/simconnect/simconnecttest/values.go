@@ -0,0 +1,104 @@
+package simconnecttest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+// EncodeValue encodes v as a SimConnect data block value of type dt. v may be
+// any Go number or bool for numeric types, a string for string types, or the
+// matching simconnect struct (LatLonAlt, XYZ, Waypoint, InitPosition,
+// MarkerState). A nil v encodes the zero value. Fixed-length strings are
+// truncated to leave room for their NUL terminator. It panics on a mismatch
+// between v and dt.
+func EncodeValue(dt simconnect.DataType, v any) []byte {
+	if v == nil {
+		if dt == simconnect.DataTypeStringV {
+			return []byte{0}
+		}
+		return make([]byte, dt.Size())
+	}
+	switch {
+	case dt.IsString():
+		s, ok := v.(string)
+		if !ok {
+			panic(fmt.Sprintf("simconnecttest: %s value must be a string, got %T", dt, v))
+		}
+		if dt == simconnect.DataTypeStringV {
+			return append([]byte(s), 0)
+		}
+		buf := make([]byte, dt.Size())
+		copy(buf[:len(buf)-1], s)
+		return buf
+	case dt.IsStruct():
+		return encodeStruct(dt, v)
+	}
+
+	f, ok := toFloat64(v)
+	if !ok {
+		panic(fmt.Sprintf("simconnecttest: %s value must be a number, got %T", dt, v))
+	}
+	switch dt {
+	case simconnect.DataTypeInt32:
+		return binary.LittleEndian.AppendUint32(nil, uint32(int32(f))) // #nosec G115 -- test values are small
+	case simconnect.DataTypeInt64:
+		return binary.LittleEndian.AppendUint64(nil, uint64(int64(f))) // #nosec G115 -- two's complement encoding
+	case simconnect.DataTypeFloat32:
+		return binary.LittleEndian.AppendUint32(nil, math.Float32bits(float32(f)))
+	case simconnect.DataTypeFloat64:
+		return Float64s(f)
+	default:
+		panic(fmt.Sprintf("simconnecttest: unsupported data type %s", dt))
+	}
+}
+
+func encodeStruct(dt simconnect.DataType, v any) []byte {
+	want := map[simconnect.DataType]any{
+		simconnect.DataTypeLatLonAlt:    simconnect.LatLonAlt{},
+		simconnect.DataTypeXYZ:          simconnect.XYZ{},
+		simconnect.DataTypeWaypoint:     simconnect.Waypoint{},
+		simconnect.DataTypeInitPosition: simconnect.InitPosition{},
+		simconnect.DataTypeMarkerState:  simconnect.MarkerState{},
+	}[dt]
+	if fmt.Sprintf("%T", want) != fmt.Sprintf("%T", v) {
+		panic(fmt.Sprintf("simconnecttest: %s value must be a %T, got %T", dt, want, v))
+	}
+	if ms, ok := v.(simconnect.MarkerState); ok {
+		buf := make([]byte, 64, 68)
+		copy(buf[:63], ms.Name)
+		return binary.LittleEndian.AppendUint32(buf, ms.State)
+	}
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+		panic(fmt.Sprintf("simconnecttest: encode %s: %v", dt, err))
+	}
+	return buf.Bytes()
+}
+
+func toFloat64(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case bool:
+		if n {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,52 @@
+package simconnecttest
+
+import (
+	"encoding/binary"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+)
+
+// SetObservation scripts the METAR reported for a station. An empty station
+// scripts the reply to WEATHER_REQUEST_OBSERVATION_AT_NEAREST_STATION.
+// Requests for unscripted stations get a WEATHER_UNABLE_TO_GET_OBSERVATION
+// exception, as MSFS gives for most stations.
+func (s *Server) SetObservation(station, metar string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metars[station] = metar
+}
+
+func (s *Server) handleWeatherObsAtStation(c *Conn, m Message) {
+	if len(m.Payload) < 9 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	s.sendObservation(c, m, cString(m.Payload[4:9]))
+}
+
+func (s *Server) handleWeatherObsAtNearest(c *Conn, m Message) {
+	if len(m.Payload) < 12 {
+		_ = c.SendException(simconnect.ExceptionSizeMismatch, m.ID, 0)
+		return
+	}
+	s.sendObservation(c, m, "")
+}
+
+func (s *Server) sendObservation(c *Conn, m Message, station string) {
+	s.mu.Lock()
+	metar, ok := s.metars[station]
+	s.mu.Unlock()
+	if !ok {
+		_ = c.SendException(simconnect.ExceptionNoObservation, m.ID, 1)
+		return
+	}
+	_ = c.Send(simconnect.RecvWeatherObservation, EncodeWeatherObservation(binary.LittleEndian.Uint32(m.Payload[0:4]), metar))
+}
+
+// EncodeWeatherObservation builds a RecvWeatherObservation payload: the
+// request ID followed by the NUL-terminated METAR.
+func EncodeWeatherObservation(requestID uint32, metar string) []byte {
+	buf := binary.LittleEndian.AppendUint32(nil, requestID)
+	buf = append(buf, metar...)
+	return append(buf, 0)
+}
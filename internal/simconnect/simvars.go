@@ -1,20 +1,36 @@
 package simconnect
 
 import (
-	"encoding/binary"
 	"fmt"
-	"math"
+	"sort"
+	"sync"
 )
 
-// DataType represents the SimConnect data type for a SimVar value.
+// DataType represents the SimConnect data type for a SimVar value
+// (SIMCONNECT_DATATYPE).
 type DataType int
 
 const (
-	DataTypeInt32   DataType = 1
-	DataTypeFloat64 DataType = 4
+	DataTypeInt32        DataType = 1
+	DataTypeInt64        DataType = 2
+	DataTypeFloat32      DataType = 3
+	DataTypeFloat64      DataType = 4
+	DataTypeString8      DataType = 5
+	DataTypeString32     DataType = 6
+	DataTypeString64     DataType = 7
+	DataTypeString128    DataType = 8
+	DataTypeString256    DataType = 9
+	DataTypeString260    DataType = 10
+	DataTypeStringV      DataType = 11 // NUL-terminated, variable length
+	DataTypeInitPosition DataType = 12
+	DataTypeMarkerState  DataType = 13
+	DataTypeWaypoint     DataType = 14
+	DataTypeLatLonAlt    DataType = 15
+	DataTypeXYZ          DataType = 16
 )
 
-// SimVarDef defines a SimConnect simulation variable.
+// SimVarDef defines a SimConnect simulation variable. String and struct
+// SimVars have no unit. Size is DataType.Size(), or 0 for DataTypeStringV.
 type SimVarDef struct {
 	Name     string
 	Unit     string
@@ -128,80 +144,124 @@ var (
 		Name: "NUMBER OF ENGINES", Unit: "number",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	ThrottlePosition1 = SimVarDef{
-		Name: "GENERAL ENG THROTTLE LEVER POSITION:1", Unit: "percent",
+	FuelTotalQuantity = SimVarDef{
+		Name: "FUEL TOTAL QUANTITY", Unit: "gallons",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	ThrottlePosition2 = SimVarDef{
-		Name: "GENERAL ENG THROTTLE LEVER POSITION:2", Unit: "percent",
+	FuelLeftQuantity = SimVarDef{
+		Name: "FUEL LEFT QUANTITY", Unit: "gallons",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	EngRPM1 = SimVarDef{
-		Name: "GENERAL ENG RPM:1", Unit: "rpm",
+	FuelRightQuantity = SimVarDef{
+		Name: "FUEL RIGHT QUANTITY", Unit: "gallons",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	EngRPM2 = SimVarDef{
-		Name: "GENERAL ENG RPM:2", Unit: "rpm",
+	FuelTotalQuantityWeight = SimVarDef{
+		Name: "FUEL TOTAL QUANTITY WEIGHT", Unit: "pounds",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	TurbEngN1_1 = SimVarDef{
-		Name: "TURB ENG N1:1", Unit: "percent",
+	FuelTankLeftMainQuantity = SimVarDef{
+		Name: "FUEL TANK LEFT MAIN QUANTITY", Unit: "gallons",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	TurbEngN1_2 = SimVarDef{
-		Name: "TURB ENG N1:2", Unit: "percent",
+	FuelTankRightMainQuantity = SimVarDef{
+		Name: "FUEL TANK RIGHT MAIN QUANTITY", Unit: "gallons",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	TurbEngN2_1 = SimVarDef{
-		Name: "TURB ENG N2:1", Unit: "percent",
+	FuelTankCenterQuantity = SimVarDef{
+		Name: "FUEL TANK CENTER QUANTITY", Unit: "gallons",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	TurbEngN2_2 = SimVarDef{
-		Name: "TURB ENG N2:2", Unit: "percent",
+
+	// Radios
+	ComActiveFrequency1 = SimVarDef{
+		Name: "COM ACTIVE FREQUENCY:1", Unit: "MHz",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	FuelFlow1 = SimVarDef{
-		Name: "ENG FUEL FLOW GPH:1", Unit: "gallons per hour",
+	ComStandbyFrequency1 = SimVarDef{
+		Name: "COM STANDBY FREQUENCY:1", Unit: "MHz",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	FuelFlow2 = SimVarDef{
-		Name: "ENG FUEL FLOW GPH:2", Unit: "gallons per hour",
+	ComActiveFrequency2 = SimVarDef{
+		Name: "COM ACTIVE FREQUENCY:2", Unit: "MHz",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	EGT1 = SimVarDef{
-		Name: "ENG EXHAUST GAS TEMPERATURE:1", Unit: "celsius",
+	ComStandbyFrequency2 = SimVarDef{
+		Name: "COM STANDBY FREQUENCY:2", Unit: "MHz",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	EGT2 = SimVarDef{
-		Name: "ENG EXHAUST GAS TEMPERATURE:2", Unit: "celsius",
+
+	// GPS guidance
+	GPSCourseToSteer = SimVarDef{
+		Name: "GPS COURSE TO STEER", Unit: "degrees",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	OilTemp1 = SimVarDef{
-		Name: "GENERAL ENG OIL TEMPERATURE:1", Unit: "celsius",
+	GPSWPDesiredTrack = SimVarDef{
+		Name: "GPS WP DESIRED TRACK", Unit: "degrees",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	OilTemp2 = SimVarDef{
-		Name: "GENERAL ENG OIL TEMPERATURE:2", Unit: "celsius",
+	GPSWPDistance = SimVarDef{
+		Name: "GPS WP DISTANCE", Unit: "nautical miles",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	OilPressure1 = SimVarDef{
-		Name: "GENERAL ENG OIL PRESSURE:1", Unit: "psi",
+	GPSWPETE = SimVarDef{
+		Name: "GPS WP ETE", Unit: "seconds",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	OilPressure2 = SimVarDef{
-		Name: "GENERAL ENG OIL PRESSURE:2", Unit: "psi",
+	GPSWPNextID = SimVarDef{
+		Name: "GPS WP NEXT ID", DataType: DataTypeString32, Size: 32,
+	}
+
+	// GPS flight plan
+	GPSIsActiveFlightPlan = SimVarDef{
+		Name: "GPS IS ACTIVE FLIGHT PLAN", Unit: "bool",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	FuelTotalQuantity = SimVarDef{
-		Name: "FUEL TOTAL QUANTITY", Unit: "gallons",
+	GPSFlightPlanWPCount = SimVarDef{
+		Name: "GPS FLIGHT PLAN WP COUNT", Unit: "number",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	FuelLeftQuantity = SimVarDef{
-		Name: "FUEL LEFT QUANTITY", Unit: "gallons",
+	GPSFlightPlanWPIndex = SimVarDef{
+		Name: "GPS FLIGHT PLAN WP INDEX", Unit: "number",
 		DataType: DataTypeFloat64, Size: 8,
 	}
-	FuelRightQuantity = SimVarDef{
-		Name: "FUEL RIGHT QUANTITY", Unit: "gallons",
+	GPSWPPrevID = SimVarDef{
+		Name: "GPS WP PREV ID", DataType: DataTypeString32, Size: 32,
+	}
+	GPSWPPrevLat = SimVarDef{
+		Name: "GPS WP PREV LAT", Unit: "degrees",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GPSWPPrevLon = SimVarDef{
+		Name: "GPS WP PREV LON", Unit: "degrees",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GPSWPNextLat = SimVarDef{
+		Name: "GPS WP NEXT LAT", Unit: "degrees",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GPSWPNextLon = SimVarDef{
+		Name: "GPS WP NEXT LON", Unit: "degrees",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GPSWPNextAlt = SimVarDef{
+		Name: "GPS WP NEXT ALT", Unit: "feet",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GPSWPETA = SimVarDef{
+		Name: "GPS WP ETA", Unit: "seconds",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GPSETE = SimVarDef{
+		Name: "GPS ETE", Unit: "seconds",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GPSETA = SimVarDef{
+		Name: "GPS ETA", Unit: "seconds",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GPSWPCrossTrk = SimVarDef{
+		Name: "GPS WP CROSS TRK", Unit: "nautical miles",
 		DataType: DataTypeFloat64, Size: 8,
 	}
 
@@ -238,6 +298,14 @@ var (
 		Name: "ZULU TIME", Unit: "seconds",
 		DataType: DataTypeFloat64, Size: 8,
 	}
+	SeaLevelPressure = SimVarDef{
+		Name: "SEA LEVEL PRESSURE", Unit: "millibars",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	ZuluDayOfMonth = SimVarDef{
+		Name: "ZULU DAY OF MONTH", Unit: "number",
+		DataType: DataTypeFloat64, Size: 8,
+	}
 
 	// Autopilot
 	APMaster = SimVarDef{
@@ -288,17 +356,213 @@ var (
 		Name: "AUTOPILOT AIRSPEED HOLD VAR", Unit: "knots",
 		DataType: DataTypeFloat64, Size: 8,
 	}
+
+	// Simulation state
+	SimOnGround = SimVarDef{
+		Name: "SIM ON GROUND", Unit: "bool",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	IsUserSim = SimVarDef{
+		Name: "IS USER SIM", Unit: "bool",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+
+	// Aircraft identity and design limits
+	Title = SimVarDef{
+		Name: "TITLE", DataType: DataTypeString256, Size: 256,
+	}
+	ATCID = SimVarDef{
+		Name: "ATC ID", DataType: DataTypeString32, Size: 32,
+	}
+	ATCAirline = SimVarDef{
+		Name: "ATC AIRLINE", DataType: DataTypeString64, Size: 64,
+	}
+	ATCFlightNumber = SimVarDef{
+		Name: "ATC FLIGHT NUMBER", DataType: DataTypeString8, Size: 8,
+	}
+	ATCModel = SimVarDef{
+		Name: "ATC MODEL", DataType: DataTypeString32, Size: 32,
+	}
+	Category = SimVarDef{
+		Name: "CATEGORY", DataType: DataTypeString32, Size: 32,
+	}
+	EngineType = SimVarDef{
+		Name: "ENGINE TYPE", Unit: "enum",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	MaxGrossWeight = SimVarDef{
+		Name: "MAX GROSS WEIGHT", Unit: "pounds",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	DesignSpeedVS0 = SimVarDef{
+		Name: "DESIGN SPEED VS0", Unit: "knots",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	DesignSpeedVS1 = SimVarDef{
+		Name: "DESIGN SPEED VS1", Unit: "knots",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	DesignSpeedVC = SimVarDef{
+		Name: "DESIGN SPEED VC", Unit: "knots",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	// AirspeedBarberPole is the maximum operating speed (Vmo) at the current
+	// altitude; there is no fixed design Vmo SimVar.
+	AirspeedBarberPole = SimVarDef{
+		Name: "AIRSPEED BARBER POLE", Unit: "knots",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+
+	// Flight controls and configuration
+	ElevatorPosition = SimVarDef{
+		Name: "ELEVATOR POSITION", Unit: "position",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	AileronPosition = SimVarDef{
+		Name: "AILERON POSITION", Unit: "position",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	RudderPosition = SimVarDef{
+		Name: "RUDDER POSITION", Unit: "position",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	ElevatorTrimPosition = SimVarDef{
+		Name: "ELEVATOR TRIM POSITION", Unit: "degrees",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	AileronTrimPct = SimVarDef{
+		Name: "AILERON TRIM PCT", Unit: "percent",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	RudderTrimPct = SimVarDef{
+		Name: "RUDDER TRIM PCT", Unit: "percent",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	FlapsHandleIndex = SimVarDef{
+		Name: "FLAPS HANDLE INDEX", Unit: "number",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	FlapsHandlePercent = SimVarDef{
+		Name: "FLAPS HANDLE PERCENT", Unit: "percent",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	SpoilersHandlePosition = SimVarDef{
+		Name: "SPOILERS HANDLE POSITION", Unit: "percent",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	SpoilersArmed = SimVarDef{
+		Name: "SPOILERS ARMED", Unit: "bool",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GearHandlePosition = SimVarDef{
+		Name: "GEAR HANDLE POSITION", Unit: "bool",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GearCenterPosition = SimVarDef{
+		Name: "GEAR POSITION:0", Unit: "percent",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GearLeftPosition = SimVarDef{
+		Name: "GEAR POSITION:1", Unit: "percent",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	GearRightPosition = SimVarDef{
+		Name: "GEAR POSITION:2", Unit: "percent",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	BrakeParkingPosition = SimVarDef{
+		Name: "BRAKE PARKING POSITION", Unit: "bool",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+
+	// Electrical
+	ElectricalMasterBattery = SimVarDef{
+		Name: "ELECTRICAL MASTER BATTERY", Unit: "bool",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	ElectricalMainBusVoltage = SimVarDef{
+		Name: "ELECTRICAL MAIN BUS VOLTAGE", Unit: "volts",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	ElectricalBatteryVoltage = SimVarDef{
+		Name: "ELECTRICAL BATTERY VOLTAGE", Unit: "volts",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	ElectricalBatteryLoad = SimVarDef{
+		Name: "ELECTRICAL BATTERY LOAD", Unit: "amperes",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	ElectricalGenAltBusVoltage1 = SimVarDef{
+		Name: "ELECTRICAL GENALT BUS VOLTAGE:1", Unit: "volts",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	ElectricalGenAltBusVoltage2 = SimVarDef{
+		Name: "ELECTRICAL GENALT BUS VOLTAGE:2", Unit: "volts",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+
+	// Hydraulics
+	HydraulicPressure1 = SimVarDef{
+		Name: "HYDRAULIC PRESSURE:1", Unit: "psi",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	HydraulicPressure2 = SimVarDef{
+		Name: "HYDRAULIC PRESSURE:2", Unit: "psi",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	HydraulicPressure3 = SimVarDef{
+		Name: "HYDRAULIC PRESSURE:3", Unit: "psi",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+
+	// Pressurization
+	PressurizationCabinAltitude = SimVarDef{
+		Name: "PRESSURIZATION CABIN ALTITUDE", Unit: "feet",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	PressurizationCabinAltitudeRate = SimVarDef{
+		Name: "PRESSURIZATION CABIN ALTITUDE RATE", Unit: "feet per minute",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	PressurizationPressureDifferential = SimVarDef{
+		Name: "PRESSURIZATION PRESSURE DIFFERENTIAL", Unit: "psi",
+		DataType: DataTypeFloat64, Size: 8,
+	}
+	PressurizationDumpSwitch = SimVarDef{
+		Name: "PRESSURIZATION DUMP SWITCH", Unit: "bool",
+		DataType: DataTypeFloat64, Size: 8,
+	}
 )
 
-// SimVarRegistry holds the allowlist of valid SimVars.
+// SimVarRegistry holds the allowlist of valid SimVars and the subset that
+// may be written with SetDataOnSimObject. It also tracks SimVars the
+// simulator rejected on the current connection.
 type SimVarRegistry struct {
-	vars map[string]SimVarDef
+	vars     map[string]SimVarDef
+	writable map[string]bool
+
+	mu       sync.RWMutex
+	rejected map[rejectedKey]RejectedSimVar
+}
+
+// RejectedSimVar is a SimVar the simulator refused to add to a data definition.
+type RejectedSimVar struct {
+	Name      string
+	DefID     uint32
+	Exception *Exception
+}
+
+type rejectedKey struct {
+	defID uint32
+	name  string
 }
 
 // NewSimVarRegistry creates a registry with all known SimVars.
 func NewSimVarRegistry() *SimVarRegistry {
 	r := &SimVarRegistry{
-		vars: make(map[string]SimVarDef),
+		vars:     make(map[string]SimVarDef),
+		writable: make(map[string]bool),
+		rejected: make(map[rejectedKey]RejectedSimVar),
 	}
 	for _, v := range []SimVarDef{
 		// Position
@@ -309,20 +573,56 @@ func NewSimVarRegistry() *SimVarRegistry {
 		IndicatedAltitude, KohlsmanSettingHg, AirspeedMach,
 		HeadingIndicator, TurnIndicatorRate, TurnCoordinatorBall,
 		// Engine data
-		NumberOfEngines, ThrottlePosition1, ThrottlePosition2,
-		EngRPM1, EngRPM2, TurbEngN1_1, TurbEngN1_2, TurbEngN2_1, TurbEngN2_2,
-		FuelFlow1, FuelFlow2, EGT1, EGT2, OilTemp1, OilTemp2,
-		OilPressure1, OilPressure2, FuelTotalQuantity, FuelLeftQuantity, FuelRightQuantity,
+		NumberOfEngines, FuelTotalQuantity, FuelLeftQuantity, FuelRightQuantity,
+		FuelTankLeftMainQuantity, FuelTankRightMainQuantity, FuelTankCenterQuantity, FuelTotalQuantityWeight,
+		// Radios
+		ComActiveFrequency1, ComStandbyFrequency1, ComActiveFrequency2, ComStandbyFrequency2,
+		// GPS guidance
+		GPSCourseToSteer, GPSWPDesiredTrack, GPSWPDistance, GPSWPETE, GPSWPNextID,
+		// GPS flight plan
+		GPSIsActiveFlightPlan, GPSFlightPlanWPCount, GPSFlightPlanWPIndex,
+		GPSWPPrevID, GPSWPPrevLat, GPSWPPrevLon, GPSWPNextLat, GPSWPNextLon, GPSWPNextAlt,
+		GPSWPETA, GPSETE, GPSETA, GPSWPCrossTrk,
 		// Environment
 		AmbientWindVelocity, AmbientWindDirection, AmbientTemperature,
 		AmbientPressure, AmbientVisibility, AmbientPrecipState, LocalTime, ZuluTime,
+		SeaLevelPressure, ZuluDayOfMonth,
 		// Autopilot
 		APMaster, APHeadingLock, APNav1Lock, APApproachHold,
 		APAltitudeLock, APVerticalHold, APAirspeedHold, APFlightDirector,
 		APHeadingLockDir, APAltitudeLockVar, APVerticalHoldVar, APAirspeedHoldVar,
+		// Simulation state
+		SimOnGround, IsUserSim,
+		// Aircraft
+		Title, ATCID, ATCAirline, ATCFlightNumber, ATCModel, Category, EngineType,
+		MaxGrossWeight, DesignSpeedVS0, DesignSpeedVS1, DesignSpeedVC, AirspeedBarberPole,
+		// Flight controls
+		ElevatorPosition, AileronPosition, RudderPosition,
+		ElevatorTrimPosition, AileronTrimPct, RudderTrimPct,
+		FlapsHandleIndex, FlapsHandlePercent, SpoilersHandlePosition, SpoilersArmed,
+		GearHandlePosition, GearCenterPosition, GearLeftPosition, GearRightPosition,
+		BrakeParkingPosition,
+		// Systems
+		ElectricalMasterBattery, ElectricalMainBusVoltage, ElectricalBatteryVoltage, ElectricalBatteryLoad,
+		ElectricalGenAltBusVoltage1, ElectricalGenAltBusVoltage2,
+		HydraulicPressure1, HydraulicPressure2, HydraulicPressure3,
+		PressurizationCabinAltitude, PressurizationCabinAltitudeRate,
+		PressurizationPressureDifferential, PressurizationDumpSwitch,
 	} {
 		r.vars[v.Name] = v
 	}
+	for _, v := range EngineSimVars {
+		r.vars[v.Name] = v
+	}
+	for _, v := range NavigationSimVars {
+		r.vars[v.Name] = v
+	}
+	for _, v := range QuerySimVars {
+		r.vars[v.Name] = v
+	}
+	for _, v := range WritableSimVars {
+		r.writable[v.Name] = true
+	}
 	return r
 }
 
@@ -340,21 +640,61 @@ func (r *SimVarRegistry) Validate(name string) error {
 	return nil
 }
 
-// ParseSimVarValue decodes raw bytes into a typed value based on the DataType.
-func ParseSimVarValue(data []byte, dt DataType) (any, error) {
-	switch dt {
-	case DataTypeFloat64:
-		if len(data) < 8 {
-			return nil, fmt.Errorf("float64 requires 8 bytes, got %d", len(data))
-		}
-		bits := binary.LittleEndian.Uint64(data[:8])
-		return math.Float64frombits(bits), nil
-	case DataTypeInt32:
-		if len(data) < 4 {
-			return nil, fmt.Errorf("int32 requires 4 bytes, got %d", len(data))
-		}
-		return int32(binary.LittleEndian.Uint32(data[:4])), nil // #nosec G115 -- intentional reinterpretation of binary-encoded signed int32
-	default:
-		return nil, fmt.Errorf("unsupported data type: %d", dt)
+// ValidateWritable checks if a SimVar name is in the allowlist and may be written.
+func (r *SimVarRegistry) ValidateWritable(name string) error {
+	if err := r.Validate(name); err != nil {
+		return err
+	}
+	if !r.writable[name] {
+		return fmt.Errorf("%w: %s", ErrSimVarNotWritable, name)
 	}
+	return nil
+}
+
+// MarkRejected flags a SimVar the simulator rejected for a data definition.
+func (r *SimVarRegistry) MarkRejected(name string, defID uint32, exc *Exception) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rejected[rejectedKey{defID, name}] = RejectedSimVar{Name: name, DefID: defID, Exception: exc}
+}
+
+// IsRejected reports whether the SimVar was rejected for the data definition.
+func (r *SimVarRegistry) IsRejected(defID uint32, name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.rejected[rejectedKey{defID, name}]
+	return ok
+}
+
+// Rejected returns all rejected SimVars ordered by definition ID and name.
+func (r *SimVarRegistry) Rejected() []RejectedSimVar {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]RejectedSimVar, 0, len(r.rejected))
+	for _, rv := range r.rejected {
+		out = append(out, rv)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].DefID != out[j].DefID {
+			return out[i].DefID < out[j].DefID
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// ClearRejected forgets all rejections, e.g. when a new connection re-registers
+// every definition.
+func (r *SimVarRegistry) ClearRejected() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	clear(r.rejected)
+}
+
+// ParseSimVarValue decodes raw bytes into a typed value based on the DataType:
+// int32, int64, float32, float64, string, or one of the struct types such as
+// LatLonAlt. Trailing bytes are ignored.
+func ParseSimVarValue(data []byte, dt DataType) (any, error) {
+	v, _, err := decodeValue(data, dt)
+	return v, err
 }
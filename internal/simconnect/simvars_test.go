@@ -118,6 +118,96 @@ func TestSimVarRegistry(t *testing.T) {
 	})
 }
 
+func TestSimVarRegistryRejected(t *testing.T) {
+	registry := NewSimVarRegistry()
+	exc := &Exception{Code: ExceptionNameUnrecognized}
+	engRPM2 := indexedDef("GENERAL ENG RPM", 2)
+
+	registry.MarkRejected(engRPM2.Name, DefIDEngine, exc)
+	registry.MarkRejected(VerticalSpeed.Name, DefIDInstruments, exc)
+
+	assert.True(t, registry.IsRejected(DefIDEngine, engRPM2.Name))
+	assert.False(t, registry.IsRejected(DefIDPosition, VerticalSpeed.Name), "rejection is per definition")
+	assert.Equal(t, []RejectedSimVar{
+		{Name: VerticalSpeed.Name, DefID: DefIDInstruments, Exception: exc},
+		{Name: engRPM2.Name, DefID: DefIDEngine, Exception: exc},
+	}, registry.Rejected())
+
+	registry.ClearRejected()
+	assert.Empty(t, registry.Rejected())
+}
+
+func TestSimVarRegistryResolve(t *testing.T) {
+	registry := NewSimVarRegistry()
+	tests := []struct {
+		name     string
+		simvar   string
+		unit     string
+		wantName string
+		wantUnit string
+		wantErr  error
+	}{
+		{name: "polled simvar", simvar: "PLANE ALTITUDE", wantName: "PLANE ALTITUDE", wantUnit: "feet"},
+		{name: "case and space insensitive", simvar: " plane altitude ", wantName: "PLANE ALTITUDE", wantUnit: "feet"},
+		{name: "query-only simvar", simvar: "SIM ON GROUND", wantName: "SIM ON GROUND", wantUnit: "bool"},
+		{name: "indexed", simvar: "GENERAL ENG RPM:3", wantName: "GENERAL ENG RPM:3", wantUnit: "rpm"},
+		{name: "unit override", simvar: "PLANE ALTITUDE", unit: "METERS", wantName: "PLANE ALTITUDE", wantUnit: "meters"},
+		{name: "transponder in Bco16", simvar: "TRANSPONDER CODE:1", wantName: "TRANSPONDER CODE:1", wantUnit: "Bco16"},
+		{name: "Bco16 override", simvar: "TRANSPONDER CODE:1", unit: "bco16", wantName: "TRANSPONDER CODE:1", wantUnit: "Bco16"},
+		{name: "index out of range", simvar: "GENERAL ENG RPM:5", wantErr: ErrInvalidSimVar},
+		{name: "index not a number", simvar: "GENERAL ENG RPM:x", wantErr: ErrInvalidSimVar},
+		{name: "missing index", simvar: "GENERAL ENG RPM", wantErr: ErrInvalidSimVar},
+		{name: "unknown", simvar: "PLANE SELF DESTRUCT", wantErr: ErrInvalidSimVar},
+		{name: "unknown unit", simvar: "PLANE ALTITUDE", unit: "furlongs", wantErr: ErrInvalidUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, err := registry.Resolve(tt.simvar, tt.unit)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, def.Name)
+			assert.Equal(t, tt.wantUnit, def.Unit)
+			assert.Equal(t, DataTypeFloat64, def.DataType)
+			assert.Equal(t, 8, def.Size)
+		})
+	}
+}
+
+func TestSimVarRegistryValidateWritable(t *testing.T) {
+	registry := NewSimVarRegistry()
+	tests := []struct {
+		name    string
+		simvar  string
+		wantErr error
+	}{
+		{"altimeter setting is writable", "KOHLSMAN SETTING HG", nil},
+		{"fuel tank is writable", "FUEL TANK LEFT MAIN QUANTITY", nil},
+		{"com standby is writable", "COM STANDBY FREQUENCY:1", nil},
+		{"read-only var rejected", "PLANE LATITUDE", ErrSimVarNotWritable},
+		{"unknown var rejected", "NOT_A_REAL_VAR", ErrInvalidSimVar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.ValidateWritable(tt.simvar)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWritableSimVarsAreRegistered(t *testing.T) {
+	registry := NewSimVarRegistry()
+	for _, sv := range WritableSimVars {
+		assert.NoError(t, registry.Validate(sv.Name), sv.Name)
+	}
+}
+
 func TestPlaneLatitudeDefinition(t *testing.T) {
 	assert.Equal(t, "PLANE LATITUDE", PlaneLatitude.Name)
 	assert.Equal(t, "degrees", PlaneLatitude.Unit)
@@ -170,13 +260,22 @@ func TestInstrumentsSimVars(t *testing.T) {
 }
 
 func TestEngineSimVars(t *testing.T) {
-	assert.Len(t, EngineSimVars, 20)
+	assert.Len(t, EngineSimVars, 44)
 	assert.Equal(t, NumberOfEngines, EngineSimVars[0])
-	assert.Equal(t, FuelRightQuantity, EngineSimVars[19])
+	assert.Equal(t, "GENERAL ENG THROTTLE LEVER POSITION:1", EngineSimVars[1].Name)
+	assert.Equal(t, "TURB ENG ITT:1", EngineSimVars[7].Name)
+	assert.Equal(t, SimVarDef{Name: "ENG TORQUE:2", Unit: "foot pounds", DataType: DataTypeFloat64, Size: 8}, EngineSimVars[18])
+	assert.Equal(t, "GENERAL ENG OIL PRESSURE:4", EngineSimVars[40].Name)
+	assert.Equal(t, FuelRightQuantity, EngineSimVars[43])
+
+	registry := NewSimVarRegistry()
+	for _, v := range EngineSimVars {
+		assert.NoError(t, registry.Validate(v.Name))
+	}
 }
 
 func TestEnvironmentSimVars(t *testing.T) {
-	assert.Len(t, EnvironmentSimVars, 8)
+	assert.Len(t, EnvironmentSimVars, 10)
 	assert.Equal(t, AmbientWindVelocity, EnvironmentSimVars[0])
 	assert.Equal(t, ZuluTime, EnvironmentSimVars[7])
 }
@@ -0,0 +1,118 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// openPayloadSize is the size of a SIMCONNECT_RECV_OPEN payload: a 256-byte
+// application name, two version quadruples and two reserved fields.
+const openPayloadSize = 296
+
+// String returns the lowercase name of the connection state.
+func (s ConnectionState) String() string {
+	switch s {
+	case StateDisconnected:
+		return "disconnected"
+	case StateConnecting:
+		return "connecting"
+	case StateConnected:
+		return "connected"
+	case StateReconnecting:
+		return "reconnecting"
+	default:
+		return fmt.Sprintf("unknown(%d)", int32(s))
+	}
+}
+
+// Version is a SimConnect major.minor.buildMajor.buildMinor version.
+type Version struct {
+	Major, Minor, BuildMajor, BuildMinor uint32
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.BuildMajor, v.BuildMinor)
+}
+
+// OpenInfo is the simulator's OPEN acknowledgement.
+type OpenInfo struct {
+	AppName           string
+	AppVersion        Version
+	SimConnectVersion Version
+	Received          time.Time
+}
+
+// ParseOpen decodes a SIMCONNECT_RECV_OPEN payload.
+func ParseOpen(data []byte) (OpenInfo, error) {
+	if len(data) < openPayloadSize {
+		return OpenInfo{}, fmt.Errorf("open payload too short: got %d bytes, need %d", len(data), openPayloadSize)
+	}
+	u := func(i int) uint32 { return binary.LittleEndian.Uint32(data[256+4*i:]) }
+	return OpenInfo{
+		AppName:           cString(data[:256]),
+		AppVersion:        Version{u(0), u(1), u(2), u(3)},
+		SimConnectVersion: Version{u(4), u(5), u(6), u(7)},
+	}, nil
+}
+
+// Status is a snapshot of the client's connection history.
+type Status struct {
+	State           ConnectionState
+	ConnectAttempts int64
+	LastConnectedAt time.Time // zero if never connected
+	LastError       error     // most recent connect or read failure; nil if none
+	Open            *OpenInfo // OPEN acknowledgement for the current connection; nil until received
+}
+
+// Status returns the connection state, connect attempts, last error and, once
+// the simulator has acknowledged the connection, its version information.
+func (c *Client) Status() Status {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	st := Status{
+		State:           c.State(),
+		ConnectAttempts: c.attempts,
+		LastConnectedAt: c.lastConnected,
+		LastError:       c.lastErr,
+	}
+	if c.open != nil {
+		info := *c.open
+		st.Open = &info
+	}
+	return st
+}
+
+// recordConnect updates the status after a connect attempt.
+func (c *Client) recordConnect(err error) {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	c.attempts++
+	c.open = nil
+	if err != nil {
+		c.lastErr = err
+		return
+	}
+	c.lastConnected = time.Now()
+}
+
+// recordError remembers a failure that ended or broke the connection.
+func (c *Client) recordError(err error) {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	c.lastErr = err
+}
+
+// handleOpen stores the simulator's OPEN acknowledgement.
+func (c *Client) handleOpen(data []byte) {
+	info, err := ParseOpen(data)
+	if err != nil {
+		c.recordError(err)
+		return
+	}
+	info.Received = time.Now()
+
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	c.open = &info
+}
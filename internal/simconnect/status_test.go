@@ -0,0 +1,45 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOpen(t *testing.T) {
+	data := make([]byte, 256, openPayloadSize)
+	copy(data, "KittyHawk")
+	for _, v := range []uint32{11, 0, 282174, 999, 11, 0, 62651, 3, 0, 0} {
+		data = binary.LittleEndian.AppendUint32(data, v)
+	}
+
+	info, err := ParseOpen(data)
+	require.NoError(t, err)
+	assert.Equal(t, "KittyHawk", info.AppName)
+	assert.Equal(t, Version{11, 0, 282174, 999}, info.AppVersion)
+	assert.Equal(t, "11.0.282174.999", info.AppVersion.String())
+	assert.Equal(t, "11.0.62651.3", info.SimConnectVersion.String())
+}
+
+func TestParseOpenTooShort(t *testing.T) {
+	_, err := ParseOpen(make([]byte, 280))
+	assert.Error(t, err)
+}
+
+func TestConnectionStateString(t *testing.T) {
+	tests := []struct {
+		state ConnectionState
+		want  string
+	}{
+		{StateDisconnected, "disconnected"},
+		{StateConnecting, "connecting"},
+		{StateConnected, "connected"},
+		{StateReconnecting, "reconnecting"},
+		{ConnectionState(9), "unknown(9)"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.state.String())
+	}
+}
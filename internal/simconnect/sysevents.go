@@ -0,0 +1,103 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// System event names for SubscribeToSystemEvent.
+const (
+	SystemEventAircraftLoaded = "AircraftLoaded"
+	SystemEventFlightLoaded   = "FlightLoaded"
+	SystemEventPause          = "Pause"
+	SystemEventCrashed        = "Crashed"
+	SystemEventCrashReset     = "CrashReset"
+	SystemEventSimStart       = "SimStart"
+	SystemEventSimStop        = "SimStop"
+)
+
+// Client event IDs for system event subscriptions. They share the client
+// event ID space with the EventRegistry, whose IDs start at eventIDBase.
+const (
+	SysEventIDAircraftLoaded uint32 = 1
+	SysEventIDFlightLoaded   uint32 = 2
+	SysEventIDPause          uint32 = 3 // Data is 1 when paused, 0 when resumed
+	SysEventIDCrashed        uint32 = 4
+	SysEventIDCrashReset     uint32 = 5
+	SysEventIDSimStart       uint32 = 6 // a flight started running
+	SysEventIDSimStop        uint32 = 7 // the flight stopped, e.g. back to the main menu
+)
+
+// systemStatePayloadSize is the size of a SIMCONNECT_RECV_SYSTEM_STATE
+// payload: request ID, integer and float results and a MAX_PATH string.
+const systemStatePayloadSize = 12 + 260
+
+// eventFilenamePayloadSize is the size of a SIMCONNECT_RECV_EVENT_FILENAME
+// payload: the 12-byte event, a MAX_PATH file name and a flags field.
+const eventFilenamePayloadSize = 12 + 260 + 4
+
+// Event is a SIMCONNECT_RECV_EVENT, or a SIMCONNECT_RECV_EVENT_FILENAME with
+// Filename set.
+type Event struct {
+	GroupID  uint32
+	EventID  uint32
+	Data     uint32
+	Filename string
+}
+
+// ParseEvent decodes a SIMCONNECT_RECV_EVENT payload.
+func ParseEvent(data []byte) (Event, error) {
+	if len(data) < 12 {
+		return Event{}, fmt.Errorf("event payload too short: got %d bytes, need 12", len(data))
+	}
+	return Event{
+		GroupID: binary.LittleEndian.Uint32(data[0:4]),
+		EventID: binary.LittleEndian.Uint32(data[4:8]),
+		Data:    binary.LittleEndian.Uint32(data[8:12]),
+	}, nil
+}
+
+// ParseEventFilename decodes a SIMCONNECT_RECV_EVENT_FILENAME payload, sent
+// for system events such as AircraftLoaded that carry a file name.
+func ParseEventFilename(data []byte) (Event, error) {
+	if len(data) < eventFilenamePayloadSize {
+		return Event{}, fmt.Errorf("event filename payload too short: got %d bytes, need %d", len(data), eventFilenamePayloadSize)
+	}
+	ev, _ := ParseEvent(data)
+	ev.Filename = cString(data[12:272])
+	return ev, nil
+}
+
+// SystemState is a SIMCONNECT_RECV_SYSTEM_STATE reply to RequestSystemState.
+// Which field carries the result depends on the state requested: "Sim" sets
+// Integer to 1 while a flight is running, "AircraftLoaded" sets String.
+type SystemState struct {
+	RequestID uint32
+	Integer   uint32
+	Float     float32
+	String    string
+}
+
+// ParseSystemState decodes a SIMCONNECT_RECV_SYSTEM_STATE payload.
+func ParseSystemState(data []byte) (SystemState, error) {
+	if len(data) < systemStatePayloadSize {
+		return SystemState{}, fmt.Errorf("system state payload too short: got %d bytes, need %d", len(data), systemStatePayloadSize)
+	}
+	return SystemState{
+		RequestID: binary.LittleEndian.Uint32(data[0:4]),
+		Integer:   binary.LittleEndian.Uint32(data[4:8]),
+		Float:     math.Float32frombits(binary.LittleEndian.Uint32(data[8:12])),
+		String:    cString(data[12:systemStatePayloadSize]),
+	}, nil
+}
+
+// SubscribeToSystemEvent sends a SUBSCRIBE_TO_SYSTEM_EVENT message. The
+// simulator then sends RecvEvent or RecvEventFilename with eventID whenever
+// the named system event fires.
+func (c *Client) SubscribeToSystemEvent(eventID uint32, name string) error {
+	payload := binary.LittleEndian.AppendUint32(make([]byte, 0, 260), eventID)
+	buf := make([]byte, 256)
+	copy(buf, name)
+	return c.sendMessage(SendSubscribeToSystemEvent, append(payload, buf...))
+}
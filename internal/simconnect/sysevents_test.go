@@ -0,0 +1,48 @@
+package simconnect
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEventFilename(t *testing.T) {
+	data := binary.LittleEndian.AppendUint32(nil, 0xffffffff)
+	data = binary.LittleEndian.AppendUint32(data, SysEventIDAircraftLoaded)
+	data = binary.LittleEndian.AppendUint32(data, 0)
+	data = append(data, fixedString(`SimObjects\Airplanes\C172\aircraft.cfg`, 260)...)
+	data = binary.LittleEndian.AppendUint32(data, 0)
+
+	ev, err := ParseEventFilename(data)
+	require.NoError(t, err)
+	assert.Equal(t, Event{GroupID: 0xffffffff, EventID: SysEventIDAircraftLoaded, Filename: `SimObjects\Airplanes\C172\aircraft.cfg`}, ev)
+
+	_, err = ParseEventFilename(data[:100])
+	assert.Error(t, err)
+	_, err = ParseEvent(data[:8])
+	assert.Error(t, err)
+}
+
+func TestParseSystemState(t *testing.T) {
+	data := binary.LittleEndian.AppendUint32(nil, ReqIDSimState)
+	data = binary.LittleEndian.AppendUint32(data, 1)
+	data = binary.LittleEndian.AppendUint32(data, math.Float32bits(0.5))
+	data = append(data, fixedString("Sim", 260)...)
+
+	st, err := ParseSystemState(data)
+	require.NoError(t, err)
+	assert.Equal(t, SystemState{RequestID: ReqIDSimState, Integer: 1, Float: 0.5, String: "Sim"}, st)
+
+	_, err = ParseSystemState(data[:12])
+	assert.Error(t, err)
+}
+
+func TestDescribeSubscribeToSystemEvent(t *testing.T) {
+	payload := binary.LittleEndian.AppendUint32(nil, SysEventIDAircraftLoaded)
+	payload = append(payload, fixedString(SystemEventAircraftLoaded, 256)...)
+	m := describeSend(7, SendSubscribeToSystemEvent, payload)
+	assert.Equal(t, `SUBSCRIBE_TO_SYSTEM_EVENT "AircraftLoaded"`, m.String())
+}
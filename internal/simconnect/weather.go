@@ -0,0 +1,127 @@
+package simconnect
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// WeatherObservationAtStation sends a WEATHER_REQUEST_OBSERVATION_AT_STATION
+// message for the station with the given ICAO ident. The reply arrives as a
+// RecvWeatherObservation carrying a METAR.
+func (c *Client) WeatherObservationAtStation(requestID uint32, icao string) error {
+	return c.sendMessage(SendWeatherObsAtStation, encodeWeatherObsAtStation(requestID, icao))
+}
+
+// encodeWeatherObsAtStation builds a WEATHER_REQUEST_OBSERVATION_AT_STATION payload (9 bytes):
+//
+//	int32:   requestID
+//	char[5]: ICAO ident (zero-padded; at most 4 characters, longer idents are cut)
+func encodeWeatherObsAtStation(requestID uint32, icao string) []byte {
+	payload := make([]byte, 0, 9)
+	payload = binary.LittleEndian.AppendUint32(payload, requestID)
+	ident := make([]byte, 5)
+	copy(ident[:4], icao)
+	return append(payload, ident...)
+}
+
+// WeatherObservationAtNearest sends a
+// WEATHER_REQUEST_OBSERVATION_AT_NEAREST_STATION message for the station
+// closest to the given position.
+func (c *Client) WeatherObservationAtNearest(requestID uint32, lat, lon float64) error {
+	return c.sendMessage(SendWeatherObsAtNearest, encodeWeatherObsAtNearest(requestID, lat, lon))
+}
+
+// encodeWeatherObsAtNearest builds a WEATHER_REQUEST_OBSERVATION_AT_NEAREST_STATION payload (12 bytes):
+//
+//	int32:   requestID
+//	float32: latitude, longitude (degrees)
+func encodeWeatherObsAtNearest(requestID uint32, lat, lon float64) []byte {
+	payload := make([]byte, 0, 12)
+	payload = binary.LittleEndian.AppendUint32(payload, requestID)
+	payload = binary.LittleEndian.AppendUint32(payload, math.Float32bits(float32(lat)))
+	return binary.LittleEndian.AppendUint32(payload, math.Float32bits(float32(lon)))
+}
+
+// nextWeatherRequest returns the request ID for the next weather request.
+func (c *Client) nextWeatherRequest() uint32 {
+	return ReqIDWeatherBase + c.weatherSeq.Add(1)%querySlots
+}
+
+// StationObservation returns the METAR the simulator reports for the station
+// with the given ICAO ident. Like ReadSimVars it needs a reader running
+// ReadNext. MSFS no longer serves observations for most stations; when it
+// rejects the request the error matches ErrNoObservation, and when it never
+// answers the call waits until ctx is done.
+func (c *Client) StationObservation(ctx context.Context, icao string) (string, error) {
+	icao = strings.ToUpper(strings.TrimSpace(icao))
+	return c.readObservation(ctx, func(reqID uint32) (uint32, []byte) {
+		return SendWeatherObsAtStation, encodeWeatherObsAtStation(reqID, icao)
+	})
+}
+
+// NearestObservation returns the METAR the simulator reports for the station
+// nearest to the given position, with the same caveats as StationObservation.
+func (c *Client) NearestObservation(ctx context.Context, lat, lon float64) (string, error) {
+	return c.readObservation(ctx, func(reqID uint32) (uint32, []byte) {
+		return SendWeatherObsAtNearest, encodeWeatherObsAtNearest(reqID, lat, lon)
+	})
+}
+
+// readObservation sends the weather request built by encode and waits for
+// its RecvWeatherObservation.
+func (c *Client) readObservation(ctx context.Context, encode func(reqID uint32) (uint32, []byte)) (string, error) {
+	reqID := c.nextWeatherRequest()
+	reply := make(chan []byte, 1)
+	c.pendingMu.Lock()
+	c.replies[reqID] = reply
+	c.pendingMu.Unlock()
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.replies, reqID)
+		c.pendingMu.Unlock()
+	}()
+
+	excs := make(chan *Exception, 1)
+	msgType, payload := encode(reqID)
+	c.mu.Lock()
+	sendID, err := c.sendTrackedLocked(msgType, payload, excs)
+	c.mu.Unlock()
+	defer c.untrackExceptions(sendID)
+	if err != nil {
+		return "", err
+	}
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case exc := <-excs:
+		return "", fmt.Errorf("%w: %w", ErrNoObservation, exc)
+	case data := <-reply:
+		metar := strings.TrimSpace(cString(data))
+		if metar == "" {
+			return "", ErrNoObservation
+		}
+		return metar, nil
+	}
+}
+
+// deliverWeatherObservation hands the METAR of a RecvWeatherObservation to
+// the call waiting on its request ID, if any.
+func (c *Client) deliverWeatherObservation(data []byte) {
+	if len(data) < 4 {
+		return
+	}
+	c.pendingMu.Lock()
+	reply, ok := c.replies[binary.LittleEndian.Uint32(data[0:4])]
+	c.pendingMu.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case reply <- data[4:]:
+	default:
+	}
+}
@@ -0,0 +1,74 @@
+package simconnect_test
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect"
+	"github.com/eytandecker/flightsim-mcp/internal/simconnect/simconnecttest"
+)
+
+func TestStationObservation(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.SetObservation("KSEA", "KSEA 141453Z 18012KT 10SM FEW035 14/08 A3001 ")
+	c := connectReading(t, srv)
+
+	metar, err := c.StationObservation(context.Background(), " ksea")
+	require.NoError(t, err)
+	assert.Equal(t, "KSEA 141453Z 18012KT 10SM FEW035 14/08 A3001", metar)
+
+	msgs := srv.Messages(simconnect.SendWeatherObsAtStation)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "KSEA\x00", string(msgs[0].Payload[4:9]))
+}
+
+func TestStationObservationUnavailable(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	c := connectReading(t, srv)
+
+	_, err := c.StationObservation(context.Background(), "KSEA")
+	require.ErrorIs(t, err, simconnect.ErrNoObservation)
+	require.ErrorIs(t, err, simconnect.ErrException)
+}
+
+func TestStationObservationEmpty(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.SetObservation("KSEA", "")
+	c := connectReading(t, srv)
+
+	_, err := c.StationObservation(context.Background(), "KSEA")
+	require.ErrorIs(t, err, simconnect.ErrNoObservation)
+}
+
+func TestNearestObservation(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.SetObservation("", "KBFI 141453Z 17010KT 9999 SCT020 13/09 Q1017")
+	c := connectReading(t, srv)
+
+	metar, err := c.NearestObservation(context.Background(), 47.5, -122.3)
+	require.NoError(t, err)
+	assert.Equal(t, "KBFI 141453Z 17010KT 9999 SCT020 13/09 Q1017", metar)
+
+	msgs := srv.Messages(simconnect.SendWeatherObsAtNearest)
+	require.Len(t, msgs, 1)
+	require.Len(t, msgs[0].Payload, 12)
+	assert.InDelta(t, 47.5, math.Float32frombits(binary.LittleEndian.Uint32(msgs[0].Payload[4:8])), 1e-5)
+	assert.InDelta(t, -122.3, math.Float32frombits(binary.LittleEndian.Uint32(msgs[0].Payload[8:12])), 1e-5)
+}
+
+func TestNearestObservationTimesOut(t *testing.T) {
+	srv := simconnecttest.NewServer()
+	srv.Handle(simconnect.SendWeatherObsAtNearest, func(*simconnecttest.Conn, simconnecttest.Message) {})
+	c := connectReading(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.NearestObservation(ctx, 47.5, -122.3)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
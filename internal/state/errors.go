@@ -4,3 +4,11 @@ import "errors"
 
 // ErrStale is returned when position data has not been updated within the stale threshold.
 var ErrStale = errors.New("state: position data is stale")
+
+// ErrPaused is returned for live data while the simulator is paused, since
+// the cached values are frozen rather than current.
+var ErrPaused = errors.New("state: simulator is paused")
+
+// ErrInMenu is returned for live data while the simulator is in a menu with
+// no flight running.
+var ErrInMenu = errors.New("state: simulator is in the main menu")
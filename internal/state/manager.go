@@ -9,11 +9,15 @@ import (
 
 // Data group keys for per-group staleness tracking.
 const (
-	GroupPosition    = "position"
-	GroupInstruments = "instruments"
-	GroupEngine      = "engine"
-	GroupEnvironment = "environment"
-	GroupAutopilot   = "autopilot"
+	GroupPosition       = "position"
+	GroupInstruments    = "instruments"
+	GroupEngine         = "engine"
+	GroupEnvironment    = "environment"
+	GroupAutopilot      = "autopilot"
+	GroupAircraft       = "aircraft"
+	GroupFlightControls = "flight_controls"
+	GroupSystems        = "systems"
+	GroupNavigation     = "navigation"
 )
 
 // Manager holds a concurrent-safe cache of all aircraft state data.
@@ -24,8 +28,14 @@ type Manager struct {
 	engine         types.EngineData
 	environment    types.Environment
 	autopilot      types.AutopilotState
+	aircraft       types.AircraftInfo
+	controls       types.FlightControls
+	systems        types.SystemsStatus
+	navigation     types.Navigation
+	lifecycle      types.SimLifecycle
 	lastUpdated    map[string]time.Time
 	staleThreshold time.Duration
+	groupStale     map[string]time.Duration
 }
 
 // NewManager creates a Manager with the given stale threshold.
@@ -34,16 +44,30 @@ func NewManager(staleThreshold time.Duration) *Manager {
 	return &Manager{
 		staleThreshold: staleThreshold,
 		lastUpdated:    make(map[string]time.Time),
+		groupStale:     make(map[string]time.Duration),
 	}
 }
 
+// SetStaleThreshold overrides the stale threshold for one data group, so
+// each group goes stale on its own cadence: quickly for fast groups, without
+// false alarms between polls for slow ones.
+func (m *Manager) SetStaleThreshold(group string, threshold time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.groupStale[group] = threshold
+}
+
 // isStale checks whether the given group's data is stale. Caller must hold at least RLock.
 func (m *Manager) isStale(group string) bool {
 	lu, ok := m.lastUpdated[group]
 	if !ok || lu.IsZero() {
 		return true
 	}
-	if m.staleThreshold > 0 && time.Since(lu) > m.staleThreshold {
+	threshold := m.staleThreshold
+	if d, ok := m.groupStale[group]; ok {
+		threshold = d
+	}
+	if threshold > 0 && time.Since(lu) > threshold {
 		return true
 	}
 	return false
@@ -58,9 +82,13 @@ func (m *Manager) Update(pos types.AircraftPosition) { //nolint:gocritic
 }
 
 // GetPosition returns the cached position, or ErrStale if data is missing or expired.
+// While the simulator is in a menu or paused it returns ErrInMenu or ErrPaused instead.
 func (m *Manager) GetPosition() (types.AircraftPosition, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	if err := m.lifecycleErr(); err != nil {
+		return types.AircraftPosition{}, err
+	}
 	if m.isStale(GroupPosition) {
 		return types.AircraftPosition{}, ErrStale
 	}
@@ -76,9 +104,13 @@ func (m *Manager) UpdateInstruments(inst types.FlightInstruments) { //nolint:goc
 }
 
 // GetInstruments returns the cached instruments, or ErrStale if data is missing or expired.
+// While the simulator is in a menu or paused it returns ErrInMenu or ErrPaused instead.
 func (m *Manager) GetInstruments() (types.FlightInstruments, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	if err := m.lifecycleErr(); err != nil {
+		return types.FlightInstruments{}, err
+	}
 	if m.isStale(GroupInstruments) {
 		return types.FlightInstruments{}, ErrStale
 	}
@@ -94,9 +126,13 @@ func (m *Manager) UpdateEngine(eng types.EngineData) { //nolint:gocritic
 }
 
 // GetEngine returns the cached engine data, or ErrStale if data is missing or expired.
+// While the simulator is in a menu or paused it returns ErrInMenu or ErrPaused instead.
 func (m *Manager) GetEngine() (types.EngineData, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	if err := m.lifecycleErr(); err != nil {
+		return types.EngineData{}, err
+	}
 	if m.isStale(GroupEngine) {
 		return types.EngineData{}, ErrStale
 	}
@@ -112,9 +148,13 @@ func (m *Manager) UpdateEnvironment(env types.Environment) { //nolint:gocritic
 }
 
 // GetEnvironment returns the cached environment, or ErrStale if data is missing or expired.
+// While the simulator is in a menu or paused it returns ErrInMenu or ErrPaused instead.
 func (m *Manager) GetEnvironment() (types.Environment, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	if err := m.lifecycleErr(); err != nil {
+		return types.Environment{}, err
+	}
 	if m.isStale(GroupEnvironment) {
 		return types.Environment{}, ErrStale
 	}
@@ -130,15 +170,131 @@ func (m *Manager) UpdateAutopilot(ap types.AutopilotState) { //nolint:gocritic
 }
 
 // GetAutopilot returns the cached autopilot state, or ErrStale if data is missing or expired.
+// While the simulator is in a menu or paused it returns ErrInMenu or ErrPaused instead.
 func (m *Manager) GetAutopilot() (types.AutopilotState, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	if err := m.lifecycleErr(); err != nil {
+		return types.AutopilotState{}, err
+	}
 	if m.isStale(GroupAutopilot) {
 		return types.AutopilotState{}, ErrStale
 	}
 	return m.autopilot, nil
 }
 
+// UpdateAircraft stores new aircraft identity data.
+func (m *Manager) UpdateAircraft(info types.AircraftInfo) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.aircraft = info
+	m.lastUpdated[GroupAircraft] = time.Now()
+}
+
+// GetAircraftInfo returns the cached aircraft identity, or ErrStale if data is missing or expired.
+func (m *Manager) GetAircraftInfo() (types.AircraftInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.isStale(GroupAircraft) {
+		return types.AircraftInfo{}, ErrStale
+	}
+	return m.aircraft, nil
+}
+
+// UpdateFlightControls stores new flight controls data.
+func (m *Manager) UpdateFlightControls(fc types.FlightControls) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.controls = fc
+	m.lastUpdated[GroupFlightControls] = time.Now()
+}
+
+// GetFlightControls returns the cached flight controls, or ErrStale if data is missing or expired.
+// While the simulator is in a menu or paused it returns ErrInMenu or ErrPaused instead.
+func (m *Manager) GetFlightControls() (types.FlightControls, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.lifecycleErr(); err != nil {
+		return types.FlightControls{}, err
+	}
+	if m.isStale(GroupFlightControls) {
+		return types.FlightControls{}, ErrStale
+	}
+	return m.controls, nil
+}
+
+// UpdateSystems stores new aircraft systems data.
+func (m *Manager) UpdateSystems(sys types.SystemsStatus) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.systems = sys
+	m.lastUpdated[GroupSystems] = time.Now()
+}
+
+// GetSystems returns the cached systems status, or ErrStale if data is missing or expired.
+// While the simulator is in a menu or paused it returns ErrInMenu or ErrPaused instead.
+func (m *Manager) GetSystems() (types.SystemsStatus, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.lifecycleErr(); err != nil {
+		return types.SystemsStatus{}, err
+	}
+	if m.isStale(GroupSystems) {
+		return types.SystemsStatus{}, ErrStale
+	}
+	return m.systems, nil
+}
+
+// UpdateNavigation stores new radio and GPS navigation data.
+func (m *Manager) UpdateNavigation(nav types.Navigation) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.navigation = nav
+	m.lastUpdated[GroupNavigation] = time.Now()
+}
+
+// GetNavigation returns the cached navigation data, or ErrStale if data is missing or expired.
+// While the simulator is in a menu or paused it returns ErrInMenu or ErrPaused instead.
+func (m *Manager) GetNavigation() (types.Navigation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if err := m.lifecycleErr(); err != nil {
+		return types.Navigation{}, err
+	}
+	if m.isStale(GroupNavigation) {
+		return types.Navigation{}, ErrStale
+	}
+	return m.navigation, nil
+}
+
+// UpdateLifecycle stores the simulator lifecycle state.
+func (m *Manager) UpdateLifecycle(l types.SimLifecycle) { //nolint:gocritic
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lifecycle = l
+}
+
+// GetLifecycle returns the simulator lifecycle state. It is the zero value,
+// with Known unset, until the simulator reports it.
+func (m *Manager) GetLifecycle() types.SimLifecycle {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.lifecycle
+}
+
+// lifecycleErr returns ErrInMenu or ErrPaused when live data would be
+// misleading. Aircraft identity stays valid either way. Caller must hold at
+// least RLock.
+func (m *Manager) lifecycleErr() error {
+	switch {
+	case m.lifecycle.Known && !m.lifecycle.Running:
+		return ErrInMenu
+	case m.lifecycle.Paused:
+		return ErrPaused
+	}
+	return nil
+}
+
 // LastUpdated returns the most recent update time across all groups, or zero if never updated.
 func (m *Manager) LastUpdated() time.Time {
 	m.mu.RLock()
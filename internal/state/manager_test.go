@@ -46,13 +46,11 @@ func sampleInstruments() types.FlightInstruments {
 
 func sampleEngine() types.EngineData {
 	return types.EngineData{
-		NumberOfEngines:   2.0,
-		ThrottlePosition1: 85.0,
-		ThrottlePosition2: 85.0,
-		RPM1:              2400.0,
-		RPM2:              2400.0,
-		N1Engine1:         92.0,
-		N1Engine2:         91.5,
+		NumberOfEngines: 2.0,
+		Engines: []types.Engine{
+			{ThrottlePosition: 85.0, RPM: 2400.0, N1: 92.0},
+			{ThrottlePosition: 85.0, RPM: 2400.0, N1: 91.5},
+		},
 		FuelTotalQuantity: 500.0,
 	}
 }
@@ -122,6 +120,34 @@ func TestZeroThresholdNeverStale(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestPerGroupStaleThreshold(t *testing.T) {
+	mgr := NewManager(1 * time.Millisecond)
+	mgr.SetStaleThreshold(GroupEnvironment, 5*time.Second)
+	mgr.Update(samplePosition())
+	mgr.UpdateEnvironment(types.Environment{})
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, err := mgr.GetPosition()
+	assert.ErrorIs(t, err, ErrStale, "position uses the default threshold")
+	_, err = mgr.GetEnvironment()
+	assert.NoError(t, err, "environment uses its own, longer threshold")
+}
+
+func TestFastGroupStaleBeforeDefaultThreshold(t *testing.T) {
+	mgr := NewManager(5 * time.Second)
+	mgr.SetStaleThreshold(GroupPosition, 20*time.Millisecond)
+	mgr.Update(samplePosition())
+	mgr.UpdateEnvironment(types.Environment{})
+
+	time.Sleep(40 * time.Millisecond)
+
+	_, err := mgr.GetPosition()
+	assert.ErrorIs(t, err, ErrStale, "position follows its own, shorter threshold")
+	_, err = mgr.GetEnvironment()
+	assert.NoError(t, err, "environment uses the default threshold")
+}
+
 func TestLastUpdatedZeroBeforeUpdate(t *testing.T) {
 	mgr := NewManager(5 * time.Second)
 	assert.True(t, mgr.LastUpdated().IsZero())
@@ -273,6 +299,126 @@ func TestGetAutopilotReturnsStaleWhenExpired(t *testing.T) {
 	assert.ErrorIs(t, err, ErrStale)
 }
 
+// Aircraft tests
+
+func TestUpdateAndGetAircraftInfo(t *testing.T) {
+	mgr := NewManager(5 * time.Second)
+	_, err := mgr.GetAircraftInfo()
+	require.ErrorIs(t, err, ErrStale)
+
+	info := types.AircraftInfo{Title: "Cessna Skyhawk G1000 Asobo", ATCID: "N172SP", NumberOfEngines: 1}
+	mgr.UpdateAircraft(info)
+
+	got, err := mgr.GetAircraftInfo()
+	require.NoError(t, err)
+	assert.Equal(t, info, got)
+}
+
+// Flight controls tests
+
+func TestUpdateAndGetFlightControls(t *testing.T) {
+	mgr := NewManager(5 * time.Second)
+	_, err := mgr.GetFlightControls()
+	require.ErrorIs(t, err, ErrStale)
+
+	fc := types.FlightControls{FlapsHandleIndex: 2, GearHandle: 1, ParkingBrake: 1}
+	mgr.UpdateFlightControls(fc)
+
+	got, err := mgr.GetFlightControls()
+	require.NoError(t, err)
+	assert.Equal(t, fc, got)
+
+	mgr.UpdateLifecycle(types.SimLifecycle{Known: true, Running: true, Paused: true})
+	_, err = mgr.GetFlightControls()
+	assert.ErrorIs(t, err, ErrPaused)
+}
+
+// Systems tests
+
+func TestUpdateAndGetSystems(t *testing.T) {
+	mgr := NewManager(5 * time.Second)
+	_, err := mgr.GetSystems()
+	require.ErrorIs(t, err, ErrStale)
+
+	sys := types.SystemsStatus{
+		Fuel:       types.FuelSystem{TotalQuantity: 53, TotalWeight: 318},
+		Electrical: types.ElectricalSystem{MasterBattery: 1, MainBusVoltage: 28.1},
+	}
+	mgr.UpdateSystems(sys)
+
+	got, err := mgr.GetSystems()
+	require.NoError(t, err)
+	assert.Equal(t, sys, got)
+
+	mgr.UpdateLifecycle(types.SimLifecycle{Known: true})
+	_, err = mgr.GetSystems()
+	assert.ErrorIs(t, err, ErrInMenu)
+}
+
+// Navigation tests
+
+func TestUpdateAndGetNavigation(t *testing.T) {
+	mgr := NewManager(5 * time.Second)
+	_, err := mgr.GetNavigation()
+	require.ErrorIs(t, err, ErrStale)
+
+	nav := types.Navigation{
+		Nav1:              types.NavRadio{ActiveFrequency: 110.5, HasLocalizer: 1},
+		TransponderCode:   1200,
+		GPSNextWaypointID: "KSEA",
+	}
+	mgr.UpdateNavigation(nav)
+
+	got, err := mgr.GetNavigation()
+	require.NoError(t, err)
+	assert.Equal(t, nav, got)
+
+	mgr.UpdateLifecycle(types.SimLifecycle{Known: true, Running: true, Paused: true})
+	_, err = mgr.GetNavigation()
+	assert.ErrorIs(t, err, ErrPaused)
+}
+
+// Lifecycle tests
+
+func TestLifecycleGatesLiveData(t *testing.T) {
+	tests := []struct {
+		name string
+		life types.SimLifecycle
+		want error
+	}{
+		{"unknown", types.SimLifecycle{}, nil},
+		{"running", types.SimLifecycle{Known: true, Running: true}, nil},
+		{"paused", types.SimLifecycle{Known: true, Running: true, Paused: true}, ErrPaused},
+		{"in menu", types.SimLifecycle{Known: true}, ErrInMenu},
+		{"in menu and paused", types.SimLifecycle{Known: true, Paused: true}, ErrInMenu},
+		{"crashed", types.SimLifecycle{Known: true, Running: true, Crashed: true}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewManager(5 * time.Second)
+			mgr.Update(samplePosition())
+			mgr.UpdateAutopilot(types.AutopilotState{Master: 1})
+			mgr.UpdateAircraft(types.AircraftInfo{Title: "Cessna Skyhawk G1000 Asobo"})
+			mgr.UpdateLifecycle(tt.life)
+
+			_, err := mgr.GetPosition()
+			assert.ErrorIs(t, err, tt.want)
+			_, err = mgr.GetAutopilot()
+			assert.ErrorIs(t, err, tt.want)
+			_, err = mgr.GetAircraftInfo()
+			assert.NoError(t, err, "aircraft identity stays available")
+			assert.Equal(t, tt.life, mgr.GetLifecycle())
+		})
+	}
+}
+
+func TestLifecycleTakesPrecedenceOverStale(t *testing.T) {
+	mgr := NewManager(5 * time.Second)
+	mgr.UpdateLifecycle(types.SimLifecycle{Known: true})
+	_, err := mgr.GetEngine()
+	assert.ErrorIs(t, err, ErrInMenu)
+}
+
 // Cross-group staleness independence
 
 func TestCrossGroupStalenessIndependence(t *testing.T) {
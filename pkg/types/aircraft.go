@@ -15,3 +15,20 @@ type AircraftPosition struct {
 	Pitch          float64
 	Bank           float64
 }
+
+// AircraftInfo identifies the loaded aircraft and holds its design limits.
+type AircraftInfo struct {
+	Title           string
+	ATCID           string
+	ATCAirline      string
+	ATCFlightNumber string
+	ATCModel        string
+	Category        string
+	EngineType      float64 // ENGINE TYPE enum: 0 piston, 1 jet, 2 none, 3 helo turbine, 4 unsupported, 5 turboprop
+	NumberOfEngines float64
+	MaxGrossWeight  float64
+	DesignSpeedVS0  float64
+	DesignSpeedVS1  float64
+	DesignSpeedVC   float64
+	DesignSpeedVMO  float64
+}
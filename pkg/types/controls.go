@@ -0,0 +1,21 @@
+package types
+
+// FlightControls holds control surface positions, trim and the aircraft's
+// configuration: flaps, spoilers, gear and parking brake.
+type FlightControls struct {
+	Elevator           float64 // position, -1 to 1
+	Aileron            float64 // position, -1 to 1
+	Rudder             float64 // position, -1 to 1
+	ElevatorTrim       float64 // degrees
+	AileronTrim        float64 // percent, -100 to 100
+	RudderTrim         float64 // percent, -100 to 100
+	FlapsHandleIndex   float64
+	FlapsHandlePercent float64
+	SpoilersHandle     float64 // percent
+	SpoilersArmed      float64 // bool
+	GearHandle         float64 // bool, 1 when down
+	GearCenter         float64 // percent extended
+	GearLeft           float64 // percent extended
+	GearRight          float64 // percent extended
+	ParkingBrake       float64 // bool
+}
@@ -1,24 +1,27 @@
 package types
 
-// EngineData holds engine performance and fuel data for up to 2 engines.
+// MaxEngines is the most engines EngineData reports.
+const MaxEngines = 4
+
+// Engine holds the readings for one engine.
+type Engine struct {
+	ThrottlePosition float64 // percent
+	RPM              float64
+	N1               float64 // percent
+	N2               float64 // percent
+	FuelFlow         float64 // gallons per hour
+	EGT              float64 // celsius
+	ITT              float64 // celsius, turbines only
+	Torque           float64 // foot-pounds, turboprops and turboshafts only
+	OilTemp          float64 // celsius
+	OilPressure      float64 // psi
+}
+
+// EngineData holds per-engine performance data and fuel quantities.
 type EngineData struct {
-	NumberOfEngines   float64
-	ThrottlePosition1 float64
-	ThrottlePosition2 float64
-	RPM1              float64
-	RPM2              float64
-	N1Engine1         float64
-	N1Engine2         float64
-	N2Engine1         float64
-	N2Engine2         float64
-	FuelFlow1         float64
-	FuelFlow2         float64
-	EGT1              float64
-	EGT2              float64
-	OilTemp1          float64
-	OilTemp2          float64
-	OilPressure1      float64
-	OilPressure2      float64
+	NumberOfEngines float64
+	// Engines holds one entry per engine, NumberOfEngines capped at MaxEngines.
+	Engines           []Engine
 	FuelTotalQuantity float64
 	FuelLeftQuantity  float64
 	FuelRightQuantity float64
@@ -0,0 +1,70 @@
+package types
+
+// Facility is an airport, VOR, NDB or waypoint from the simulator's facility
+// cache, which covers the area around the user aircraft.
+type Facility struct {
+	Ident     string
+	Region    string
+	Latitude  float64
+	Longitude float64
+	Altitude  float64 // meters
+	MagVar    float64 // degrees; not reported for airports
+	Frequency float64 // Hz; VORs and NDBs only
+
+	// VORs only.
+	HasNavSignal     bool
+	HasLocalizer     bool
+	HasGlideSlope    bool
+	HasDME           bool
+	LocalizerHeading float64 // degrees
+	GlideSlopeAngle  float64 // degrees
+}
+
+// Airport holds an airport's facility data with its runways and frequencies.
+type Airport struct {
+	ICAO        string
+	Region      string
+	Name        string
+	Latitude    float64
+	Longitude   float64
+	Altitude    float64 // meters
+	MagVar      float64 // degrees
+	Runways     []Runway
+	Frequencies []AirportFrequency
+}
+
+// Runway is one runway of an airport. Heading is the true heading of the
+// primary end; the secondary end is its reciprocal.
+type Runway struct {
+	Latitude            float64 // runway center
+	Longitude           float64
+	Altitude            float64 // meters
+	Heading             float64 // degrees true
+	Length              float64 // meters
+	Width               float64 // meters
+	Surface             int     // facility SURFACE enum
+	PrimaryNumber       int     // 1-36, or 37-45 for N, NE, E, SE, S, SW, W, NW
+	PrimaryDesignator   int     // 0 none, 1 L, 2 R, 3 C, 4 W, 5 A, 6 B
+	SecondaryNumber     int
+	SecondaryDesignator int
+	PrimaryILS          ILS
+	SecondaryILS        ILS
+}
+
+// ILS is the localizer serving a runway end; Ident is empty when there is none.
+type ILS struct {
+	Ident         string
+	Region        string
+	Name          string
+	Frequency     float64 // Hz
+	Course        float64 // localizer heading, degrees true
+	GlideSlope    float64 // glideslope angle, degrees
+	HasGlideSlope bool
+}
+
+// AirportFrequency is a COM frequency published for an airport.
+type AirportFrequency struct {
+	Type      int     // facility frequency TYPE enum
+	Frequency float64 // Hz
+	Name      string
+}
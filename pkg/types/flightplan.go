@@ -0,0 +1,22 @@
+package types
+
+// FlightPlan holds the progress of the GPS along its flight plan: the active
+// leg, from the previous to the next waypoint, and the time remaining.
+type FlightPlan struct {
+	IsActive             float64 // bool
+	WaypointCount        float64
+	ActiveWaypointIndex  float64 // index of the next waypoint
+	PrevWaypointID       string
+	PrevWaypointLat      float64
+	PrevWaypointLon      float64
+	NextWaypointID       string
+	NextWaypointLat      float64
+	NextWaypointLon      float64
+	NextWaypointAltitude float64 // feet MSL
+	NextWaypointDistance float64 // nautical miles
+	NextWaypointETE      float64 // seconds
+	NextWaypointETA      float64 // seconds since midnight, sim local time
+	DestinationETE       float64 // seconds
+	DestinationETA       float64 // seconds since midnight, sim local time
+	CrossTrackError      float64 // nautical miles off the desired track
+}
@@ -0,0 +1,11 @@
+package types
+
+// SimLifecycle is the simulator's lifecycle state, tracked from system events.
+type SimLifecycle struct {
+	Known        bool // false until the simulator reports whether a flight is running
+	Running      bool // a flight is running; false in the main menu
+	Paused       bool
+	Crashed      bool
+	AircraftFile string // aircraft.cfg of the last AircraftLoaded event
+	FlightFile   string // .FLT file of the last FlightLoaded event
+}
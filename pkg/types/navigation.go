@@ -0,0 +1,40 @@
+package types
+
+// NavRadio holds the tuning and indications of one VOR/ILS receiver.
+type NavRadio struct {
+	ActiveFrequency  float64 // MHz
+	StandbyFrequency float64 // MHz
+	OBS              float64 // degrees
+	Radial           float64 // degrees
+	CDI              float64 // -127 (full left) to 127 (full right)
+	GSI              float64 // -119 (full up) to 119 (full down)
+	ToFrom           float64 // enum: 0 off, 1 to, 2 from
+	DME              float64 // nautical miles
+	HasNav           float64 // bool
+	HasLocalizer     float64 // bool
+	HasGlideSlope    float64 // bool
+}
+
+// Navigation holds the radio stack and GPS guidance.
+type Navigation struct {
+	Nav1 NavRadio
+	Nav2 NavRadio
+
+	ADFFrequency float64 // kHz
+	ADFRadial    float64 // degrees, relative to the aircraft nose
+
+	Com1ActiveFrequency  float64 // MHz
+	Com1StandbyFrequency float64 // MHz
+	Com2ActiveFrequency  float64 // MHz
+	Com2StandbyFrequency float64 // MHz
+
+	// TransponderCode is the squawk as a decimal number whose digits are
+	// the four octal code digits, e.g. 7000.
+	TransponderCode int
+
+	GPSCourseToSteer        float64 // degrees
+	GPSDesiredTrack         float64 // degrees
+	GPSNextWaypointID       string
+	GPSNextWaypointDistance float64 // nautical miles
+	GPSNextWaypointETE      float64 // seconds
+}
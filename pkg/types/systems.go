@@ -0,0 +1,43 @@
+package types
+
+// SystemsStatus holds the aircraft systems read by the systems data group.
+type SystemsStatus struct {
+	Fuel           FuelSystem
+	Electrical     ElectricalSystem
+	Hydraulics     HydraulicSystem
+	Pressurization PressurizationSystem
+}
+
+// FuelSystem holds total and per-tank fuel quantities.
+type FuelSystem struct {
+	TotalQuantity float64 // gallons
+	TotalWeight   float64 // pounds
+	LeftMain      float64 // gallons
+	RightMain     float64 // gallons
+	Center        float64 // gallons
+}
+
+// ElectricalSystem holds battery and bus state.
+type ElectricalSystem struct {
+	MasterBattery     float64 // bool
+	MainBusVoltage    float64 // volts
+	BatteryVoltage    float64 // volts
+	BatteryLoad       float64 // amperes
+	GenAltBusVoltage1 float64 // volts
+	GenAltBusVoltage2 float64 // volts
+}
+
+// HydraulicSystem holds the pressure of up to three hydraulic systems.
+type HydraulicSystem struct {
+	Pressure1 float64 // psi
+	Pressure2 float64 // psi
+	Pressure3 float64 // psi
+}
+
+// PressurizationSystem holds cabin pressurization state.
+type PressurizationSystem struct {
+	CabinAltitude        float64 // feet
+	CabinAltitudeRate    float64 // feet per minute
+	PressureDifferential float64 // psi
+	DumpSwitch           float64 // bool
+}
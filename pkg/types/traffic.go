@@ -0,0 +1,20 @@
+package types
+
+// TrafficObject identifies an AI or multiplayer object near the user aircraft
+// and holds its position and motion.
+type TrafficObject struct {
+	ObjectID        uint32
+	Type            string  // SimConnect object type: "aircraft", "helicopter" or "ground"
+	UserSim         float64 // IS USER SIM: 1 for the user aircraft
+	ATCID           string
+	ATCAirline      string
+	ATCFlightNumber string
+	ATCModel        string
+	Latitude        float64
+	Longitude       float64
+	Altitude        float64 // feet MSL
+	HeadingTrue     float64
+	GroundSpeed     float64 // knots
+	VerticalSpeed   float64 // feet per minute
+	OnGround        float64
+}